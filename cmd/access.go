@@ -0,0 +1,18 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/ForceCLI/force-md/cmd/access"
+)
+
+func init() {
+	accessCmd.AddCommand(access.EffectiveCmd)
+	accessCmd.AddCommand(access.WhoCanAccessCmd)
+	RootCmd.AddCommand(accessCmd)
+}
+
+var accessCmd = &cobra.Command{
+	Use:   "access",
+	Short: "Analyze access granted by profiles and permission sets",
+}
@@ -0,0 +1,127 @@
+package access
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/ForceCLI/force-md/cmd/repo"
+	"github.com/ForceCLI/force-md/repo/access"
+)
+
+var (
+	profileName    string
+	permissionSets []string
+	groups         []string
+	jsonOutput     bool
+)
+
+func init() {
+	EffectiveCmd.Flags().StringVarP(&profileName, "profile", "p", "", "profile name")
+	EffectiveCmd.Flags().StringSliceVarP(&permissionSets, "permissionset", "s", []string{}, "permission set names")
+	EffectiveCmd.Flags().StringSliceVarP(&groups, "group", "g", []string{}, "permission set group names")
+	EffectiveCmd.Flags().BoolVarP(&jsonOutput, "json", "j", false, "output as json")
+}
+
+var EffectiveCmd = &cobra.Command{
+	Use:   "effective [flags] [filename]...",
+	Short: "Show the combined access granted by a profile and permission sets",
+	Long: `
+Show the object, field, user permission, Apex class, Visualforce page, tab, and
+custom permission access granted by a profile, permission sets, and permission
+set groups together.
+
+	The profile, permission sets, and groups are looked up by name in the files
+	passed, or in the --project.  The permission sets in each group are
+	included, less any permissions muted by the group's muting permission set.
+`,
+	Example: `
+$ force-md access effective --project . --profile Standard --permissionset Sales,Reports --group Support
+
+$ force-md access effective --project . --profile Standard --group Support --json
+`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if profileName == "" && len(permissionSets) == 0 && len(groups) == 0 {
+			return errors.New("requires --profile, --permissionset, or --group")
+		}
+		return repo.FilesRequired(cmd, args)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		for _, file := range repo.Files(args) {
+			if _, err := repo.Metadata.Open(file); err != nil {
+				log.Warnf("failed to open %s: %s", file, err.Error())
+			}
+		}
+		a, err := access.Effective(repo.Metadata, profileName, permissionSets, groups)
+		if err != nil {
+			log.Fatal("calculating access failed: " + err.Error())
+		}
+		if jsonOutput {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(a); err != nil {
+				log.Fatal("encoding access failed: " + err.Error())
+			}
+			return
+		}
+		printAccess(a)
+	},
+}
+
+func check(b bool) string {
+	if b {
+		return "✓"
+	}
+	return ""
+}
+
+func printAccess(a access.Access) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Object", "Create", "Read", "Edit", "Delete", "View All", "Modify All"})
+	for _, o := range a.Objects {
+		table.Append([]string{o.Object, check(o.Create), check(o.Read), check(o.Edit), check(o.Delete), check(o.ViewAll), check(o.ModifyAll)})
+	}
+	renderTable(table)
+
+	table = tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Field", "Read", "Edit"})
+	for _, f := range a.Fields {
+		table.Append([]string{f.Field, check(f.Read), check(f.Edit)})
+	}
+	renderTable(table)
+
+	table = tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Tab", "Visibility"})
+	for _, t := range a.Tabs {
+		table.Append([]string{t.Tab, t.Visibility})
+	}
+	renderTable(table)
+
+	printNames("User Permissions", a.UserPermissions)
+	printNames("Apex Classes", a.ApexClasses)
+	printNames("Visualforce Pages", a.Pages)
+	printNames("Custom Permissions", a.CustomPermissions)
+}
+
+func renderTable(table *tablewriter.Table) {
+	if table.NumLines() > 0 {
+		table.Render()
+		fmt.Println()
+	}
+}
+
+func printNames(heading string, names []string) {
+	if len(names) == 0 {
+		return
+	}
+	fmt.Println(heading + " (" + strconv.Itoa(len(names)) + "):")
+	fmt.Println("  " + strings.Join(names, "\n  "))
+	fmt.Println()
+}
@@ -0,0 +1,63 @@
+package access
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/ForceCLI/force-md/cmd/repo"
+	"github.com/ForceCLI/force-md/metadata/permissionset"
+	"github.com/ForceCLI/force-md/metadata/permissionsetgroup"
+	"github.com/ForceCLI/force-md/metadata/profile"
+	"github.com/ForceCLI/force-md/repo/access"
+)
+
+var WhoCanAccessCmd = &cobra.Command{
+	Use:   "who-can-access component [filename]...",
+	Short: "List the profiles and permission sets that grant access to a component",
+	Long: `
+List the profiles and permission sets that grant access to a field, object, or
+user permission.
+
+	Fields are specified as Object.Field.  For fields, read and edit access are
+	listed.  For objects, create, read, edit, delete, view all, and modify all
+	are listed.  The permission set groups that include each permission set are
+	also listed, along with the access that remains after the group's muting
+	permission set is applied.  The files passed, or all of the metadata in the
+	--project, are searched.
+`,
+	Example: `
+$ force-md access who-can-access --project . Account.SSN__c
+
+$ force-md access who-can-access --project . Opportunity
+
+$ force-md access who-can-access ModifyAllData src/profiles/* src/permissionsets/*
+`,
+	Args:                  cobra.MinimumNArgs(1),
+	DisableFlagsInUseLine: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		files := repo.Files(args[1:], profile.NAME, permissionset.NAME, permissionsetgroup.NAME)
+		if len(files) == 0 {
+			return errors.New("requires at least 1 file or --project")
+		}
+		for _, file := range files {
+			if _, err := repo.Metadata.Open(file); err != nil {
+				log.Warnf("failed to open %s: %s", file, err.Error())
+			}
+		}
+		for _, g := range access.WhoCanAccess(repo.Metadata, args[0]) {
+			fmt.Printf("%s: %s %s (%s)\n", g.Path, g.Type, g.Name, strings.Join(g.Permissions, ", "))
+			for _, group := range g.Groups {
+				permissions := "muted"
+				if len(group.Permissions) > 0 {
+					permissions = strings.Join(group.Permissions, ", ")
+				}
+				fmt.Printf("  via %s %s (%s)\n", permissionsetgroup.NAME, group.Name, permissions)
+			}
+		}
+		return nil
+	},
+}
@@ -0,0 +1,17 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/ForceCLI/force-md/cmd/automation"
+)
+
+func init() {
+	automationCmd.AddCommand(automation.MapCmd)
+	RootCmd.AddCommand(automationCmd)
+}
+
+var automationCmd = &cobra.Command{
+	Use:   "automation",
+	Short: "Inspect automation",
+}
@@ -0,0 +1,107 @@
+package automation
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/ForceCLI/force-md/cmd/repo"
+	"github.com/ForceCLI/force-md/repo/automation"
+)
+
+var (
+	event string
+	all   bool
+)
+
+func init() {
+	MapCmd.Flags().StringVarP(&event, "event", "e", "", "only show automation for event (create, update, delete, or undelete)")
+	MapCmd.Flags().BoolVarP(&all, "all", "a", false, "include inactive automation")
+}
+
+var MapCmd = &cobra.Command{
+	Use:   "map [flags] object [filename]...",
+	Short: "Show the automation that runs when records are saved",
+	Long: `
+Show the automation that runs when records of an object are saved, grouped
+by phase of the order of execution.
+
+	Before-save includes before-save and before-delete flows, and before
+	Apex triggers.  Validation includes validation rules and duplicate rules.
+	After-save includes after Apex triggers, assignment rules, auto-response
+	rules, and after-save flows.  Workflow includes workflow rules, and async
+	includes workflow time triggers and scheduled paths of flows.
+
+	Warnings are shown for multiple active record-triggered flows that run in
+	the same phase for the same event without distinct trigger orders, and
+	for multiple Apex triggers for the same event.
+
+	The files passed, or all of the metadata in the --project, are searched.
+	Apex triggers are read from their source files.
+`,
+	Example: `
+$ force-md automation map --project . Account
+
+$ force-md automation map --project . --event update Opportunity
+`,
+	Args:                  cobra.MinimumNArgs(1),
+	DisableFlagsInUseLine: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		switch event {
+		case "", automation.Create, automation.Update, automation.Delete, automation.Undelete:
+		default:
+			return fmt.Errorf("invalid event: %s", event)
+		}
+		files := repo.Files(args[1:])
+		if len(files) == 0 {
+			return errors.New("requires at least 1 file or --project")
+		}
+		for _, file := range files {
+			if _, err := repo.Metadata.Open(file); err != nil {
+				log.Warnf("failed to open %s: %s", file, err.Error())
+			}
+		}
+		var items []automation.Automation
+		for _, a := range automation.Map(repo.Metadata, args[0]) {
+			if (a.Active || all) && (event == "" || slices.Contains(a.Events, event)) {
+				items = append(items, a)
+			}
+		}
+		for _, phase := range automation.Phases {
+			fmt.Printf("%s:\n", phase)
+			for _, a := range items {
+				if a.Phase == phase {
+					fmt.Printf("\t%s\n", describe(a))
+				}
+			}
+		}
+		for _, w := range automation.Warnings(items) {
+			log.Warn(w)
+		}
+		return nil
+	},
+}
+
+// describe formats automation as, e.g., "Flow My_Flow (create, update; order
+// 10)"
+func describe(a automation.Automation) string {
+	var details []string
+	if len(a.Events) > 0 {
+		details = append(details, strings.Join(a.Events, ", "))
+	}
+	if a.Order != "" {
+		details = append(details, "order "+a.Order)
+	}
+	if !a.Active {
+		details = append(details, "inactive")
+	}
+	s := a.Type + " " + a.Name
+	if len(details) > 0 {
+		s += " (" + strings.Join(details, "; ") + ")"
+	}
+	return s
+}
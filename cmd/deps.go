@@ -0,0 +1,18 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/ForceCLI/force-md/cmd/deps"
+)
+
+func init() {
+	depsCmd.AddCommand(deps.WhatUsesCmd)
+	depsCmd.AddCommand(deps.UsesCmd)
+	RootCmd.AddCommand(depsCmd)
+}
+
+var depsCmd = &cobra.Command{
+	Use:   "deps",
+	Short: "Find references between metadata",
+}
@@ -0,0 +1,95 @@
+package deps
+
+import (
+	"errors"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/ForceCLI/force-md/cmd/repo"
+	"github.com/ForceCLI/force-md/metadata"
+	"github.com/ForceCLI/force-md/repo/deps"
+)
+
+var WhatUsesCmd = &cobra.Command{
+	Use:   "what-uses component [filename]...",
+	Short: "List metadata that references a component",
+	Long: `
+List the metadata that references a field, object, or report.
+
+	Fields are referenced as Object.Field, and reports as Folder/Report.  An
+	object name also matches references to the object's fields.  The files
+	passed, or all of the metadata in the --project, are searched.
+
+	References are found in profile and permission set field and object
+	permissions, layout items, flexipage field items, flow record lookups,
+	creates, and updates, workflow field updates, report type columns,
+	dashboard components, and formula fields.
+`,
+	Example: `
+$ force-md deps what-uses --project . Account.Foo__c
+
+$ force-md deps what-uses Account src/profiles/* src/layouts/*
+`,
+	Args:                  cobra.MinimumNArgs(1),
+	DisableFlagsInUseLine: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		files := repo.Files(args[1:])
+		if len(files) == 0 {
+			return errors.New("requires at least 1 file or --project")
+		}
+		for _, file := range files {
+			if _, err := repo.Metadata.Open(file); err != nil {
+				log.Warnf("failed to open %s: %s", file, err.Error())
+			}
+		}
+		idx := deps.NewIndex(repo.Metadata)
+		for _, r := range idx.WhatUses(args[0]) {
+			fmt.Printf("%s: %s %s (%s)\n", r.Path, r.Source, r.SourceName, describe(r))
+		}
+		return nil
+	},
+}
+
+var UsesCmd = &cobra.Command{
+	Use:   "uses [filename]...",
+	Short: "List the components referenced by metadata",
+	Long: `
+List the fields, objects, and reports referenced by metadata.
+
+	Relationships in formulas are followed using the objects in the --project,
+	if any.
+`,
+	Example: `
+$ force-md deps uses src/layouts/Account-Account\ Layout.layout
+
+$ force-md deps uses --project . force-app/main/default/flows/My_Flow.flow-meta.xml
+`,
+	Args:                  cobra.MinimumNArgs(1),
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		var items []metadata.RegisterableMetadata
+		for _, file := range args {
+			m, err := repo.Metadata.Open(file)
+			if err != nil {
+				log.Warnf("failed to open %s: %s", file, err.Error())
+				continue
+			}
+			items = append(items, m.(metadata.RegisterableMetadata))
+		}
+		idx := deps.NewIndex(repo.Metadata)
+		for _, m := range items {
+			for _, r := range idx.References(m) {
+				fmt.Printf("%s: %s (%s)\n", r.Path, r.Target, r.Context)
+			}
+		}
+	},
+}
+
+func describe(r deps.Reference) string {
+	if r.Target.Type == deps.CustomField {
+		return r.Context + " " + r.Target.Name
+	}
+	return r.Context
+}
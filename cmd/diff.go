@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/ForceCLI/force-md/internal/semantic"
+	"github.com/ForceCLI/force-md/repo"
+)
+
+var diffJson bool
+
+func init() {
+	diffCmd.Flags().BoolVarP(&diffJson, "json", "j", false, "output as json")
+	RootCmd.AddCommand(diffCmd)
+}
+
+var diffCmd = &cobra.Command{
+	Use:   "diff [flags] old new",
+	Short: "Show the semantic differences between two versions of a metadata file",
+	Long: `
+Show the differences between two versions of a metadata file.
+
+	Both files are parsed as metadata, and the entries in lists are matched by
+	their natural key, such as the field in field permissions, the object in
+	object permissions, or the name of a flow element, so reordering entries
+	isn't reported as a change.  Changes to values are reported with the keys
+	of the entries that contain them.
+`,
+	Example: `
+$ force-md diff old/Admin.profile src/profiles/Admin.profile
+
+$ git show HEAD~:src/flows/My_Flow.flow > /tmp/My_Flow.flow
+$ force-md diff --json /tmp/My_Flow.flow src/flows/My_Flow.flow
+`,
+	Args:                  cobra.ExactArgs(2),
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		old, err := repo.MetadataFromPath(args[0])
+		if err != nil {
+			log.Fatalf("invalid file %s: %s", args[0], err.Error())
+		}
+		updated, err := repo.MetadataFromPath(args[1])
+		if err != nil {
+			log.Fatalf("invalid file %s: %s", args[1], err.Error())
+		}
+		if old.Type() != updated.Type() {
+			log.Fatalf("cannot compare %s to %s", old.Type(), updated.Type())
+		}
+		diffs := semantic.Diff(old, updated)
+		if diffJson {
+			if diffs == nil {
+				diffs = []semantic.Difference{}
+			}
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(diffs); err != nil {
+				log.Fatal("encoding differences failed: " + err.Error())
+			}
+			return
+		}
+		for _, d := range diffs {
+			fmt.Println(d)
+		}
+	},
+}
@@ -0,0 +1,21 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/ForceCLI/force-md/cmd/flexipage"
+)
+
+func init() {
+	flexipageCmd.AddCommand(flexipage.ListComponentsCmd)
+	flexipageCmd.AddCommand(flexipage.ShowVisibilityCmd)
+	flexipageCmd.AddCommand(flexipage.FieldsCmd)
+	flexipageCmd.AddCommand(flexipage.ComponentCmd)
+	flexipageCmd.AddCommand(flexipage.FieldCmd)
+	RootCmd.AddCommand(flexipageCmd)
+}
+
+var flexipageCmd = &cobra.Command{
+	Use:   "flexipage",
+	Short: "Manage Lightning pages",
+}
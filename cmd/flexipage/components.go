@@ -0,0 +1,170 @@
+package flexipage
+
+import (
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/ForceCLI/force-md/cmd/repo"
+	"github.com/ForceCLI/force-md/internal"
+	flexipage "github.com/ForceCLI/force-md/metadata/flexipages"
+)
+
+func init() {
+	ListComponentsCmd.Flags().StringP("name", "n", "", "only list component")
+	ShowVisibilityCmd.Flags().StringP("name", "n", "", "only show component or field")
+
+	removeComponentCmd.Flags().StringP("name", "n", "", "component name, e.g. c:myComponent or flexipage:richText")
+	removeComponentCmd.MarkFlagRequired("name")
+
+	ComponentCmd.AddCommand(removeComponentCmd)
+}
+
+var ListComponentsCmd = &cobra.Command{
+	Use:   "list-components [flags] [filename]...",
+	Short: "List Lightning page components",
+	Long: `
+List the components on Lightning pages, with the region that contains each
+one.  Components with visibility rules are marked with an asterisk.
+`,
+	Example: `
+$ force-md flexipage list-components --project . -n c:legacyBanner
+`,
+	Args:                  repo.FilesRequired,
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		name, _ := cmd.Flags().GetString("name")
+		forEachPage(args, func(pageName string, p *flexipage.FlexiPage) {
+			for _, c := range p.Components() {
+				if name != "" && !flexipage.SameComponent(c.Name, name) {
+					continue
+				}
+				visible := ""
+				if c.Visibility != nil {
+					visible = " *"
+				}
+				fmt.Printf("%s: %s: %s (%s)%s\n", pageName, c.Region, c.Name, c.Identifier, visible)
+			}
+		})
+	},
+}
+
+var ShowVisibilityCmd = &cobra.Command{
+	Use:   "show-visibility [flags] [filename]...",
+	Short: "Show Lightning page visibility rules",
+	Long: `
+Show the visibility rules of the components, tabs, and fields on Lightning
+pages.  Criteria are numbered as in the rule's filter logic, which defaults
+to AND.
+`,
+	Example: `
+$ force-md flexipage show-visibility src/flexipages/Case_Record_Page.flexipage
+`,
+	Args:                  repo.FilesRequired,
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		name, _ := cmd.Flags().GetString("name")
+		forEachPage(args, func(pageName string, p *flexipage.FlexiPage) {
+			for _, c := range p.Components() {
+				if name != "" && !flexipage.SameComponent(c.Name, name) {
+					continue
+				}
+				if c.Visibility != nil {
+					fmt.Printf("%s: %s (%s)\n", pageName, c.Identifier, c.Name)
+					printVisibility(c.Visibility)
+				}
+				for _, prop := range c.Instance.ComponentInstanceProperties {
+					if prop.ValueList == nil {
+						continue
+					}
+					for _, item := range prop.ValueList.ValueListItems {
+						if item.VisibilityRule != nil {
+							fmt.Printf("%s: %s (%s) %s %s\n", pageName, c.Identifier, c.Name, prop.Name.String(), item.Value.String())
+							printVisibility(item.VisibilityRule)
+						}
+					}
+				}
+			}
+			for _, f := range p.Fields() {
+				if name != "" && !strings.EqualFold(strings.TrimPrefix(f.Field, "Record."), strings.TrimPrefix(name, "Record.")) {
+					continue
+				}
+				if f.Visibility != nil {
+					fmt.Printf("%s: %s (%s)\n", pageName, f.Identifier, f.Field)
+					printVisibility(f.Visibility)
+				}
+			}
+		})
+	},
+}
+
+var ComponentCmd = &cobra.Command{
+	Use:   "component",
+	Short: "Manage Lightning page components",
+}
+
+var removeComponentCmd = &cobra.Command{
+	Use:   "remove -n Name [filename]...",
+	Short: "Remove component from Lightning pages",
+	Long: `
+Remove every instance of a component from Lightning pages.
+
+	Facets that hold the content of removed components, such as the columns
+	of a field section or the contents of a tab, are removed too.
+`,
+	Example: `
+$ force-md flexipage component remove -n c:legacyBanner --project .
+`,
+	Args:                  repo.FilesRequired,
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		name, _ := cmd.Flags().GetString("name")
+		updatePages(args, func(p *flexipage.FlexiPage) error {
+			_, err := p.RemoveComponent(name)
+			return err
+		})
+	},
+}
+
+func printVisibility(v *flexipage.VisibilityRule) {
+	for i, c := range v.Criteria {
+		fmt.Printf("    %d. %s %s %s\n", i+1, c.LeftValue.String(), c.Operator.String(), c.RightValue.String())
+	}
+	if v.BooleanFilter != nil {
+		fmt.Printf("    Filter: %s\n", v.BooleanFilter.String())
+	}
+}
+
+func forEachPage(args []string, f func(name string, p *flexipage.FlexiPage)) {
+	for _, file := range repo.Files(args, flexipage.NAME) {
+		p, err := flexipage.Open(file)
+		if err != nil {
+			log.Warn("parsing flexipage failed: " + err.Error())
+			continue
+		}
+		f(string(p.GetMetadataInfo().Name()), p)
+	}
+}
+
+// updatePages applies an update to each page and writes it back to file.
+// When updating all of a project's pages, pages that don't include the
+// component or field being changed are skipped quietly.
+func updatePages(args []string, update func(p *flexipage.FlexiPage) error) {
+	bulk := len(args) == 0
+	forEachPage(args, func(name string, p *flexipage.FlexiPage) {
+		file := string(p.GetMetadataInfo().Path())
+		if err := update(p); err != nil {
+			if bulk && (err == flexipage.ComponentNotFoundError || err == flexipage.FieldNotFoundError) {
+				log.Debug(fmt.Sprintf("skipping %s: %s", file, err.Error()))
+				return
+			}
+			log.Warn(fmt.Sprintf("update failed for %s: %s", file, err.Error()))
+			return
+		}
+		if err := internal.WriteToFile(p, file); err != nil {
+			log.Warn("update failed: " + err.Error())
+		}
+	})
+}
@@ -0,0 +1,104 @@
+package flexipage
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ForceCLI/force-md/cmd/repo"
+	flexipage "github.com/ForceCLI/force-md/metadata/flexipages"
+)
+
+func init() {
+	FieldsCmd.Flags().StringP("field", "f", "", "only list field")
+
+	addFieldCmd.Flags().StringP("field", "f", "", "field")
+	addFieldCmd.Flags().StringP("region", "r", "", "region or facet, e.g. Facet-1234")
+	addFieldCmd.Flags().StringP("behavior", "b", "none", "behavior; can be "+strings.Join(flexipage.Behaviors, ", "))
+	addFieldCmd.Flags().String("after", "", "add after field (default end of region)")
+	addFieldCmd.MarkFlagRequired("field")
+	addFieldCmd.MarkFlagRequired("region")
+
+	removeFieldCmd.Flags().StringP("field", "f", "", "field")
+	removeFieldCmd.MarkFlagRequired("field")
+
+	FieldCmd.AddCommand(addFieldCmd)
+	FieldCmd.AddCommand(removeFieldCmd)
+}
+
+var FieldsCmd = &cobra.Command{
+	Use:   "fields [flags] [filename]...",
+	Short: "List Lightning page fields",
+	Long: `
+List the fields on Lightning pages that use Dynamic Forms, with the region
+that contains each one and its behavior.  Fields with visibility rules are
+marked with an asterisk.
+`,
+	Example: `
+$ force-md flexipage fields --project . -f Retired__c
+`,
+	Args:                  repo.FilesRequired,
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		field, _ := cmd.Flags().GetString("field")
+		forEachPage(args, func(pageName string, p *flexipage.FlexiPage) {
+			for _, f := range p.Fields() {
+				if field != "" && !strings.EqualFold(strings.TrimPrefix(f.Field, "Record."), strings.TrimPrefix(field, "Record.")) {
+					continue
+				}
+				visible := ""
+				if f.Visibility != nil {
+					visible = " *"
+				}
+				fmt.Printf("%s: %s: %s (%s)%s\n", pageName, f.Region, f.Field, f.Behavior, visible)
+			}
+		})
+	},
+}
+
+var FieldCmd = &cobra.Command{
+	Use:   "field",
+	Short: "Manage Lightning page fields",
+}
+
+var addFieldCmd = &cobra.Command{
+	Use:   "add -f Field -r Region [flags] [filename]...",
+	Short: "Add field to Lightning page",
+	Long: `
+Add a field to a region of Lightning pages that use Dynamic Forms.
+
+	The columns of field sections are facets.  Use the fields command to find
+	the facet that contains a nearby field.
+`,
+	Example: `
+$ force-md flexipage field add -f Reason__c -r Facet-1234 --after Status -b required src/flexipages/Case_Record_Page.flexipage
+`,
+	Args:                  cobra.MinimumNArgs(1),
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		field, _ := cmd.Flags().GetString("field")
+		region, _ := cmd.Flags().GetString("region")
+		behavior, _ := cmd.Flags().GetString("behavior")
+		after, _ := cmd.Flags().GetString("after")
+		updatePages(args, func(p *flexipage.FlexiPage) error {
+			return p.AddField(region, field, strings.ToLower(behavior), after)
+		})
+	},
+}
+
+var removeFieldCmd = &cobra.Command{
+	Use:   "remove -f Field [filename]...",
+	Short: "Remove field from Lightning pages",
+	Example: `
+$ force-md flexipage field remove -f Retired__c --project .
+`,
+	Args:                  repo.FilesRequired,
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		field, _ := cmd.Flags().GetString("field")
+		updatePages(args, func(p *flexipage.FlexiPage) error {
+			return p.RemoveField(field)
+		})
+	},
+}
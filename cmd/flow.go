@@ -0,0 +1,22 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/ForceCLI/force-md/cmd/flow"
+)
+
+func init() {
+	flowCmd.AddCommand(flow.ListCmd)
+	flowCmd.AddCommand(flow.ShowCmd)
+	flowCmd.AddCommand(flow.ElementsCmd)
+	flowCmd.AddCommand(flow.VariablesCmd)
+	flowCmd.AddCommand(flow.GraphCmd)
+	flowCmd.AddCommand(flow.LintCmd)
+	RootCmd.AddCommand(flowCmd)
+}
+
+var flowCmd = &cobra.Command{
+	Use:   "flow",
+	Short: "Inspect Flows",
+}
@@ -0,0 +1,61 @@
+package flow
+
+import (
+	"os"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/ForceCLI/force-md/cmd/repo"
+	"github.com/ForceCLI/force-md/metadata/flow"
+)
+
+var elementType string
+
+func init() {
+	ElementsCmd.Flags().StringVarP(&elementType, "type", "t", "", "element type, e.g. Decision or RecordUpdate")
+}
+
+var ElementsCmd = &cobra.Command{
+	Use:   "elements [flags] [filename]...",
+	Short: "List flow elements",
+	Long: `
+List the elements of flows with the elements they connect to.
+`,
+	Example: `
+$ force-md flow elements src/flows/Account_After_Save.flow
+
+$ force-md flow elements --type RecordUpdate --project .
+`,
+	Args: repo.FilesRequired,
+	Run: func(cmd *cobra.Command, args []string) {
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Flow", "Element", "Type", "Label", "Connects To"})
+		table.SetAutoWrapText(false)
+		for _, f := range openFlows(args) {
+			name := string(f.GetMetadataInfo().Name())
+			for _, e := range f.Elements() {
+				if elementType != "" && !strings.EqualFold(e.Type, elementType) {
+					continue
+				}
+				table.Append([]string{name, string(e.Name), e.Type, e.Label, connectors(e.Connectors)})
+			}
+		}
+		if table.NumLines() > 0 {
+			table.Render()
+		}
+	},
+}
+
+func connectors(connectors []flow.Connector) string {
+	var targets []string
+	for _, c := range connectors {
+		if c.Label != "" {
+			targets = append(targets, string(c.Target)+" ("+c.Label+")")
+		} else {
+			targets = append(targets, string(c.Target))
+		}
+	}
+	return strings.Join(targets, ", ")
+}
@@ -0,0 +1,54 @@
+package flow
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/ForceCLI/force-md/cmd/repo"
+)
+
+var graphFormat string
+
+func init() {
+	GraphCmd.Flags().StringVarP(&graphFormat, "format", "f", "dot", "output format: dot or mermaid")
+}
+
+var GraphCmd = &cobra.Command{
+	Use:   "graph [flags] [filename]...",
+	Short: "Export the control graph of flows",
+	Long: `
+Export the control graph of flows in Graphviz DOT or Mermaid format.
+
+	Each element is a node, and connectors are edges labeled with decision
+	outcomes, loop paths, and scheduled paths.  Fault paths are dashed.
+
+	DOT output contains a graph for each flow.  Mermaid output is a single
+	flowchart, so exactly one flow must be passed.
+`,
+	Example: `
+$ force-md flow graph src/flows/Account_After_Save.flow | dot -Tsvg > flow.svg
+
+$ force-md flow graph --format mermaid src/flows/Account_After_Save.flow
+`,
+	Args: repo.FilesRequired,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		if graphFormat != "dot" && graphFormat != "mermaid" {
+			log.Fatal("format must be dot or mermaid")
+		}
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		flows := openFlows(args)
+		if graphFormat == "mermaid" && len(flows) != 1 {
+			log.Fatal("mermaid format requires exactly one flow")
+		}
+		for _, f := range flows {
+			if graphFormat == "mermaid" {
+				fmt.Print(f.Mermaid())
+			} else {
+				fmt.Print(f.DOT())
+			}
+		}
+	},
+}
@@ -0,0 +1,119 @@
+package flow
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/ForceCLI/force-md/cmd/repo"
+	"github.com/ForceCLI/force-md/repo/lint"
+)
+
+var (
+	lintConfig     string
+	lintDisabled   []string
+	lintApiVersion float64
+	lintFormat     string
+)
+
+func init() {
+	LintCmd.Flags().StringVarP(&lintConfig, "config", "c", "", "YAML file configuring rules")
+	LintCmd.Flags().StringSliceVarP(&lintDisabled, "disable", "d", nil, "rule to disable")
+	LintCmd.Flags().Float64Var(&lintApiVersion, "min-api-version", 0, fmt.Sprintf("minimum API version (default %.1f)", lint.DefaultMinApiVersion))
+	LintCmd.Flags().StringVarP(&lintFormat, "format", "f", "text", "output format: text or sarif")
+}
+
+var LintCmd = &cobra.Command{
+	Use:   "lint [flags] [filename]...",
+	Short: "Check flows for best practices",
+	Long: `
+Check flows for violations of best practices.
+
+	Rules:
+	  dml-in-loop             record lookups, creates, updates, or deletes inside loops
+	  missing-fault-path      record creates, updates, deletes, or actions without fault paths
+	  unreachable-element     elements that can't be reached from the start
+	  unused-variable         variables that aren't used or available for input or output
+	  hardcoded-id            record ids in values
+	  missing-entry-criteria  record-triggered flows without entry criteria
+	  api-version             API version below --min-api-version
+
+	The level of each rule can be set to error, warning, note, or off in a
+	YAML config file, along with the minimum API version:
+
+	  rules:
+	    unused-variable: off
+	    missing-fault-path: error
+	  minApiVersion: 60
+
+	Output is text, or SARIF for code scanning tools.  The command exits with a
+	non-zero status if any errors are found.
+`,
+	Example: `
+$ force-md flow lint --project .
+
+$ force-md flow lint --disable unused-variable --format sarif src/flows/* > flows.sarif
+`,
+	Args: repo.FilesRequired,
+	Run: func(cmd *cobra.Command, args []string) {
+		config, err := loadLintConfig()
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+		var results []lint.FlowResults
+		failed := false
+		for _, f := range openFlows(args) {
+			issues := lint.LintFlow(f, config)
+			results = append(results, lint.FlowResults{Path: string(f.GetMetadataInfo().Path()), Issues: issues})
+			for _, i := range issues {
+				failed = failed || i.Level == lint.Error
+			}
+		}
+		if lintFormat == "sarif" {
+			out, err := lint.FlowSARIF(results)
+			if err != nil {
+				log.Fatal("encoding results failed: " + err.Error())
+			}
+			fmt.Println(string(out))
+		} else {
+			for _, r := range results {
+				for _, i := range r.Issues {
+					fmt.Printf("%s: %s\n", r.Path, i)
+				}
+			}
+		}
+		if failed {
+			os.Exit(1)
+		}
+	},
+}
+
+func loadLintConfig() (lint.FlowConfig, error) {
+	var config lint.FlowConfig
+	if lintFormat != "text" && lintFormat != "sarif" {
+		return config, errors.New("format must be text or sarif")
+	}
+	if lintConfig != "" {
+		data, err := os.ReadFile(lintConfig)
+		if err != nil {
+			return config, err
+		}
+		if err := yaml.Unmarshal(data, &config); err != nil {
+			return config, errors.Wrap(err, "parsing "+lintConfig)
+		}
+	}
+	if config.Rules == nil {
+		config.Rules = make(map[string]lint.Level)
+	}
+	for _, id := range lintDisabled {
+		config.Rules[id] = lint.Off
+	}
+	if lintApiVersion != 0 {
+		config.MinApiVersion = lintApiVersion
+	}
+	return config, lint.ValidateFlowConfig(config)
+}
@@ -0,0 +1,76 @@
+package flow
+
+import (
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/ForceCLI/force-md/cmd/repo"
+	"github.com/ForceCLI/force-md/metadata/flow"
+)
+
+var ListCmd = &cobra.Command{
+	Use:   "list [filename]...",
+	Short: "List flows",
+	Long: `
+List flows with their type, status, and trigger.
+`,
+	Example: `
+$ force-md flow list src/flows/*
+
+$ force-md flow list --project .
+`,
+	Args:                  repo.FilesRequired,
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		for _, f := range openFlows(args) {
+			details := []string{f.ProcessType.Text, status(f)}
+			if t := trigger(f); t != "" {
+				details = append(details, t)
+			}
+			fmt.Printf("%s: %s (%s)\n", f.GetMetadataInfo().Name(), f.Label.Text, strings.Join(details, ", "))
+		}
+	},
+}
+
+func openFlows(args []string) []*flow.Flow {
+	var flows []*flow.Flow
+	for _, file := range repo.Files(args, flow.NAME) {
+		f, err := flow.Open(file)
+		if err != nil {
+			log.Warn("parsing flow failed: " + err.Error())
+			continue
+		}
+		flows = append(flows, f)
+	}
+	return flows
+}
+
+func status(f *flow.Flow) string {
+	if f.Status == nil {
+		return "Draft"
+	}
+	return f.Status.Text
+}
+
+// trigger describes what starts a flow, e.g. "RecordAfterSave on Account
+// (Create)"
+func trigger(f *flow.Flow) string {
+	s := f.Start
+	if s == nil {
+		return ""
+	}
+	var parts []string
+	if s.TriggerType != nil {
+		parts = append(parts, *s.TriggerType)
+	}
+	if s.Object != nil {
+		parts = append(parts, "on "+*s.Object)
+	}
+	if s.RecordTriggerType != nil {
+		parts = append(parts, "("+s.RecordTriggerType.Text+")")
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,166 @@
+package flow
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ForceCLI/force-md/cmd/repo"
+	"github.com/ForceCLI/force-md/metadata/flow"
+)
+
+var ShowCmd = &cobra.Command{
+	Use:   "show [filename]...",
+	Short: "Show flow details",
+	Long: `
+Show the trigger configuration, entry criteria, and schedule of flows, a count
+of their elements by type, and the objects and fields each flow reads and
+writes.
+`,
+	Example: `
+$ force-md flow show src/flows/Account_After_Save.flow
+`,
+	Args:                  repo.FilesRequired,
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		for _, f := range openFlows(args) {
+			showFlow(f)
+		}
+	},
+}
+
+func showFlow(f *flow.Flow) {
+	fmt.Printf("%s\n", f.GetMetadataInfo().Path())
+	field := func(name, value string) {
+		if value != "" {
+			fmt.Printf("\t%s: %s\n", name, value)
+		}
+	}
+	field("Label", f.Label.Text)
+	field("Type", f.ProcessType.Text)
+	field("Status", status(f))
+	if f.ApiVersion != nil {
+		field("API Version", f.ApiVersion.Text)
+	}
+	if f.RunInMode != nil {
+		field("Run Mode", f.RunInMode.Text)
+	}
+	field("Trigger", trigger(f))
+	if f.TriggerOrder != nil {
+		field("Trigger Order", f.TriggerOrder.Text)
+	}
+	if s := f.Start; s != nil {
+		field("Entry Criteria", entryCriteria(s))
+		if s.DoesRequireRecordChangedToMeetCriteria != nil {
+			field("Only When Changed To Meet Criteria", s.DoesRequireRecordChangedToMeetCriteria.Text)
+		}
+		if s.Schedule != nil {
+			field("Schedule", fmt.Sprintf("%s starting %s %s", s.Schedule.Frequency.Text, s.Schedule.StartDate.Text, s.Schedule.StartTime.Text))
+		}
+		for _, p := range s.ScheduledPaths {
+			field("Scheduled Path", scheduledPath(p.Label, p.OffsetNumber, p.OffsetUnit, p.RecordField, p.TimeSource))
+		}
+	}
+
+	counts := make(map[string]int)
+	for _, e := range f.Elements() {
+		counts[e.Type]++
+	}
+	var types []string
+	for t := range counts {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	var elements []string
+	for _, t := range types {
+		elements = append(elements, fmt.Sprintf("%d %s", counts[t], t))
+	}
+	field("Elements", strings.Join(elements, ", "))
+
+	var reads, writes []string
+	for _, a := range f.DataAccess() {
+		object := a.Object
+		if object == "" {
+			object = "unknown object"
+		}
+		line := fmt.Sprintf("%s %s (%s)", a.Operation, object, a.Element)
+		if len(a.Fields) > 0 {
+			line += ": " + strings.Join(a.Fields, ", ")
+		}
+		if a.Operation == flow.Read {
+			reads = append(reads, line)
+		} else {
+			writes = append(writes, line)
+		}
+	}
+	if len(reads) > 0 {
+		fmt.Println("\tReads:")
+		for _, r := range reads {
+			fmt.Printf("\t\t%s\n", r)
+		}
+	}
+	if len(writes) > 0 {
+		fmt.Println("\tWrites:")
+		for _, w := range writes {
+			fmt.Printf("\t\t%s\n", w)
+		}
+	}
+}
+
+// entryCriteria describes the conditions under which a flow starts
+func entryCriteria(s *flow.Start) string {
+	if s.FilterFormula != nil && s.FilterFormula.String() != "" {
+		return s.FilterFormula.String()
+	}
+	var conditions []string
+	for _, filter := range s.Filters {
+		c := filter.Field.Text + " " + filter.Operator.Text
+		if filter.Value != nil {
+			c += " " + filter.Value.String()
+		}
+		conditions = append(conditions, c)
+	}
+	if len(conditions) == 0 {
+		return ""
+	}
+	logic := "and"
+	if s.FilterLogic != nil {
+		logic = *s.FilterLogic
+	}
+	switch logic {
+	case "and", "or":
+		return strings.Join(conditions, " "+strings.ToUpper(logic)+" ")
+	}
+	var numbered []string
+	for i, c := range conditions {
+		numbered = append(numbered, fmt.Sprintf("%d. %s", i+1, c))
+	}
+	return logic + ": " + strings.Join(numbered, "; ")
+}
+
+type textElement = *struct {
+	Text string `xml:",chardata"`
+}
+
+// scheduledPath describes when a scheduled path runs, e.g. "Reminder: 3 Days
+// after CloseDate"
+func scheduledPath(label, offset, unit textElement, recordField *string, timeSource textElement) string {
+	var when string
+	switch {
+	case offset != nil && unit != nil:
+		when = offset.Text + " " + unit.Text
+		if recordField != nil {
+			when += " from " + *recordField
+		} else if timeSource != nil {
+			when += " from " + timeSource.Text
+		}
+	default:
+		when = "asynchronous"
+	}
+	if label != nil {
+		return label.Text + ": " + when
+	}
+	return when
+}
@@ -0,0 +1,63 @@
+package flow
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/ForceCLI/force-md/cmd/repo"
+)
+
+var (
+	inputOnly  bool
+	outputOnly bool
+)
+
+func init() {
+	VariablesCmd.Flags().BoolVarP(&inputOnly, "input", "i", false, "only input variables")
+	VariablesCmd.Flags().BoolVarP(&outputOnly, "output", "o", false, "only output variables")
+}
+
+var VariablesCmd = &cobra.Command{
+	Use:   "variables [flags] [filename]...",
+	Short: "List flow variables",
+	Long: `
+List the variables of flows with their data type and whether they're available
+for input and output.
+`,
+	Example: `
+$ force-md flow variables --input src/flows/Create_Case.flow
+`,
+	Args: repo.FilesRequired,
+	Run: func(cmd *cobra.Command, args []string) {
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Flow", "Variable", "Data Type", "Object", "Collection", "Input", "Output", "Default"})
+		for _, f := range openFlows(args) {
+			name := string(f.GetMetadataInfo().Name())
+			for _, v := range f.Variables {
+				if inputOnly && !v.IsInput.ToBool() || outputOnly && !v.IsOutput.ToBool() {
+					continue
+				}
+				object := ""
+				if v.ObjectType != nil {
+					object = v.ObjectType.Text
+				}
+				value := ""
+				if v.Value != nil {
+					value = v.Value.String()
+				}
+				table.Append([]string{name, v.Name, v.DataType, object,
+					strconv.FormatBool(v.IsCollection.ToBool()),
+					strconv.FormatBool(v.IsInput.ToBool()),
+					strconv.FormatBool(v.IsOutput.ToBool()),
+					value,
+				})
+			}
+		}
+		if table.NumLines() > 0 {
+			table.Render()
+		}
+	},
+}
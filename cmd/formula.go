@@ -0,0 +1,19 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/ForceCLI/force-md/cmd/formula"
+)
+
+func init() {
+	formulaCmd.AddCommand(formula.AnalyzeCmd)
+	formulaCmd.AddCommand(formula.EvalCmd)
+	formulaCmd.AddCommand(formula.TestCmd)
+	RootCmd.AddCommand(formulaCmd)
+}
+
+var formulaCmd = &cobra.Command{
+	Use:   "formula",
+	Short: "Analyze and test formulas",
+}
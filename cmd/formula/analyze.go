@@ -0,0 +1,120 @@
+package formula
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/ForceCLI/force-md/cmd/repo"
+	"github.com/ForceCLI/force-md/metadata"
+	"github.com/ForceCLI/force-md/metadata/flow"
+	"github.com/ForceCLI/force-md/metadata/objects"
+	"github.com/ForceCLI/force-md/metadata/objects/field"
+	"github.com/ForceCLI/force-md/metadata/objects/validationrule"
+	"github.com/ForceCLI/force-md/metadata/workflow"
+	"github.com/ForceCLI/force-md/repo/formulas"
+)
+
+var analyzeJson bool
+
+func init() {
+	AnalyzeCmd.Flags().BoolVarP(&analyzeJson, "json", "j", false, "output as json")
+}
+
+var AnalyzeCmd = &cobra.Command{
+	Use:   "analyze [filename]...",
+	Short: "List the fields referenced by formulas and check their size",
+	Long: `
+List the fields and relationship paths referenced by formulas.
+
+	Formulas are read from formula fields, field default values, validation
+	rules, workflow rules and field updates, and flow formulas.  Relationships
+	are followed using the objects in the --project, and references to custom
+	fields that aren't in the objects loaded are reported as unknown.
+
+	The compiled size of each formula is estimated and checked against the
+	5,000 character compiled size limit.  The estimate removes whitespace and
+	comments and includes the formulas of the formula fields it references.
+	Salesforce's actual compiled size can't be computed from the source, so a
+	formula near the limit may still be too large.  The source size of each
+	formula is also checked against the 3,900 character limit on formula
+	text.
+
+	The command exits with a non-zero status if any formula can't be parsed,
+	references unknown fields, or exceeds either size limit.
+`,
+	Example: `
+$ force-md formula analyze --project .
+
+$ force-md formula analyze --project . --json src/objects/Account.object
+`,
+	Args:                  repo.FilesRequired,
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		var items []metadata.RegisterableMetadata
+		for _, file := range repo.Files(args, objects.NAME, field.NAME, validationrule.NAME, workflow.NAME, flow.NAME) {
+			m, err := repo.Metadata.Open(file)
+			if err != nil {
+				log.Warnf("failed to open %s: %s", file, err.Error())
+				continue
+			}
+			items = append(items, m.(metadata.RegisterableMetadata))
+		}
+		analyzer := formulas.NewAnalyzer(repo.Metadata)
+		results := []formulas.Analysis{}
+		problems := false
+		for _, m := range items {
+			for _, f := range formulas.Formulas(m) {
+				a := analyzer.Analyze(f)
+				results = append(results, a)
+				if a.Error != "" || len(a.UnknownFields) > 0 || a.OverSourceSizeLimit || a.OverCompiledSizeLimit {
+					problems = true
+				}
+			}
+		}
+		if analyzeJson {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(results); err != nil {
+				log.Fatal("encoding analysis failed: " + err.Error())
+			}
+		} else {
+			for _, a := range results {
+				printAnalysis(a)
+			}
+		}
+		if problems {
+			os.Exit(1)
+		}
+	},
+}
+
+func printAnalysis(a formulas.Analysis) {
+	fmt.Printf("%s: %s %s %s\n", a.Path, a.Type, a.Name, a.Element)
+	if a.Error != "" {
+		fmt.Printf("\terror: %s\n", a.Error)
+		return
+	}
+	if len(a.References) > 0 {
+		fmt.Printf("\treferences: %s\n", strings.Join(a.References, ", "))
+	}
+	if len(a.Fields) > 0 {
+		fmt.Printf("\tfields: %s\n", strings.Join(a.Fields, ", "))
+	}
+	if len(a.UnknownFields) > 0 {
+		fmt.Printf("\tunknown fields: %s\n", strings.Join(a.UnknownFields, ", "))
+	}
+	fmt.Printf("\testimated compiled size: %d of %d%s\n", a.ExpandedSize, formulas.CompiledSizeLimit, overLimit(a.OverCompiledSizeLimit))
+	fmt.Printf("\tsource size: %d of %d%s\n", a.SourceSize, formulas.SourceSizeLimit, overLimit(a.OverSourceSizeLimit))
+}
+
+func overLimit(over bool) string {
+	if over {
+		return " (over limit)"
+	}
+	return ""
+}
@@ -0,0 +1,94 @@
+package formula
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/ForceCLI/force-md/internal/formula"
+	"github.com/ForceCLI/force-md/repo/formulas"
+)
+
+var (
+	evalFile      string
+	evalComponent string
+	evalElement   string
+	evalRecord    string
+	evalPrior     string
+	evalUser      string
+	evalProfile   string
+)
+
+func init() {
+	EvalCmd.Flags().StringVarP(&evalFile, "file", "f", "", "metadata file containing formula")
+	EvalCmd.Flags().StringVarP(&evalComponent, "component", "c", "", "component containing formula, e.g. Account.Total__c")
+	EvalCmd.Flags().StringVarP(&evalElement, "element", "e", "", "element containing formula, e.g. defaultValue")
+	EvalCmd.Flags().StringVarP(&evalRecord, "record", "r", "", "JSON file containing record")
+	EvalCmd.Flags().StringVar(&evalPrior, "prior", "", "JSON file containing record before change")
+	EvalCmd.Flags().StringVar(&evalUser, "user", "", "JSON file containing $User fields")
+	EvalCmd.Flags().StringVar(&evalProfile, "profile", "", "JSON file containing $Profile fields")
+}
+
+var EvalCmd = &cobra.Command{
+	Use:   "eval [flags] [formula]",
+	Short: "Evaluate a formula against a record",
+	Long: `
+Evaluate a formula against a record and print the result.
+
+	The formula can be given as an argument or read from a field, validation
+	rule, workflow, or flow file with --file.  The record and the fields of
+	$User and $Profile are read from JSON files.
+`,
+	Example: `
+$ force-md formula eval --record account.json 'IF(ISPICKVAL(Type, "Customer"), AnnualRevenue * 0.1, 0)'
+
+$ force-md formula eval --record opportunity.json --prior opportunity-prior.json --file src/objects/Opportunity/validationRules/Stage_Locked.validationRule-meta.xml
+`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 && evalFile == "" || len(args) > 0 && evalFile != "" {
+			return fmt.Errorf("either a formula or --file is required")
+		}
+		return cobra.MaximumNArgs(1)(cmd, args)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		t := formulas.TestCase{
+			File:      evalFile,
+			Component: evalComponent,
+			Element:   evalElement,
+		}
+		if len(args) > 0 {
+			t.Formula = args[0]
+		}
+		if evalRecord != "" {
+			t.Record = evalRecord
+		}
+		if evalPrior != "" {
+			t.Prior = evalPrior
+		}
+		t.User = readFields(evalUser)
+		t.Profile = readFields(evalProfile)
+		result, err := (&formulas.Suite{}).Evaluate(t)
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+		fmt.Println(formula.Format(result))
+	},
+}
+
+func readFields(file string) map[string]any {
+	if file == "" {
+		return nil
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		log.Fatal("parsing " + file + ": " + err.Error())
+	}
+	return fields
+}
@@ -0,0 +1,82 @@
+package formula
+
+import (
+	"fmt"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/ForceCLI/force-md/repo/formulas"
+)
+
+var TestCmd = &cobra.Command{
+	Use:   "test [filename]...",
+	Short: "Run formula test cases",
+	Long: `
+Run formula test cases defined in YAML files.
+
+	Each test case evaluates a formula against a record and compares the result
+	to the expected value.  The formula can be given inline or read from a
+	field, validation rule, workflow, or flow file, with paths relative to the
+	YAML file.  Records can be given inline or as the path to a JSON file.  The
+	fields of $User and $Profile are set for all tests at the top level, and
+	can be overridden by each test.
+
+	A prior record can be given to test ISCHANGED and PRIORVALUE.  Validation
+	rules are expected to be true when the record is invalid.
+
+	The command exits with a non-zero status if any test fails.
+`,
+	Example: `
+$ cat formulas.yaml
+user:
+  Department: Sales
+now: 2024-03-01T12:00:00Z
+tests:
+  - name: discount requires approval
+    file: src/objects/Opportunity/validationRules/Discount_Approval.validationRule-meta.xml
+    record:
+      Discount__c: 30
+      StageName: Negotiation
+    prior: records/opportunity.json
+    expect: true
+  - name: days open
+    formula: TODAY() - CreatedDate__c
+    record:
+      CreatedDate__c: 2024-02-01
+    expect: 29
+
+$ force-md formula test formulas.yaml
+`,
+	Args:                  cobra.MinimumNArgs(1),
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		passed, failed := 0, 0
+		for _, file := range args {
+			suite, err := formulas.LoadSuite(file)
+			if err != nil {
+				log.Warn(err.Error())
+				failed++
+				continue
+			}
+			for _, r := range suite.Run() {
+				switch {
+				case r.Error != nil:
+					fmt.Printf("FAIL %s: %s: %s\n", file, r.Name, r.Error.Error())
+					failed++
+				case !r.Passed():
+					fmt.Printf("FAIL %s: %s: expected %q, got %q\n", file, r.Name, r.Expected, r.Actual)
+					failed++
+				default:
+					fmt.Printf("ok   %s: %s\n", file, r.Name)
+					passed++
+				}
+			}
+		}
+		fmt.Printf("%d passed, %d failed\n", passed, failed)
+		if failed > 0 {
+			os.Exit(1)
+		}
+	},
+}
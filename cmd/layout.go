@@ -0,0 +1,19 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/ForceCLI/force-md/cmd/layout"
+)
+
+func init() {
+	layoutCmd.AddCommand(layout.FieldCmd)
+	layoutCmd.AddCommand(layout.RelatedListCmd)
+	layoutCmd.AddCommand(layout.QuickActionCmd)
+	RootCmd.AddCommand(layoutCmd)
+}
+
+var layoutCmd = &cobra.Command{
+	Use:   "layout",
+	Short: "Manage page layouts",
+}
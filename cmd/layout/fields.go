@@ -0,0 +1,238 @@
+package layout
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/ForceCLI/force-md/cmd/repo"
+	"github.com/ForceCLI/force-md/internal"
+	layout "github.com/ForceCLI/force-md/metadata/layouts"
+)
+
+func init() {
+	listFieldsCmd.Flags().StringP("field", "f", "", "only list field (Field or Object.Field)")
+
+	for _, c := range []*cobra.Command{addFieldCmd, deleteFieldCmd, moveFieldCmd, behaviorCmd} {
+		c.Flags().StringP("field", "f", "", "field (Field or Object.Field)")
+		c.MarkFlagRequired("field")
+	}
+	for _, c := range []*cobra.Command{addFieldCmd, moveFieldCmd} {
+		c.Flags().StringP("section", "s", "", "section label")
+		c.Flags().IntP("column", "c", 1, "column number")
+		c.Flags().String("after", "", "add after field (default end of column)")
+		c.MarkFlagRequired("section")
+	}
+	addFieldCmd.Flags().StringP("behavior", "b", "Edit", "behavior; can be "+strings.Join(layout.Behaviors, ", "))
+	behaviorCmd.Flags().StringP("behavior", "b", "", "behavior; can be "+strings.Join(layout.Behaviors, ", "))
+	behaviorCmd.MarkFlagRequired("behavior")
+
+	FieldCmd.AddCommand(listFieldsCmd)
+	FieldCmd.AddCommand(addFieldCmd)
+	FieldCmd.AddCommand(deleteFieldCmd)
+	FieldCmd.AddCommand(moveFieldCmd)
+	FieldCmd.AddCommand(behaviorCmd)
+}
+
+var FieldCmd = &cobra.Command{
+	Use:   "field",
+	Short: "Manage layout fields",
+}
+
+var listFieldsCmd = &cobra.Command{
+	Use:   "list [flags] [filename]...",
+	Short: "List layout fields",
+	Long: `
+List the fields in layout sections, with their behavior, section, and column.
+`,
+	Example: `
+$ force-md layout field list --project . -f Case.Retired__c
+`,
+	Args:                  repo.FilesRequired,
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		object, field := objectField(cmd)
+		forEachLayout(args, object, func(name string, l *layout.Layout) {
+			for _, f := range l.Fields() {
+				if field != "" && !strings.EqualFold(f.Field, field) {
+					continue
+				}
+				fmt.Printf("%s: %s (%s) in %s, column %d\n", name, f.Field, f.Behavior, f.Section, f.Column)
+			}
+		})
+	},
+}
+
+var addFieldCmd = &cobra.Command{
+	Use:   "add -f Field -s Section [flags] [filename]...",
+	Short: "Add field to layouts",
+	Long: `
+Add a field to a section of layouts.
+
+	If the field is qualified with its object, only that object's layouts are
+	updated.  Layouts that already include the field are skipped.
+`,
+	Example: `
+$ force-md layout field add -f Case.Reason__c -s "Case Information" -c 2 --project .
+
+$ force-md layout field add -f Reason__c -s "Case Information" --after Status -b Required "src/layouts/Case-Case Layout.layout"
+`,
+	Args:                  repo.FilesRequired,
+	DisableFlagsInUseLine: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		object, field := objectField(cmd)
+		behavior, err := behaviorFlag(cmd)
+		if err != nil {
+			return err
+		}
+		section, _ := cmd.Flags().GetString("section")
+		column, _ := cmd.Flags().GetInt("column")
+		after, _ := cmd.Flags().GetString("after")
+		updateLayouts(args, object, func(l *layout.Layout) error {
+			err := l.AddField(field, behavior, section, column, after)
+			if err == layout.FieldExistsError {
+				return nil
+			}
+			return err
+		})
+		return nil
+	},
+}
+
+var deleteFieldCmd = &cobra.Command{
+	Use:   "delete -f Field [filename]...",
+	Short: "Remove field from layouts",
+	Long: `
+Remove a field from layouts, including related content, the mini layout, and
+the highlights panel.
+
+	If the field is qualified with its object, only that object's layouts are
+	updated.
+`,
+	Example: `
+$ force-md layout field delete -f Case.Retired__c --project .
+`,
+	Args:                  repo.FilesRequired,
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		object, field := objectField(cmd)
+		updateLayouts(args, object, func(l *layout.Layout) error {
+			return l.DeleteField(field)
+		})
+	},
+}
+
+var moveFieldCmd = &cobra.Command{
+	Use:   "move -f Field -s Section [flags] [filename]...",
+	Short: "Move field within layouts",
+	Long: `
+Move a field to a section and column of layouts, keeping its behavior.
+`,
+	Example: `
+$ force-md layout field move -f Case.Priority -s "Case Information" -c 1 --after Status --project .
+`,
+	Args:                  repo.FilesRequired,
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		object, field := objectField(cmd)
+		section, _ := cmd.Flags().GetString("section")
+		column, _ := cmd.Flags().GetInt("column")
+		after, _ := cmd.Flags().GetString("after")
+		updateLayouts(args, object, func(l *layout.Layout) error {
+			return l.MoveField(field, section, column, after)
+		})
+	},
+}
+
+var behaviorCmd = &cobra.Command{
+	Use:   "behavior -f Field -b Behavior [filename]...",
+	Short: "Set field behavior in layouts",
+	Long: `
+Set whether a field is editable, required, or read-only in layouts.
+`,
+	Example: `
+$ force-md layout field behavior -f Case.Status -b Required --project .
+`,
+	Args:                  repo.FilesRequired,
+	DisableFlagsInUseLine: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		object, field := objectField(cmd)
+		behavior, err := behaviorFlag(cmd)
+		if err != nil {
+			return err
+		}
+		updateLayouts(args, object, func(l *layout.Layout) error {
+			return l.SetFieldBehavior(field, behavior)
+		})
+		return nil
+	},
+}
+
+// objectField splits the field flag into an optional object and a field
+func objectField(cmd *cobra.Command) (string, string) {
+	field, _ := cmd.Flags().GetString("field")
+	if object, name, ok := strings.Cut(field, "."); ok {
+		return object, name
+	}
+	return "", field
+}
+
+func behaviorFlag(cmd *cobra.Command) (string, error) {
+	behavior, _ := cmd.Flags().GetString("behavior")
+	for _, b := range layout.Behaviors {
+		if strings.EqualFold(b, behavior) {
+			return b, nil
+		}
+	}
+	return "", errors.Errorf("invalid behavior: %s", behavior)
+}
+
+// Layout names are prefixed with the object name, e.g. Account-Account Layout
+func layoutObject(l *layout.Layout) string {
+	object, _, _ := strings.Cut(string(l.GetMetadataInfo().Name()), "-")
+	return object
+}
+
+// forEachLayout calls f for each layout, skipping layouts of other objects
+// if object isn't empty
+func forEachLayout(args []string, object string, f func(name string, l *layout.Layout)) {
+	for _, file := range repo.Files(args, layout.NAME) {
+		l, err := layout.Open(file)
+		if err != nil {
+			log.Warn("parsing layout failed: " + err.Error())
+			continue
+		}
+		if object != "" && !strings.EqualFold(layoutObject(l), object) {
+			continue
+		}
+		f(string(l.GetMetadataInfo().Name()), l)
+	}
+}
+
+// updateLayouts applies an update to each layout and writes it back to
+// file.  When updating all of a project's layouts, layouts that don't include
+// the field, related list, or quick action being changed are skipped quietly.
+func updateLayouts(args []string, object string, update func(l *layout.Layout) error) {
+	bulk := len(args) == 0
+	forEachLayout(args, object, func(name string, l *layout.Layout) {
+		file := string(l.GetMetadataInfo().Path())
+		if err := update(l); err != nil {
+			if bulk && notFound(err) {
+				log.Debug(fmt.Sprintf("skipping %s: %s", file, err.Error()))
+				return
+			}
+			log.Warn(fmt.Sprintf("update failed for %s: %s", file, err.Error()))
+			return
+		}
+		if err := internal.WriteToFile(l, file); err != nil {
+			log.Warn("update failed: " + err.Error())
+		}
+	})
+}
+
+func notFound(err error) bool {
+	return err == layout.FieldNotFoundError || err == layout.RelatedListNotFoundError || err == layout.QuickActionNotFoundError
+}
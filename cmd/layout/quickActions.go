@@ -0,0 +1,93 @@
+package layout
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ForceCLI/force-md/cmd/repo"
+	layout "github.com/ForceCLI/force-md/metadata/layouts"
+)
+
+func init() {
+	for _, c := range []*cobra.Command{listQuickActionsCmd, addQuickActionCmd, deleteQuickActionCmd} {
+		c.Flags().StringP("object", "o", "", "only update layouts of object")
+	}
+	for _, c := range []*cobra.Command{addQuickActionCmd, deleteQuickActionCmd} {
+		c.Flags().StringP("action", "a", "", "quick action, e.g. FeedItem.TextPost or Case.Close")
+		c.MarkFlagRequired("action")
+	}
+
+	QuickActionCmd.AddCommand(listQuickActionsCmd)
+	QuickActionCmd.AddCommand(addQuickActionCmd)
+	QuickActionCmd.AddCommand(deleteQuickActionCmd)
+}
+
+var QuickActionCmd = &cobra.Command{
+	Use:   "quickaction",
+	Short: "Manage layout quick actions",
+}
+
+var listQuickActionsCmd = &cobra.Command{
+	Use:                   "list [flags] [filename]...",
+	Short:                 "List layout quick actions",
+	Args:                  repo.FilesRequired,
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		object, _ := cmd.Flags().GetString("object")
+		forEachLayout(args, object, func(name string, l *layout.Layout) {
+			for _, a := range l.QuickActions() {
+				fmt.Printf("%s: %s\n", name, a)
+			}
+		})
+	},
+}
+
+var addQuickActionCmd = &cobra.Command{
+	Use:   "add -a QuickAction [flags] [filename]...",
+	Short: "Add quick action to layouts",
+	Long: `
+Add a quick action to the end of layouts' publisher actions.
+
+	If a layout overrides the predefined actions in Lightning Experience, the
+	action is added there too.  Layouts that already include the action are
+	skipped.
+`,
+	Example: `
+$ force-md layout quickaction add -o Case -a Case.Close --project .
+`,
+	Args:                  repo.FilesRequired,
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		object, _ := cmd.Flags().GetString("object")
+		action, _ := cmd.Flags().GetString("action")
+		updateLayouts(args, object, func(l *layout.Layout) error {
+			err := l.AddQuickAction(action)
+			if err == layout.QuickActionExistsError {
+				return nil
+			}
+			return err
+		})
+	},
+}
+
+var deleteQuickActionCmd = &cobra.Command{
+	Use:   "delete -a QuickAction [flags] [filename]...",
+	Short: "Remove quick action from layouts",
+	Long: `
+Remove a quick action from layouts' publisher actions and Lightning
+Experience actions.
+`,
+	Example: `
+$ force-md layout quickaction delete -a Case.Close --project .
+`,
+	Args:                  repo.FilesRequired,
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		object, _ := cmd.Flags().GetString("object")
+		action, _ := cmd.Flags().GetString("action")
+		updateLayouts(args, object, func(l *layout.Layout) error {
+			return l.DeleteQuickAction(action)
+		})
+	},
+}
@@ -0,0 +1,97 @@
+package layout
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ForceCLI/force-md/cmd/repo"
+	layout "github.com/ForceCLI/force-md/metadata/layouts"
+)
+
+func init() {
+	for _, c := range []*cobra.Command{listRelatedListsCmd, addRelatedListCmd, deleteRelatedListCmd} {
+		c.Flags().StringP("object", "o", "", "only update layouts of object")
+	}
+	for _, c := range []*cobra.Command{addRelatedListCmd, deleteRelatedListCmd} {
+		c.Flags().StringP("related-list", "r", "", "related list, e.g. RelatedContactList or Invoice__c.Account__c")
+		c.MarkFlagRequired("related-list")
+	}
+	addRelatedListCmd.Flags().StringSliceP("fields", "f", nil, "columns to display")
+	addRelatedListCmd.Flags().String("sort-field", "", "field to sort by")
+	addRelatedListCmd.Flags().String("sort-order", "", "sort order; can be Asc or Desc (default Asc)")
+
+	RelatedListCmd.AddCommand(listRelatedListsCmd)
+	RelatedListCmd.AddCommand(addRelatedListCmd)
+	RelatedListCmd.AddCommand(deleteRelatedListCmd)
+}
+
+var RelatedListCmd = &cobra.Command{
+	Use:   "relatedlist",
+	Short: "Manage layout related lists",
+}
+
+var listRelatedListsCmd = &cobra.Command{
+	Use:                   "list [flags] [filename]...",
+	Short:                 "List layout related lists",
+	Args:                  repo.FilesRequired,
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		object, _ := cmd.Flags().GetString("object")
+		forEachLayout(args, object, func(name string, l *layout.Layout) {
+			for _, r := range l.RelatedLists {
+				fmt.Printf("%s: %s\n", name, r.RelatedList.String())
+			}
+		})
+	},
+}
+
+var addRelatedListCmd = &cobra.Command{
+	Use:   "add -r RelatedList [flags] [filename]...",
+	Short: "Add related list to layouts",
+	Long: `
+Add a related list to the end of layouts' related lists.  Layouts that already
+include the related list are skipped.
+
+	Standard related lists are named like RelatedContactList.  Related lists
+	of custom objects are named by the child object and its lookup or
+	master-detail field, e.g. Invoice__c.Account__c, not by the relationship
+	name.
+`,
+	Example: `
+$ force-md layout relatedlist add -o Account -r Invoice__c.Account__c -f NAME,Amount__c --sort-field Amount__c --sort-order Desc --project .
+`,
+	Args:                  repo.FilesRequired,
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		object, _ := cmd.Flags().GetString("object")
+		relatedList, _ := cmd.Flags().GetString("related-list")
+		fields, _ := cmd.Flags().GetStringSlice("fields")
+		sortField, _ := cmd.Flags().GetString("sort-field")
+		sortOrder, _ := cmd.Flags().GetString("sort-order")
+		updateLayouts(args, object, func(l *layout.Layout) error {
+			err := l.AddRelatedList(relatedList, fields, sortField, sortOrder)
+			if err == layout.RelatedListExistsError {
+				return nil
+			}
+			return err
+		})
+	},
+}
+
+var deleteRelatedListCmd = &cobra.Command{
+	Use:   "delete -r RelatedList [flags] [filename]...",
+	Short: "Remove related list from layouts",
+	Example: `
+$ force-md layout relatedlist delete -o Account -r Invoice__c.Account__c --project .
+`,
+	Args:                  repo.FilesRequired,
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		object, _ := cmd.Flags().GetString("object")
+		relatedList, _ := cmd.Flags().GetString("related-list")
+		updateLayouts(args, object, func(l *layout.Layout) error {
+			return l.DeleteRelatedList(relatedList)
+		})
+	},
+}
@@ -0,0 +1,17 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/ForceCLI/force-md/cmd/lint"
+)
+
+func init() {
+	lintCmd.AddCommand(lint.RefsCmd)
+	RootCmd.AddCommand(lintCmd)
+}
+
+var lintCmd = &cobra.Command{
+	Use:   "lint",
+	Short: "Check metadata for problems",
+}
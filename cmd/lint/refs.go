@@ -0,0 +1,80 @@
+package lint
+
+import (
+	"fmt"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/ForceCLI/force-md/cmd/repo"
+	"github.com/ForceCLI/force-md/internal"
+	"github.com/ForceCLI/force-md/metadata"
+	"github.com/ForceCLI/force-md/metadata/permissionset"
+	"github.com/ForceCLI/force-md/metadata/profile"
+	"github.com/ForceCLI/force-md/repo/lint"
+)
+
+var fix bool
+
+func init() {
+	RefsCmd.Flags().BoolVar(&fix, "fix", false, "remove references to missing components")
+}
+
+var RefsCmd = &cobra.Command{
+	Use:   "refs [filename]...",
+	Short: "Check profiles and permission sets for references to missing components",
+	Long: `
+Check profiles and permission sets for references to fields, objects, record
+types, Apex classes, Visualforce pages, tabs, applications, flows, layouts, and
+custom permissions that don't exist in the project.
+
+	Standard objects and fields, components from managed packages, and
+	components of types not in the project are assumed to exist.  The command
+	exits with a non-zero status if any missing references are found, unless
+	--fix is used to remove them.
+`,
+	Example: `
+$ force-md lint refs --project .
+
+$ force-md lint refs --project . --fix src/profiles/Admin.profile
+`,
+	Args:                  repo.FilesRequired,
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		var items []metadata.RegisterableMetadata
+		for _, file := range repo.Files(args, permissionset.NAME, profile.NAME) {
+			m, err := repo.Metadata.Open(file)
+			if err != nil {
+				log.Warnf("failed to open %s: %s", file, err.Error())
+				continue
+			}
+			items = append(items, m.(metadata.RegisterableMetadata))
+		}
+		checker := lint.NewRefChecker(repo.Metadata)
+		missing := false
+		for _, m := range items {
+			file := string(m.GetMetadataInfo().Path())
+			if !fix {
+				for _, r := range checker.MissingReferences(m) {
+					fmt.Printf("%s: %s\n", file, r)
+					missing = true
+				}
+				continue
+			}
+			removed := checker.RemoveMissingReferences(m)
+			if len(removed) == 0 {
+				continue
+			}
+			for _, r := range removed {
+				fmt.Printf("%s: removed %s\n", file, r)
+			}
+			if err := internal.WriteToFile(m, file); err != nil {
+				log.Warn("write failed: " + err.Error())
+			}
+		}
+		if missing {
+			os.Exit(1)
+		}
+	},
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/ForceCLI/force-md/general"
+	"github.com/ForceCLI/force-md/internal"
+	"github.com/ForceCLI/force-md/internal/semantic"
+	"github.com/ForceCLI/force-md/metadata"
+	"github.com/ForceCLI/force-md/repo"
+)
+
+func init() {
+	RootCmd.AddCommand(mergeDriverCmd)
+}
+
+var mergeDriverCmd = &cobra.Command{
+	Use:   "merge-driver base ours theirs",
+	Short: "Merge metadata files as a git merge driver",
+	Long: `
+Perform a three-way merge of a metadata file, writing the result to ours.
+
+	Entries in lists, such as field permissions or custom fields, are matched by
+	their natural key, so independent additions, removals, and changes to
+	different entries are merged automatically.  The result is tidied.  Where
+	both sides changed the same value, or one side removed an entry that the
+	other changed, conflict markers are written around the differing lines and
+	the command exits with a non-zero status.
+
+	To use force-md as a merge driver, add it to your git config:
+
+	[merge "force-md"]
+		name = force-md metadata merge
+		driver = force-md merge-driver %O %A %B
+
+	and set the merge attribute in .gitattributes:
+
+	*.profile merge=force-md
+	*.permissionset merge=force-md
+	*.object merge=force-md
+	*.labels merge=force-md
+`,
+	Example: `
+$ force-md merge-driver base.profile ours.profile theirs.profile
+`,
+	Args:                  cobra.ExactArgs(3),
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		basePath, oursPath, theirsPath := args[0], args[1], args[2]
+		ours, err := repo.MetadataFromPath(oursPath)
+		if err != nil {
+			log.Fatalf("invalid file %s: %s", oursPath, err.Error())
+		}
+		theirs, err := repo.MetadataFromPath(theirsPath)
+		if err != nil {
+			log.Fatalf("invalid file %s: %s", theirsPath, err.Error())
+		}
+		if ours.Type() != theirs.Type() {
+			log.Fatalf("cannot merge %s with %s", ours.Type(), theirs.Type())
+		}
+		// The base is empty if the file was added on both sides
+		var base metadata.RegisterableMetadata
+		if info, err := os.Stat(basePath); err != nil || info.Size() > 0 {
+			base, err = repo.MetadataFromPath(basePath)
+			if err != nil {
+				log.Fatalf("invalid file %s: %s", basePath, err.Error())
+			}
+			if base.Type() != ours.Type() {
+				log.Fatalf("cannot merge %s with %s", base.Type(), ours.Type())
+			}
+		}
+
+		mergedOurs, mergedTheirs, _ := semantic.Merge(base, ours, theirs)
+		contents, err := marshalTidy(mergedOurs)
+		if err != nil {
+			log.Fatal("merge failed: " + err.Error())
+		}
+		theirsContents, err := marshalTidy(mergedTheirs)
+		if err != nil {
+			log.Fatal("merge failed: " + err.Error())
+		}
+		conflicts := string(contents) != string(theirsContents)
+		if conflicts {
+			contents = semantic.ConflictMarkers(contents, theirsContents)
+		}
+		if err := os.WriteFile(oursPath, contents, 0644); err != nil {
+			log.Fatal("writing merge result failed: " + err.Error())
+		}
+		if conflicts {
+			os.Exit(1)
+		}
+	},
+}
+
+func marshalTidy(m any) ([]byte, error) {
+	if t, ok := m.(general.Tidyable); ok {
+		t.Tidy()
+	}
+	return internal.Marshal(m)
+}
@@ -15,6 +15,8 @@ func init() {
 	objectsCmd.AddCommand(objects.ValidationRuleCmd)
 	objectsCmd.AddCommand(objects.ActionCmd)
 	objectsCmd.AddCommand(objects.WebLinkCmd)
+	objectsCmd.AddCommand(objects.DecomposeCmd)
+	objectsCmd.AddCommand(objects.ComposeCmd)
 	RootCmd.AddCommand(objectsCmd)
 }
 
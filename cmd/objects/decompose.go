@@ -0,0 +1,87 @@
+package objects
+
+import (
+	"path/filepath"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/ForceCLI/force-md/internal"
+	"github.com/ForceCLI/force-md/metadata/objects"
+)
+
+var outputDir string
+
+func init() {
+	DecomposeCmd.Flags().StringVarP(&outputDir, "directory", "d", "", "directory where objects should be output")
+	DecomposeCmd.MarkFlagRequired("directory")
+
+	ComposeCmd.Flags().StringVarP(&outputDir, "directory", "d", "", "directory where objects should be output")
+	ComposeCmd.MarkFlagRequired("directory")
+}
+
+var DecomposeCmd = &cobra.Command{
+	Use:   "decompose -d directory [filename]...",
+	Short: "Convert objects to sfdx source format",
+	Long: `
+Convert objects from metadata API format to sfdx source format.
+
+	Each object is written to its own directory, with fields, record types, list
+	views, validation rules, web links, compact layouts, field sets, business
+	processes, indexes, and sharing reasons split into separate files.
+`,
+	Example: `
+$ force-md objects decompose -d sfdx/main/default/objects src/objects/*
+`,
+	Args:                  cobra.MinimumNArgs(1),
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		for _, file := range args {
+			decompose(file, outputDir)
+		}
+	},
+}
+
+var ComposeCmd = &cobra.Command{
+	Use:   "compose -d directory [directory]...",
+	Short: "Convert objects from sfdx source format",
+	Long: `
+Convert objects from sfdx source format to metadata API format.
+
+	Each directory should contain an object in sfdx source format.  The object
+	and its child components are combined into a single, tidied .object file.
+`,
+	Example: `
+$ force-md objects compose -d src/objects sfdx/main/default/objects/*
+`,
+	Args:                  cobra.MinimumNArgs(1),
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		for _, dir := range args {
+			compose(dir, outputDir)
+		}
+	},
+}
+
+func decompose(file string, dir string) {
+	o, err := objects.Open(file)
+	if err != nil {
+		log.Warn("parsing object failed: " + err.Error())
+		return
+	}
+	if err = o.Decompose(dir); err != nil {
+		log.Warnf("decomposing %s failed: %s", file, err.Error())
+	}
+}
+
+func compose(objectDir string, dir string) {
+	o, err := objects.Compose(objectDir)
+	if err != nil {
+		log.Warnf("composing %s failed: %s", objectDir, err.Error())
+		return
+	}
+	objectName := filepath.Base(filepath.Clean(objectDir))
+	if err = internal.WriteToFile(o, filepath.Join(dir, objectName+".object")); err != nil {
+		log.Warn("write failed: " + err.Error())
+	}
+}
@@ -13,6 +13,7 @@ import (
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
+	"github.com/ForceCLI/force-md/cmd/repo"
 	. "github.com/ForceCLI/force-md/general"
 	"github.com/ForceCLI/force-md/internal"
 	"github.com/ForceCLI/force-md/metadata/objects"
@@ -143,10 +144,10 @@ var FieldCmd = &cobra.Command{
 var listFieldsCmd = &cobra.Command{
 	Use:   "list [flags] [filename]...",
 	Short: "List object fields",
-	Args:  cobra.MinimumNArgs(1),
+	Args:  repo.FilesRequired,
 	Run: func(cmd *cobra.Command, args []string) {
 		filterAttributes := setFields(cmd)
-		for _, file := range args {
+		for _, file := range repo.Files(args, objects.NAME, field.NAME) {
 			listFields(file, filterAttributes)
 		}
 	},
@@ -166,10 +167,10 @@ var listPicklistOptionsCmd = &cobra.Command{
 var tableFieldsCmd = &cobra.Command{
 	Use:   "table [flags] [filename]...",
 	Short: "List object fields in a table",
-	Args:  cobra.MinimumNArgs(1),
+	Args:  repo.FilesRequired,
 	Run: func(cmd *cobra.Command, args []string) {
 		filterAttributes := setFields(cmd)
-		tableFields(args, filterAttributes)
+		tableFields(repo.Files(args, objects.NAME, field.NAME), filterAttributes)
 	},
 }
 
@@ -183,11 +184,11 @@ digraph (https://github.com/golang/tools/blob/gopls/v0.4.4/cmd/digraph/digraph.g
 
  $ force-md objects fields graph --object-only src/objects/* | digraph degree
 `,
-	Args: cobra.MinimumNArgs(1),
+	Args: repo.FilesRequired,
 	Run: func(cmd *cobra.Command, args []string) {
 		objectsOnly, _ := cmd.Flags().GetBool("object-only")
 		filterAttributes := setFields(cmd)
-		for _, file := range args {
+		for _, file := range repo.Files(args, objects.NAME, field.NAME) {
 			graphFields(file, filterAttributes, objectsOnly)
 		}
 	},
@@ -254,18 +255,35 @@ var writeFieldsCmd = &cobra.Command{
 	},
 }
 
+// openObject opens an object.  A field in sfdx source format is opened as an
+// object containing only that field.
+func openObject(file string) (string, *objects.CustomObject, error) {
+	if strings.HasSuffix(file, ".field-meta.xml") {
+		f, err := field.Open(file)
+		if err != nil {
+			return "", nil, err
+		}
+		objectName, _, _ := strings.Cut(string(f.GetMetadataInfo().Name()), ".")
+		return objectName, &objects.CustomObject{Fields: objects.FieldList{f.Field}}, nil
+	}
+	o, err := objects.Open(file)
+	if err != nil {
+		return "", nil, err
+	}
+	return internal.TrimSuffixToEnd(path.Base(file), ".object"), o, nil
+}
+
 var alwaysRequired map[string]bool = map[string]bool{
 	"Name":    true,
 	"OwnerId": true,
 }
 
 func listFields(file string, attributes field.Field) {
-	o, err := objects.Open(file)
+	objectName, o, err := openObject(file)
 	if err != nil {
 		log.Warn("parsing object failed: " + err.Error())
 		return
 	}
-	objectName := internal.TrimSuffixToEnd(path.Base(file), ".object")
 	var filters []field.FieldFilter
 	requiredFilter := func(f field.Field) bool {
 		isRequired := alwaysRequired[f.FullName] || (f.Required != nil && f.Required.Text == "true")
@@ -338,12 +356,11 @@ func listFields(file string, attributes field.Field) {
 }
 
 func graphFields(file string, attributes field.Field, objectsOnly bool) {
-	o, err := objects.Open(file)
+	objectName, o, err := openObject(file)
 	if err != nil {
 		log.Warn("parsing object failed: " + err.Error())
 		return
 	}
-	objectName := internal.TrimSuffixToEnd(path.Base(file), ".object")
 	var filters []field.FieldFilter
 	requiredFilter := func(f field.Field) bool {
 		isRequired := alwaysRequired[f.FullName] || (f.Required != nil && f.Required.Text == "true")
@@ -488,12 +505,11 @@ func tableFields(files []string, attributes field.Field) {
 	}
 	var fields []field
 	for _, file := range files {
-		o, err := objects.Open(file)
+		objectName, o, err := openObject(file)
 		if err != nil {
 			log.Warn("parsing object failed: " + err.Error())
 			return
 		}
-		objectName := internal.TrimSuffixToEnd(path.Base(file), ".object")
 		fields = append(fields, field{object: objectName, fields: o.GetFields(filters...)})
 	}
 	table := tablewriter.NewWriter(os.Stdout)
@@ -12,6 +12,9 @@ func init() {
 	packageCmd.AddCommand(pkg.TidyCmd)
 	packageCmd.AddCommand(pkg.ListCmd)
 	packageCmd.AddCommand(pkg.NewCmd)
+	packageCmd.AddCommand(pkg.FromDiffCmd)
+	packageCmd.AddCommand(pkg.GenerateCmd)
+	packageCmd.AddCommand(pkg.ValidateCmd)
 	RootCmd.AddCommand(packageCmd)
 }
 
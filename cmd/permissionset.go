@@ -21,6 +21,8 @@ func init() {
 	permissionSetCmd.AddCommand(permissionset.RecordTypeCmd)
 	permissionSetCmd.AddCommand(permissionset.MergeCmd)
 	permissionSetCmd.AddCommand(permissionset.EditCmd)
+	permissionSetCmd.AddCommand(permissionset.DecomposeCmd)
+	permissionSetCmd.AddCommand(permissionset.ComposeCmd)
 	RootCmd.AddCommand(permissionSetCmd)
 }
 
@@ -6,6 +6,7 @@ import (
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
+	"github.com/ForceCLI/force-md/cmd/repo"
 	"github.com/ForceCLI/force-md/internal"
 	"github.com/ForceCLI/force-md/metadata/permissionset"
 )
@@ -58,10 +59,10 @@ var deleteClassCmd = &cobra.Command{
 var listClassesCmd = &cobra.Command{
 	Use:                   "list [filename]...",
 	Short:                 "List apex classes",
-	Args:                  cobra.MinimumNArgs(1),
+	Args:                  repo.FilesRequired,
 	DisableFlagsInUseLine: true,
 	Run: func(cmd *cobra.Command, args []string) {
-		for _, file := range args {
+		for _, file := range repo.Files(args, permissionset.NAME) {
 			listClasses(file)
 		}
 	},
@@ -8,6 +8,7 @@ import (
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
+	"github.com/ForceCLI/force-md/cmd/repo"
 	"github.com/ForceCLI/force-md/internal"
 	"github.com/ForceCLI/force-md/metadata/permissionset"
 )
@@ -100,9 +101,9 @@ var addApplicationCmd = &cobra.Command{
 var listApplicationsCmd = &cobra.Command{
 	Use:   "list [flags] [filename]...",
 	Short: "List applications assigned",
-	Args:  cobra.MinimumNArgs(1),
+	Args:  repo.FilesRequired,
 	Run: func(cmd *cobra.Command, args []string) {
-		for _, file := range args {
+		for _, file := range repo.Files(args, permissionset.NAME) {
 			listApplications(file)
 		}
 	},
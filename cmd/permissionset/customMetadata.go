@@ -6,6 +6,7 @@ import (
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
+	"github.com/ForceCLI/force-md/cmd/repo"
 	"github.com/ForceCLI/force-md/internal"
 	"github.com/ForceCLI/force-md/metadata/permissionset"
 )
@@ -44,9 +45,9 @@ var addCustomMetadataTypeCmd = &cobra.Command{
 var listCustomMetadataTypesCmd = &cobra.Command{
 	Use:   "list [flags] [filename]...",
 	Short: "List custom metadata types enabled",
-	Args:  cobra.MinimumNArgs(1),
+	Args:  repo.FilesRequired,
 	Run: func(cmd *cobra.Command, args []string) {
-		for _, file := range args {
+		for _, file := range repo.Files(args, permissionset.NAME) {
 			listCustomMetadataTypes(file)
 		}
 	},
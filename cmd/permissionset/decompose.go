@@ -0,0 +1,91 @@
+package permissionset
+
+import (
+	"path/filepath"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/ForceCLI/force-md/internal"
+	"github.com/ForceCLI/force-md/metadata/permissionset"
+)
+
+var outputDir string
+
+func init() {
+	DecomposeCmd.Flags().StringVarP(&outputDir, "directory", "d", "", "directory where permission sets should be output")
+	DecomposeCmd.MarkFlagRequired("directory")
+
+	ComposeCmd.Flags().StringVarP(&outputDir, "directory", "d", "", "directory where permission sets should be output")
+	ComposeCmd.MarkFlagRequired("directory")
+}
+
+var DecomposeCmd = &cobra.Command{
+	Use:   "decompose -d directory [filename]...",
+	Short: "Split permission sets into per-object files",
+	Long: `
+Split permission sets into a directory per permission set.
+
+	The field permissions, object permissions, and
+	record type visibilities for each object are written to
+	objectSettings/Object.objectSettings-meta.xml, and the remaining settings
+	are written to Name.permissionset-meta.xml.
+
+	The output directory should be outside of the project's package
+	directories so the decomposed files aren't deployed.
+`,
+	Example: `
+$ force-md permissionset decompose -d decomposed/permissionsets src/permissionsets/*
+`,
+	Args:                  cobra.MinimumNArgs(1),
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		for _, file := range args {
+			decompose(file, outputDir)
+		}
+	},
+}
+
+var ComposeCmd = &cobra.Command{
+	Use:   "compose -d directory [directory]...",
+	Short: "Combine decomposed permission sets",
+	Long: `
+Combine permission sets split by decompose into a single file per permission set.
+
+	Each directory should contain a permission set and its objectSettings.  The result is
+	tidied and written to Name.permissionset-meta.xml.
+`,
+	Example: `
+$ force-md permissionset compose -d src/permissionsets decomposed/permissionsets/*
+`,
+	Args:                  cobra.MinimumNArgs(1),
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		for _, dir := range args {
+			compose(dir, outputDir)
+		}
+	},
+}
+
+func decompose(file string, dir string) {
+	p, err := permissionset.Open(file)
+	if err != nil {
+		log.Warn("parsing permission set failed: " + err.Error())
+		return
+	}
+	if err = p.Decompose(dir); err != nil {
+		log.Warnf("decomposing %s failed: %s", file, err.Error())
+	}
+}
+
+func compose(decomposedDir string, dir string) {
+	p, err := permissionset.Compose(decomposedDir)
+	if err != nil {
+		log.Warnf("composing %s failed: %s", decomposedDir, err.Error())
+		return
+	}
+	name := filepath.Base(filepath.Clean(decomposedDir))
+	if err = internal.WriteToFile(p, filepath.Join(dir, name+".permissionset-meta.xml")); err != nil {
+		log.Warn("write failed: " + err.Error())
+	}
+}
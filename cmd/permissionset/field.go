@@ -10,6 +10,7 @@ import (
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
+	"github.com/ForceCLI/force-md/cmd/repo"
 	"github.com/ForceCLI/force-md/internal"
 	"github.com/ForceCLI/force-md/metadata/permissionset"
 )
@@ -127,10 +128,10 @@ var deleteFieldCmd = &cobra.Command{
 var listFieldsCmd = &cobra.Command{
 	Use:                   "list [filename]...",
 	Short:                 "List fields",
-	Args:                  cobra.MinimumNArgs(1),
+	Args:                  repo.FilesRequired,
 	DisableFlagsInUseLine: true,
 	Run: func(cmd *cobra.Command, args []string) {
-		for _, file := range args {
+		for _, file := range repo.Files(args, permissionset.NAME) {
 			listFields(file)
 		}
 	},
@@ -139,9 +140,9 @@ var listFieldsCmd = &cobra.Command{
 var tableFieldsCmd = &cobra.Command{
 	Use:   "table [flags] [filename]...",
 	Short: "List Field Permissions in a table",
-	Args:  cobra.MinimumNArgs(1),
+	Args:  repo.FilesRequired,
 	Run: func(cmd *cobra.Command, args []string) {
-		tableFieldPermissions(args)
+		tableFieldPermissions(repo.Files(args, permissionset.NAME))
 	},
 }
 
@@ -11,6 +11,7 @@ import (
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
+	"github.com/ForceCLI/force-md/cmd/repo"
 	. "github.com/ForceCLI/force-md/general"
 	"github.com/ForceCLI/force-md/internal"
 	"github.com/ForceCLI/force-md/metadata/permissionset"
@@ -156,11 +157,11 @@ var listObjectsCmd = &cobra.Command{
 	Use:                   "list [filename]...",
 	Short:                 "List objects",
 	Long:                  "List objects with permissions defined in permission set",
-	Args:                  cobra.MinimumNArgs(1),
+	Args:                  repo.FilesRequired,
 	DisableFlagsInUseLine: true,
 	Run: func(cmd *cobra.Command, args []string) {
 		perms := objectPermissionsFromFlags(cmd)
-		for _, file := range args {
+		for _, file := range repo.Files(args, permissionset.NAME) {
 			listObjectPermissions(file, perms)
 		}
 	},
@@ -169,10 +170,10 @@ var listObjectsCmd = &cobra.Command{
 var tableObjectCmd = &cobra.Command{
 	Use:   "table [flags] [filename]...",
 	Short: "List Object Permissions in a table",
-	Args:  cobra.MinimumNArgs(1),
+	Args:  repo.FilesRequired,
 	Run: func(cmd *cobra.Command, args []string) {
 		perms := objectPermissionsFromFlags(cmd)
-		tableObjectPermissions(args, perms)
+		tableObjectPermissions(repo.Files(args, permissionset.NAME), perms)
 	},
 }
 
@@ -7,6 +7,7 @@ import (
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
+	"github.com/ForceCLI/force-md/cmd/repo"
 	"github.com/ForceCLI/force-md/internal"
 	"github.com/ForceCLI/force-md/metadata/permissionset"
 )
@@ -60,10 +61,10 @@ var deleteRecordTypeCmd = &cobra.Command{
 var listRecordTypesCmd = &cobra.Command{
 	Use:                   "list [filename]...",
 	Short:                 "List record types",
-	Args:                  cobra.MinimumNArgs(1),
+	Args:                  repo.FilesRequired,
 	DisableFlagsInUseLine: true,
 	Run: func(cmd *cobra.Command, args []string) {
-		for _, file := range args {
+		for _, file := range repo.Files(args, permissionset.NAME) {
 			listRecordTypes(file)
 		}
 	},
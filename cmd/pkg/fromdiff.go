@@ -0,0 +1,201 @@
+package pkg
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/ForceCLI/force-md/internal"
+	"github.com/ForceCLI/force-md/metadata/pkg"
+	"github.com/ForceCLI/force-md/repo"
+	"github.com/ForceCLI/force-md/repo/manifest"
+)
+
+var (
+	outputDir  string
+	apiVersion string
+)
+
+func init() {
+	FromDiffCmd.Flags().StringVarP(&outputDir, "output-dir", "d", ".", "directory in which to write package.xml and destructiveChanges.xml")
+	FromDiffCmd.Flags().StringVar(&apiVersion, "api-version", defaultVersion, "API version")
+}
+
+var FromDiffCmd = &cobra.Command{
+	Use:   "from-diff [flags] [base head]",
+	Short: "Create package.xml and destructiveChanges.xml from changed files",
+	Long: `
+Create package.xml and destructiveChanges.xml from the files changed between
+two git commits.
+
+	The changes are read from "git diff --name-status base head".  If no
+	commits are passed, the output of "git diff --name-status" is read from
+	stdin instead.
+
+	Added and modified files are mapped to their metadata type and member,
+	which are written to package.xml.  Files in aura, lwc, static resource,
+	and experience bundles are mapped to the bundle.  Deleted files are
+	written to destructiveChanges.xml, unless the component they belong to
+	still exists, e.g. a file removed from an LWC bundle.  Files that aren't
+	metadata are ignored.
+
+	Metadata types are identified from the files in the working tree, so head
+	should be checked out.  Files that aren't in the working tree are
+	identified by their path.
+`,
+	Example: `
+$ force-md package from-diff origin/main HEAD
+
+$ git diff --name-status origin/main HEAD | force-md package from-diff -d deploy
+`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 && len(args) != 2 {
+			return errors.New("requires base and head commits, or changes on stdin")
+		}
+		return nil
+	},
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		var changes io.Reader = os.Stdin
+		if len(args) == 2 {
+			out, err := exec.Command("git", "diff", "--name-status", "--no-renames", "--relative", args[0], args[1]).Output()
+			if err != nil {
+				if exitErr, ok := err.(*exec.ExitError); ok {
+					log.Fatalf("git diff failed: %s", strings.TrimSpace(string(exitErr.Stderr)))
+				}
+				log.Fatal("git diff failed: " + err.Error())
+			}
+			changes = bytes.NewReader(out)
+		}
+		changed, deleted, err := readChanges(changes)
+		if err != nil {
+			log.Fatal("reading changes failed: " + err.Error())
+		}
+		p, destructive := fromDiff(changed, deleted)
+		if err := os.MkdirAll(outputDir, 0755); err != nil {
+			log.Fatal("creating output directory failed: " + err.Error())
+		}
+		if err := internal.WriteToFile(p, filepath.Join(outputDir, "package.xml")); err != nil {
+			log.Fatal("writing package.xml failed: " + err.Error())
+		}
+		if len(destructive.Types) > 0 {
+			if err := internal.WriteToFile(destructive, filepath.Join(outputDir, "destructiveChanges.xml")); err != nil {
+				log.Fatal("writing destructiveChanges.xml failed: " + err.Error())
+			}
+		}
+	},
+}
+
+// readChanges parses the output of git diff --name-status into the paths
+// that were added or modified and the paths that were deleted.  Renamed and
+// copied files are treated as a deletion of the old path, if renamed, and an
+// addition of the new path.
+func readChanges(r io.Reader) (changed []string, deleted []string, err error) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		if len(fields) < 2 {
+			return nil, nil, errors.Errorf("invalid change: %s", line)
+		}
+		status := fields[0]
+		switch status[0] {
+		case 'D':
+			deleted = append(deleted, fields[1])
+		case 'R':
+			if len(fields) < 3 {
+				return nil, nil, errors.Errorf("invalid rename: %s", line)
+			}
+			deleted = append(deleted, fields[1])
+			changed = append(changed, fields[2])
+		case 'C':
+			if len(fields) < 3 {
+				return nil, nil, errors.Errorf("invalid copy: %s", line)
+			}
+			changed = append(changed, fields[2])
+		default:
+			changed = append(changed, fields[1])
+		}
+	}
+	return changed, deleted, scanner.Err()
+}
+
+func fromDiff(changed []string, deleted []string) (pkg.Package, pkg.Package) {
+	p := pkg.NewPackage(apiVersion)
+	destructive := pkg.NewPackage(apiVersion)
+	added := make(map[manifest.Component]bool)
+	for _, file := range changed {
+		c, ok := changedComponent(file)
+		if !ok || added[c] {
+			continue
+		}
+		added[c] = true
+		if err := p.Add(c.Type, c.Member); err != nil {
+			log.Warn(err.Error())
+		}
+	}
+	removed := make(map[manifest.Component]bool)
+	for _, file := range deleted {
+		c, ok := manifest.ComponentFromPath(file)
+		if !ok || removed[c] || added[c] {
+			continue
+		}
+		if stillExists(file, c) {
+			// A file was removed from a component that still exists
+			added[c] = true
+			if err := p.Add(c.Type, c.Member); err != nil {
+				log.Warn(err.Error())
+			}
+			continue
+		}
+		removed[c] = true
+		if err := destructive.Add(c.Type, c.Member); err != nil {
+			log.Warn(err.Error())
+		}
+	}
+	p.Tidy()
+	destructive.Tidy()
+	return p, destructive
+}
+
+func changedComponent(file string) (manifest.Component, bool) {
+	if m, err := repo.MetadataFromPath(file); err == nil {
+		return manifest.ComponentOf(m)
+	}
+	return manifest.ComponentFromPath(file)
+}
+
+// stillExists returns whether the component that a deleted file belonged to
+// is still in the working tree, e.g. when a file is removed from a bundle
+func stillExists(file string, c manifest.Component) bool {
+	if m, err := repo.MetadataFromPath(file); err == nil {
+		existing, ok := manifest.ComponentOf(m)
+		return ok && existing == c
+	}
+	// Look for the metadata file of a bundle in the directories containing
+	// the file
+	for dir := filepath.Dir(file); ; dir = filepath.Dir(dir) {
+		files, _ := filepath.Glob(filepath.Join(dir, "*-meta.xml"))
+		if len(files) == 1 {
+			if m, err := repo.MetadataFromPath(files[0]); err == nil {
+				if existing, ok := manifest.ComponentOf(m); ok && existing == c {
+					return true
+				}
+			}
+		}
+		if dir == filepath.Dir(dir) || dir == "." {
+			return false
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package pkg
+
+import (
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/ForceCLI/force-md/cmd/repo"
+	"github.com/ForceCLI/force-md/internal"
+	"github.com/ForceCLI/force-md/repo/manifest"
+)
+
+var (
+	output  string
+	exclude []string
+)
+
+func init() {
+	GenerateCmd.Flags().StringVarP(&output, "output", "o", "", "file to write package.xml to instead of stdout")
+	GenerateCmd.Flags().StringVar(&apiVersion, "api-version", defaultVersion, "API version")
+	GenerateCmd.Flags().StringSliceVarP(&exclude, "exclude", "x", nil, "exclude members matching pattern, e.g. a managed namespace like npsp__*")
+}
+
+var GenerateCmd = &cobra.Command{
+	Use:   "generate [flags] [directory]",
+	Short: "Create package.xml listing all metadata in a project",
+	Long: `
+Create package.xml listing all of the metadata in an sfdx or metadata API
+project.
+
+	The project is the directory passed as an argument, or the --project
+	directory.  Child components of objects are listed as Object.Child, e.g.
+	Account.Status__c for a CustomField, and reports, dashboards, documents,
+	and email templates are listed as Folder/Name, along with their folders.
+
+	Members matching an --exclude pattern are left out.  Patterns can match
+	the whole member name or any part of it separated by "." or "/", so
+	npsp__* excludes npsp__Trigger_Handler__c, Account.npsp__Batch__c, and
+	everything in the npsp__Reports report folder.
+`,
+	Example: `
+$ force-md package generate --api-version 60.0 -o manifest/package.xml force-app
+
+$ force-md package generate --project . -x 'npsp__*' -x 'pi__*'
+`,
+	Args:                  cobra.MaximumNArgs(1),
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		switch {
+		case len(args) == 1:
+			if err := repo.Metadata.LoadProject(args[0]); err != nil {
+				log.Fatalf("loading project failed: %s", err.Error())
+			}
+		case repo.Project == "":
+			log.Fatal("requires a project directory or --project")
+		}
+		p, err := manifest.Generate(repo.Metadata, apiVersion, exclude)
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+		if output != "" {
+			if err := internal.WriteToFile(p, output); err != nil {
+				log.Fatal("writing package.xml failed: " + err.Error())
+			}
+			return
+		}
+		b, err := internal.Marshal(p)
+		if err != nil {
+			log.Fatal("serializing failed: " + err.Error())
+		}
+		os.Stdout.Write(b)
+	},
+}
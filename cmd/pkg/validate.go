@@ -0,0 +1,53 @@
+package pkg
+
+import (
+	"fmt"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/ForceCLI/force-md/cmd/repo"
+	"github.com/ForceCLI/force-md/metadata/pkg"
+	"github.com/ForceCLI/force-md/repo/manifest"
+)
+
+var ValidateCmd = &cobra.Command{
+	Use:   "validate [filename]...",
+	Short: "Check package.xml against the metadata in a project",
+	Long: `
+Check package.xml against the metadata in the --project directory.
+
+	Members without a corresponding source file, source files that aren't
+	covered by a member or wildcard, unknown metadata types, and child
+	components such as fields whose parent object doesn't exist are reported.
+	Child components of objects are covered by their object's member.  The
+	command exits with a non-zero status if any problems are found.
+`,
+	Example: `
+$ force-md package validate --project . manifest/package.xml
+`,
+	Args:                  cobra.MinimumNArgs(1),
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		if repo.Project == "" {
+			log.Fatal("requires --project")
+		}
+		invalid := false
+		for _, file := range args {
+			p, err := pkg.Open(file)
+			if err != nil {
+				log.Warn("parsing package.xml failed: " + err.Error())
+				invalid = true
+				continue
+			}
+			for _, problem := range manifest.Validate(repo.Metadata, p) {
+				fmt.Printf("%s: %s\n", file, problem)
+				invalid = true
+			}
+		}
+		if invalid {
+			os.Exit(1)
+		}
+	},
+}
@@ -22,6 +22,8 @@ func init() {
 	profileCmd.AddCommand(profile.NewCmd)
 	profileCmd.AddCommand(profile.MergeCmd)
 	profileCmd.AddCommand(profile.LoginFlowCmd)
+	profileCmd.AddCommand(profile.DecomposeCmd)
+	profileCmd.AddCommand(profile.ComposeCmd)
 	RootCmd.AddCommand(profileCmd)
 }
 
@@ -0,0 +1,91 @@
+package profile
+
+import (
+	"path/filepath"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/ForceCLI/force-md/internal"
+	"github.com/ForceCLI/force-md/metadata/profile"
+)
+
+var outputDir string
+
+func init() {
+	DecomposeCmd.Flags().StringVarP(&outputDir, "directory", "d", "", "directory where profiles should be output")
+	DecomposeCmd.MarkFlagRequired("directory")
+
+	ComposeCmd.Flags().StringVarP(&outputDir, "directory", "d", "", "directory where profiles should be output")
+	ComposeCmd.MarkFlagRequired("directory")
+}
+
+var DecomposeCmd = &cobra.Command{
+	Use:   "decompose -d directory [filename]...",
+	Short: "Split profiles into per-object files",
+	Long: `
+Split profiles into a directory per profile.
+
+	The field permissions, layout assignments, object
+	permissions, and record type visibilities for each object are written to
+	objectSettings/Object.objectSettings-meta.xml, and the remaining settings
+	are written to Name.profile-meta.xml.
+
+	The output directory should be outside of the project's package
+	directories so the decomposed files aren't deployed.
+`,
+	Example: `
+$ force-md profile decompose -d decomposed/profiles src/profiles/*
+`,
+	Args:                  cobra.MinimumNArgs(1),
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		for _, file := range args {
+			decompose(file, outputDir)
+		}
+	},
+}
+
+var ComposeCmd = &cobra.Command{
+	Use:   "compose -d directory [directory]...",
+	Short: "Combine decomposed profiles",
+	Long: `
+Combine profiles split by decompose into a single file per profile.
+
+	Each directory should contain a profile and its objectSettings.  The result is
+	tidied and written to Name.profile-meta.xml.
+`,
+	Example: `
+$ force-md profile compose -d src/profiles decomposed/profiles/*
+`,
+	Args:                  cobra.MinimumNArgs(1),
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		for _, dir := range args {
+			compose(dir, outputDir)
+		}
+	},
+}
+
+func decompose(file string, dir string) {
+	p, err := profile.Open(file)
+	if err != nil {
+		log.Warn("parsing profile failed: " + err.Error())
+		return
+	}
+	if err = p.Decompose(dir); err != nil {
+		log.Warnf("decomposing %s failed: %s", file, err.Error())
+	}
+}
+
+func compose(decomposedDir string, dir string) {
+	p, err := profile.Compose(decomposedDir)
+	if err != nil {
+		log.Warnf("composing %s failed: %s", decomposedDir, err.Error())
+		return
+	}
+	name := filepath.Base(filepath.Clean(decomposedDir))
+	if err = internal.WriteToFile(p, filepath.Join(dir, name+".profile-meta.xml")); err != nil {
+		log.Warn("write failed: " + err.Error())
+	}
+}
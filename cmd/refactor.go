@@ -0,0 +1,17 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/ForceCLI/force-md/cmd/refactor"
+)
+
+func init() {
+	refactorCmd.AddCommand(refactor.RenameFieldCmd)
+	RootCmd.AddCommand(refactorCmd)
+}
+
+var refactorCmd = &cobra.Command{
+	Use:   "refactor",
+	Short: "Update metadata and the references to it",
+}
@@ -0,0 +1,85 @@
+package refactor
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/ForceCLI/force-md/cmd/repo"
+	"github.com/ForceCLI/force-md/internal"
+	"github.com/ForceCLI/force-md/repo/refactor"
+)
+
+var dryRun bool
+
+func init() {
+	RenameFieldCmd.Flags().BoolVarP(&dryRun, "dry-run", "n", false, "list the files that would be updated without changing them")
+}
+
+var RenameFieldCmd = &cobra.Command{
+	Use:   "rename-field Object.Field NewName [filename]...",
+	Short: "Rename a custom field and update references to it",
+	Long: `
+Rename a custom field and update the references to it.
+
+	The field is renamed in its object or field file, and references are
+	updated in profile and permission set field permissions, layouts,
+	flexipages, report types, list views, compact layouts, field sets, record
+	types, validation rules, formula fields, flows, and workflows.  Formulas
+	are parsed so only references to the field are changed, including those
+	through relationships from other objects.
+
+	The files passed, or all of the metadata in the --project, are updated.
+`,
+	Example: `
+$ force-md refactor rename-field --project . Account.Old__c New__c
+
+$ force-md refactor rename-field --dry-run --project . Account.Old__c New__c
+`,
+	Args:                  cobra.MinimumNArgs(2),
+	DisableFlagsInUseLine: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		files := repo.Files(args[2:])
+		if len(files) == 0 {
+			return errors.New("requires at least 1 file or --project")
+		}
+		for _, file := range files {
+			if _, err := repo.Metadata.Open(file); err != nil {
+				log.Warnf("failed to open %s: %s", file, err.Error())
+			}
+		}
+		changes, err := refactor.RenameField(repo.Metadata, args[0], args[1])
+		if err != nil {
+			log.Fatal("renaming field failed: " + err.Error())
+		}
+		for _, c := range changes {
+			path := string(c.Metadata.GetMetadataInfo().Path())
+			if c.Path != path {
+				fmt.Printf("%s: renamed to %s, %d references updated\n", path, c.Path, c.References)
+			} else {
+				fmt.Printf("%s: %d references updated\n", path, c.References)
+			}
+			if dryRun {
+				continue
+			}
+			if err := internal.WriteToFile(c.Metadata, c.Path); err != nil {
+				log.Warn("write failed: " + err.Error())
+				continue
+			}
+			if c.Path != path {
+				if err := os.Remove(path); err != nil {
+					log.Warn("removing renamed file failed: " + err.Error())
+				}
+			}
+		}
+		if dryRun {
+			fmt.Printf("%d files would be updated\n", len(changes))
+		} else {
+			fmt.Printf("%d files updated\n", len(changes))
+		}
+		return nil
+	},
+}
@@ -1,11 +1,47 @@
 package repo
 
 import (
+	"errors"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ForceCLI/force-md/metadata"
 	"github.com/ForceCLI/force-md/repo"
 )
 
 var Metadata *repo.Repo
 
+// Project is the directory of the sfdx or metadata API project to load, if
+// any
+var Project string
+
 func init() {
 	Metadata = repo.NewRepo()
 }
+
+// LoadProject opens all of the metadata in Project
+func LoadProject() error {
+	if Project == "" {
+		return nil
+	}
+	return Metadata.LoadProject(Project)
+}
+
+// Files returns the files passed as arguments.  If no files were passed and a
+// project was loaded, the files of the given metadata types in the project are
+// returned.
+func Files(args []string, types ...metadata.MetadataType) []string {
+	if len(args) > 0 || Project == "" {
+		return args
+	}
+	return Metadata.Files(types...)
+}
+
+// FilesRequired is a cobra.PositionalArgs that requires files to be passed
+// unless a project is loaded.
+func FilesRequired(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 && Project == "" {
+		return errors.New("requires at least 1 file or --project")
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/ForceCLI/force-md/cmd/repo"
 	"github.com/ForceCLI/force-md/internal"
 	log "github.com/sirupsen/logrus"
 
@@ -21,6 +22,7 @@ func init() {
 	cobra.OnInitialize(globalConfig)
 	RootCmd.PersistentFlags().BoolVarP(&silent, "silent", "", false, "show errors only")
 	RootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "", false, "show debugging output")
+	RootCmd.PersistentFlags().StringVarP(&repo.Project, "project", "", "", "load all metadata in sfdx or mdapi project directory")
 	RootCmd.PersistentFlags().BoolVarP(&internal.ConvertNumericXMLEntities, "convert-xml-entities", "", true, "convert numeric xml entities to character entities")
 
 	RootCmd.MarkFlagsMutuallyExclusive("silent", "verbose")
@@ -43,6 +45,9 @@ func globalConfig() {
 	if verbose {
 		log.SetLevel(log.DebugLevel)
 	}
+	if err := repo.LoadProject(); err != nil {
+		log.Fatalf("loading project failed: %s", err.Error())
+	}
 }
 
 func Execute() {
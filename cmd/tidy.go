@@ -9,6 +9,7 @@ import (
 
 	"github.com/ForceCLI/force-md/general"
 	"github.com/ForceCLI/force-md/internal"
+	"github.com/ForceCLI/force-md/metadata"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -27,36 +28,40 @@ var tidyCmd = &cobra.Command{
 $ force-md tidy sfdx/main/default/objects/*/{fields,validationRules}/* sfdx/main/default/flows/*
 
 $ force-md tidy src/objects/*
+
+$ force-md tidy --project .
 `,
-	Args: cobra.MinimumNArgs(1),
+	Args: repo.FilesRequired,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		for _, file := range args {
-			_, err := repo.Metadata.Open(file)
+		var items []metadata.RegisterableMetadata
+		for _, file := range repo.Files(args) {
+			m, err := repo.Metadata.Open(file)
 			if err != nil {
 				return fmt.Errorf("invalid file %s: %w", file, err)
 			}
+			items = append(items, m.(metadata.RegisterableMetadata))
 		}
 		changes := false
 		list, _ := cmd.Flags().GetBool("list")
-		for _, t := range repo.Metadata.Types() {
-			for _, m := range repo.Metadata.Items(t) {
-				file := m.GetMetadataInfo().Path()
-				o, ok := m.(general.Tidyable)
-				if !ok {
+		for _, m := range items {
+			file := m.GetMetadataInfo().Path()
+			o, ok := m.(general.Tidyable)
+			if !ok {
+				if len(args) > 0 {
 					log.Warnf("file %s of type %s is not tidyable", file, m.Type())
-					continue
 				}
-				if list {
-					orig := m.GetMetadataInfo().Contents()
-					needsTidying := checkIfChanged(o, orig)
-					if needsTidying {
-						fmt.Println(file)
-					}
-					changes = needsTidying || changes
-				} else {
-					if err := general.Tidy(o, file); err != nil {
-						log.Warnf("tidying failed: %s", err.Error())
-					}
+				continue
+			}
+			if list {
+				orig := m.GetMetadataInfo().Contents()
+				needsTidying := checkIfChanged(o, orig)
+				if needsTidying {
+					fmt.Println(file)
+				}
+				changes = needsTidying || changes
+			} else {
+				if err := general.Tidy(o, file); err != nil {
+					log.Warnf("tidying failed: %s", err.Error())
 				}
 			}
 		}
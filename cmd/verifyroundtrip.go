@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/ForceCLI/force-md/cmd/repo"
+	"github.com/ForceCLI/force-md/internal"
+	"github.com/ForceCLI/force-md/internal/xmldiff"
+	"github.com/ForceCLI/force-md/metadata"
+)
+
+func init() {
+	RootCmd.AddCommand(verifyRoundtripCmd)
+}
+
+// tidySortedElements are the paths of the elements that Tidy sorts, whose
+// order is ignored when comparing
+var tidySortedElements = []string{
+	"CustomApplication/profileActionOverrides",
+	"CustomMetadata/values",
+	"CustomObject/businessProcesses",
+	"CustomObject/compactLayouts",
+	"CustomObject/fieldSets",
+	"CustomObject/fields",
+	"CustomObject/indexes",
+	"CustomObject/listViews",
+	"CustomObject/recordTypes",
+	"CustomObject/recordTypes/picklistValues",
+	"CustomObject/recordTypes/picklistValues/values",
+	"CustomObject/sharingReasons",
+	"CustomObject/validationRules",
+	"CustomObject/webLinks",
+	"GlobalValueSet/customValue",
+	"ObjectSettings/fieldPermissions",
+	"ObjectSettings/layoutAssignments",
+	"ObjectSettings/objectPermissions",
+	"ObjectSettings/recordTypeVisibilities",
+	"Package/types",
+	"Package/types/members",
+	"PermissionSet/applicationVisibilities",
+	"PermissionSet/classAccesses",
+	"PermissionSet/customMetadataTypeAccesses",
+	"PermissionSet/customPermissions",
+	"PermissionSet/externalCredentialPrincipalAccesses",
+	"PermissionSet/fieldPermissions",
+	"PermissionSet/objectPermissions",
+	"PermissionSet/pageAccesses",
+	"PermissionSet/recordTypeVisibilities",
+	"PermissionSet/tabSettings",
+	"PermissionSet/userPermissions",
+	"PermissionSetGroup/permissionSets",
+	"Profile/applicationVisibilities",
+	"Profile/classAccesses",
+	"Profile/customPermissions",
+	"Profile/fieldPermissions",
+	"Profile/flowAccesses",
+	"Profile/layoutAssignments",
+	"Profile/objectPermissions",
+	"Profile/pageAccesses",
+	"Profile/recordTypeVisibilities",
+	"Profile/tabVisibilities",
+	"Profile/userPermissions",
+	"Queue/queueMembers/publicGroups/publicGroup",
+	"Queue/queueMembers/roleAndSubordinates/roleAndSubordinate",
+	"Queue/queueMembers/roles/role",
+	"Queue/queueMembers/users/user",
+	"ReportType/sections/columns",
+	"SharingRules/sharingCriteriaRules",
+	"SharingRules/sharingGuestRules",
+	"SharingRules/sharingOwnerRules",
+}
+
+var verifyRoundtripCmd = &cobra.Command{
+	Use:   "verify-roundtrip [filename]...",
+	Short: "Verify that metadata is unchanged by parsing and serializing",
+	Long: `
+Verify that metadata survives being parsed and serialized by force-md.
+
+Each file is parsed and written back out in memory, and the result is
+compared to the original.  Elements must stay in order, except for the lists
+that tidying intentionally sorts, such as profile field permissions.  Any
+element, attribute, or text that is lost, added, altered, or reordered is
+reported, and the command exits with a non-zero status.
+`,
+	Example: `
+$ force-md verify-roundtrip src/profiles/* src/permissionsets/*
+
+$ force-md verify-roundtrip --project .
+`,
+	Args:                  repo.FilesRequired,
+	DisableFlagsInUseLine: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var items []metadata.RegisterableMetadata
+		for _, file := range repo.Files(args) {
+			m, err := repo.Metadata.Open(file)
+			if err != nil {
+				return fmt.Errorf("invalid file %s: %w", file, err)
+			}
+			items = append(items, m.(metadata.RegisterableMetadata))
+		}
+		changes := false
+		for _, m := range items {
+			file := m.GetMetadataInfo().Path()
+			contents, err := internal.Marshal(m)
+			if err != nil {
+				log.Warnf("serializing %s failed: %s", file, err.Error())
+				changes = true
+				continue
+			}
+			diffs, err := xmldiff.Compare(m.GetMetadataInfo().Contents(), contents, tidySortedElements...)
+			if err != nil {
+				log.Warnf("comparing %s failed: %s", file, err.Error())
+				changes = true
+				continue
+			}
+			for _, d := range diffs {
+				fmt.Printf("%s: %s\n", file, d)
+			}
+			changes = changes || len(diffs) > 0
+		}
+		if changes {
+			os.Exit(1)
+		}
+		return nil
+	},
+}
@@ -10,6 +10,8 @@ func init() {
 	workflowCmd.AddCommand(workflow.RulesCmd)
 	workflowCmd.AddCommand(workflow.AlertsCmd)
 	workflowCmd.AddCommand(workflow.FieldUpdatesCmd)
+	workflowCmd.AddCommand(workflow.TasksCmd)
+	workflowCmd.AddCommand(workflow.MigrateCmd)
 	RootCmd.AddCommand(workflowCmd)
 }
 
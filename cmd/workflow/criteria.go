@@ -0,0 +1,150 @@
+package workflow
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/ForceCLI/force-md/internal"
+	"github.com/ForceCLI/force-md/metadata/workflow"
+)
+
+func init() {
+	listCriteriaCmd.Flags().StringP("rule", "r", "", "rule name")
+
+	for _, c := range []*cobra.Command{addCriteriaCmd, editCriteriaCmd, deleteCriteriaCmd} {
+		c.Flags().StringP("rule", "r", "", "rule name")
+		c.Flags().StringP("field", "f", "", "field (Object.Field)")
+		c.MarkFlagRequired("rule")
+		c.MarkFlagRequired("field")
+	}
+	for _, c := range []*cobra.Command{addCriteriaCmd, editCriteriaCmd} {
+		c.Flags().StringP("operation", "o", "", "operation, e.g. equals, notEqual, or greaterThan")
+		c.Flags().StringP("value", "v", "", "value; separate multiple values with commas")
+	}
+	addCriteriaCmd.MarkFlagRequired("operation")
+	deleteCriteriaCmd.Flags().String("boolean-filter", "", "new boolean filter for the remaining criteria items")
+
+	criteriaCmd.AddCommand(listCriteriaCmd)
+	criteriaCmd.AddCommand(addCriteriaCmd)
+	criteriaCmd.AddCommand(editCriteriaCmd)
+	criteriaCmd.AddCommand(deleteCriteriaCmd)
+}
+
+var criteriaCmd = &cobra.Command{
+	Use:   "criteria",
+	Short: "Manage workflow rule criteria items",
+}
+
+var listCriteriaCmd = &cobra.Command{
+	Use:   "list [flags] [filename]...",
+	Short: "List workflow rule criteria items",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		rule, _ := cmd.Flags().GetString("rule")
+		for _, file := range args {
+			listCriteria(file, rule)
+		}
+	},
+}
+
+var addCriteriaCmd = &cobra.Command{
+	Use:   "add -r RuleName -f Object.Field -o Operation [-v Value] [filename]...",
+	Short: "Add criteria item to workflow rule",
+	Long: `
+Add a criteria item to a workflow rule.
+
+	If the rule has a boolean filter, the new criteria item is ANDed with it.
+	Rules that use a formula can't have criteria items.
+`,
+	Example: `
+$ force-md workflows rules criteria add -r Escalate -f Case.Priority -o equals -v High src/workflows/Case.workflow
+`,
+	Args:                  cobra.MinimumNArgs(1),
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		rule, _ := cmd.Flags().GetString("rule")
+		item := criteriaItemFlags(cmd)
+		for _, file := range args {
+			updateWorkflow(file, func(w *workflow.Workflow, object string) error {
+				return w.AddCriteriaItem(strings.TrimPrefix(rule, object+"."), item)
+			})
+		}
+	},
+}
+
+var editCriteriaCmd = &cobra.Command{
+	Use:                   "edit -r RuleName -f Object.Field [-o Operation] [-v Value] [filename]...",
+	Short:                 "Edit workflow rule criteria items",
+	Long:                  "Change the operation or value of the criteria items on a field.",
+	Args:                  cobra.MinimumNArgs(1),
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		rule, _ := cmd.Flags().GetString("rule")
+		item := criteriaItemFlags(cmd)
+		for _, file := range args {
+			updateWorkflow(file, func(w *workflow.Workflow, object string) error {
+				return w.UpdateCriteriaItems(strings.TrimPrefix(rule, object+"."), item)
+			})
+		}
+	},
+}
+
+var deleteCriteriaCmd = &cobra.Command{
+	Use:   "delete -r RuleName -f Object.Field [--boolean-filter Filter] [filename]...",
+	Short: "Delete workflow rule criteria items",
+	Long: `
+Delete the criteria items on a field from a workflow rule.
+
+	Boolean filters refer to criteria items by number, so if the rule has a
+	boolean filter, a new one must be given.
+`,
+	Args:                  cobra.MinimumNArgs(1),
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		rule, _ := cmd.Flags().GetString("rule")
+		field, _ := cmd.Flags().GetString("field")
+		booleanFilter, _ := cmd.Flags().GetString("boolean-filter")
+		for _, file := range args {
+			updateWorkflow(file, func(w *workflow.Workflow, object string) error {
+				return w.DeleteCriteriaItems(strings.TrimPrefix(rule, object+"."), field, booleanFilter)
+			})
+		}
+	},
+}
+
+func criteriaItemFlags(cmd *cobra.Command) workflow.CriteriaItem {
+	item := workflow.CriteriaItem{}
+	item.Field.Text, _ = cmd.Flags().GetString("field")
+	item.Operation.Text, _ = cmd.Flags().GetString("operation")
+	item.Value = escapedValue(cmd, "value")
+	return item
+}
+
+func listCriteria(file string, rule string) {
+	w, err := workflow.Open(file)
+	if err != nil {
+		log.Warn("parsing workflow failed: " + err.Error())
+		return
+	}
+	objectName := internal.TrimSuffixToEnd(path.Base(file), ".workflow")
+	var filters []workflow.RuleFilter
+	if rule != "" {
+		filters = append(filters, ruleNameFilter(objectName, []string{rule}))
+	}
+	for _, r := range w.GetRules(filters...) {
+		for i, c := range r.CriteriaItems {
+			value := ""
+			if c.Value != nil {
+				value = c.Value.String()
+			}
+			fmt.Printf("%s.%s: %d. %s %s %s\n", objectName, r.FullName, i+1, c.Field.Text, c.Operation.Text, value)
+		}
+		if r.BooleanFilter != nil && r.BooleanFilter.String() != "" {
+			fmt.Printf("%s.%s: %s\n", objectName, r.FullName, r.BooleanFilter.String())
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/ForceCLI/force-md/metadata/workflow"
+)
+
+const testWorkflow = `<?xml version="1.0" encoding="UTF-8"?>
+<Workflow xmlns="http://soap.sforce.com/2006/04/metadata">
+    <fieldUpdates>
+        <fullName>Set_Priority</fullName>
+        <description>Escalate priority</description>
+        <field>Priority</field>
+        <literalValue>High</literalValue>
+        <name>Set Priority</name>
+        <notifyAssignee>false</notifyAssignee>
+        <operation>Literal</operation>
+        <protected>false</protected>
+        <reevaluateOnChange>true</reevaluateOnChange>
+    </fieldUpdates>
+    <tasks>
+        <fullName>Follow_Up</fullName>
+        <assignedToType>owner</assignedToType>
+        <description>Call the customer</description>
+        <dueDateOffset>2</dueDateOffset>
+        <notifyAssignee>true</notifyAssignee>
+        <offsetFromField>Case.CreatedDate</offsetFromField>
+        <priority>Normal</priority>
+        <protected>false</protected>
+        <status>Not Started</status>
+        <subject>Follow up</subject>
+    </tasks>
+</Workflow>
+`
+
+// runEdit runs an edit command against a copy of testWorkflow and returns the
+// updated workflow
+func runEdit(t *testing.T, cmd *cobra.Command, flags map[string]string) *workflow.Workflow {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), "Case.workflow-meta.xml")
+	if err := os.WriteFile(file, []byte(testWorkflow), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		cmd.Flags().VisitAll(func(f *pflag.Flag) {
+			f.Value.Set(f.DefValue)
+			f.Changed = false
+		})
+	})
+	for name, value := range flags {
+		if err := cmd.Flags().Set(name, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if cmd.RunE != nil {
+		if err := cmd.RunE(cmd, []string{file}); err != nil {
+			t.Fatal(err)
+		}
+	} else {
+		cmd.Run(cmd, []string{file})
+	}
+	w, err := workflow.Open(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return w
+}
+
+func TestEditFieldUpdateKeepsValuesNotPassed(t *testing.T) {
+	w := runEdit(t, editFieldUpdateCmd, map[string]string{
+		"field-update": "Set_Priority",
+		"label":        "Raise Priority",
+	})
+	u, err := w.GetFieldUpdate("Set_Priority")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Name.Text != "Raise Priority" {
+		t.Errorf("expected label to be updated, got %q", u.Name.Text)
+	}
+	if u.Description.String() != "Escalate priority" {
+		t.Errorf("expected description to be kept, got %q", u.Description.String())
+	}
+	if u.Operation.String() != "Literal" || u.LiteralValue.String() != "High" {
+		t.Errorf("expected Literal High to be kept, got %q %q", u.Operation.String(), u.LiteralValue.String())
+	}
+	if u.ReevaluateOnChange == nil || !u.ReevaluateOnChange.ToBool() {
+		t.Errorf("expected reevaluateOnChange to be kept")
+	}
+}
+
+func TestEditFieldUpdateOperationRemovesOldValue(t *testing.T) {
+	w := runEdit(t, editFieldUpdateCmd, map[string]string{
+		"field-update": "Set_Priority",
+		"operation":    "formula",
+		"formula":      `IF(IsEscalated, "High", "Medium")`,
+	})
+	u, err := w.GetFieldUpdate("Set_Priority")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Operation.String() != "Formula" {
+		t.Errorf("expected operation Formula, got %q", u.Operation.String())
+	}
+	if u.Formula == nil {
+		t.Errorf("expected formula to be set")
+	}
+	if u.LiteralValue != nil {
+		t.Errorf("expected literal value to be removed, got %q", u.LiteralValue.String())
+	}
+	if u.Name.Text != "Set Priority" {
+		t.Errorf("expected label to be kept, got %q", u.Name.Text)
+	}
+}
+
+func TestEditTaskKeepsValuesNotPassed(t *testing.T) {
+	w := runEdit(t, editTaskCmd, map[string]string{
+		"task":     "Follow_Up",
+		"priority": "High",
+	})
+	task, err := w.GetTask("Follow_Up")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task.Priority.Text != "High" {
+		t.Errorf("expected priority to be updated, got %q", task.Priority.Text)
+	}
+	if task.Subject.Text != "Follow up" || task.Status.Text != "Not Started" {
+		t.Errorf("expected subject and status to be kept, got %q %q", task.Subject.Text, task.Status.Text)
+	}
+	if task.Description.String() != "Call the customer" {
+		t.Errorf("expected description to be kept, got %q", task.Description.String())
+	}
+	if task.DueDateOffset.Text != "2" || task.OffsetFromField == nil || task.OffsetFromField.Text != "Case.CreatedDate" {
+		t.Errorf("expected due date to be kept")
+	}
+	if task.NotifyAssignee.Text != "true" || task.AssignedToType.Text != "owner" {
+		t.Errorf("expected assignee settings to be kept, got %q %q", task.NotifyAssignee.Text, task.AssignedToType.Text)
+	}
+}
@@ -3,16 +3,49 @@ package workflow
 import (
 	"fmt"
 	"path"
+	"strings"
 
+	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
+	. "github.com/ForceCLI/force-md/general"
 	"github.com/ForceCLI/force-md/internal"
 	"github.com/ForceCLI/force-md/metadata/workflow"
 )
 
+var fieldUpdateOperations = []string{"Formula", "Literal", "LookupValue", "NextValue", "Null", "PreviousValue"}
+
 func init() {
+	listFieldUpdatesCmd.Flags().StringP("field", "f", "", "only list field updates of field")
+
+	for _, c := range []*cobra.Command{addFieldUpdateCmd, editFieldUpdateCmd} {
+		c.Flags().StringP("field-update", "u", "", "field update name")
+		c.Flags().StringP("field", "f", "", "field to update")
+		c.Flags().StringP("label", "l", "", "label")
+		c.Flags().StringP("description", "d", "", "description")
+		c.Flags().StringP("operation", "o", "", "operation; can be "+strings.Join(fieldUpdateOperations, ", "))
+		c.Flags().String("literal-value", "", "literal value")
+		c.Flags().String("formula", "", "formula")
+		c.Flags().String("lookup-value", "", "lookup value, e.g. a user or queue")
+		c.Flags().String("lookup-value-type", "", "lookup value type, e.g. User or Queue")
+		c.Flags().String("target-object", "", "update a field on a related object")
+		c.Flags().Bool("reevaluate", false, "re-evaluate workflow rules after the field changes")
+		c.Flags().Bool("no-reevaluate", false, "don't re-evaluate workflow rules after the field changes")
+		c.Flags().Bool("notify-assignee", false, "notify the assignee when the owner changes")
+		c.Flags().Bool("no-notify-assignee", false, "don't notify the assignee when the owner changes")
+		c.Flags().SortFlags = false
+		c.MarkFlagRequired("field-update")
+	}
+	addFieldUpdateCmd.MarkFlagRequired("field")
+
+	deleteFieldUpdateCmd.Flags().StringP("field-update", "u", "", "field update name")
+	deleteFieldUpdateCmd.MarkFlagRequired("field-update")
+
 	FieldUpdatesCmd.AddCommand(listFieldUpdatesCmd)
+	FieldUpdatesCmd.AddCommand(addFieldUpdateCmd)
+	FieldUpdatesCmd.AddCommand(editFieldUpdateCmd)
+	FieldUpdatesCmd.AddCommand(deleteFieldUpdateCmd)
 }
 
 var FieldUpdatesCmd = &cobra.Command{
@@ -25,20 +58,193 @@ var listFieldUpdatesCmd = &cobra.Command{
 	Short: "List workflow field updates",
 	Args:  cobra.MinimumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
+		field, _ := cmd.Flags().GetString("field")
+		for _, file := range args {
+			listFieldUpdates(file, field)
+		}
+	},
+}
+
+var addFieldUpdateCmd = &cobra.Command{
+	Use:   "add -u FieldUpdateName -f Field [flags] [filename]...",
+	Short: "Add workflow field update",
+	Long: `
+Add a field update to workflows.
+
+	The operation defaults to Formula, Literal, or LookupValue if --formula,
+	--literal-value, or --lookup-value is given, and to Null otherwise.  The
+	label defaults to the name.
+`,
+	Example: `
+$ force-md workflows field-updates add -u Set_Priority -f Priority --literal-value High src/workflows/Case.workflow
+`,
+	Args:                  cobra.MinimumNArgs(1),
+	DisableFlagsInUseLine: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		operation, err := operationFlag(cmd)
+		if err != nil {
+			return err
+		}
+		update := workflow.FieldUpdate{}
+		update.FullName.Text, _ = cmd.Flags().GetString("field-update")
+		setFieldUpdateFlags(cmd, &update, operation)
+		if update.Operation == nil {
+			update.Operation = &TextLiteral{Text: "Null"}
+			switch {
+			case update.Formula != nil:
+				update.Operation.Text = "Formula"
+			case update.LiteralValue != nil:
+				update.Operation.Text = "Literal"
+			case update.LookupValue != nil:
+				update.Operation.Text = "LookupValue"
+			}
+		}
+		if update.Name.Text == "" {
+			update.Name.Text = strings.ReplaceAll(update.FullName.Text, "_", " ")
+		}
+		if update.NotifyAssignee == nil {
+			update.NotifyAssignee = &BooleanText{Text: "false"}
+		}
+		update.Protected = &BooleanText{Text: "false"}
+		for _, file := range args {
+			updateWorkflow(file, func(w *workflow.Workflow, object string) error {
+				u := update
+				u.FullName.Text = strings.TrimPrefix(u.FullName.Text, object+".")
+				return w.AddFieldUpdate(u)
+			})
+		}
+		return nil
+	},
+}
+
+var editFieldUpdateCmd = &cobra.Command{
+	Use:   "edit -u FieldUpdateName [flags] [filename]...",
+	Short: "Edit workflow field update",
+	Long: `
+Edit a field update in workflows.
+
+	Only the values passed are changed.  Changing the operation removes the
+	formula, literal value, or lookup value that the new operation doesn't use.
+`,
+	Example: `
+$ force-md workflows field-updates edit -u Set_Priority -o Formula --formula 'IF(IsEscalated, "High", "Medium")' src/workflows/Case.workflow
+`,
+	Args:                  cobra.MinimumNArgs(1),
+	DisableFlagsInUseLine: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		operation, err := operationFlag(cmd)
+		if err != nil {
+			return err
+		}
+		name, _ := cmd.Flags().GetString("field-update")
+		for _, file := range args {
+			updateWorkflow(file, func(w *workflow.Workflow, object string) error {
+				name := strings.TrimPrefix(name, object+".")
+				u, err := w.GetFieldUpdate(name)
+				if err != nil {
+					return err
+				}
+				setFieldUpdateFlags(cmd, &u, operation)
+				return w.UpdateFieldUpdate(name, u)
+			})
+		}
+		return nil
+	},
+}
+
+var deleteFieldUpdateCmd = &cobra.Command{
+	Use:                   "delete -u FieldUpdateName [filename]...",
+	Short:                 "Delete workflow field update",
+	Long:                  "Delete workflow field update.  Field updates used by rules can't be deleted.",
+	Args:                  cobra.MinimumNArgs(1),
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		name, _ := cmd.Flags().GetString("field-update")
 		for _, file := range args {
-			listFieldUpdates(file)
+			updateWorkflow(file, func(w *workflow.Workflow, object string) error {
+				return w.DeleteFieldUpdate(strings.TrimPrefix(name, object+"."))
+			})
 		}
 	},
 }
 
-func listFieldUpdates(file string) {
+// operationFlag returns the field update operation passed, if any
+func operationFlag(cmd *cobra.Command) (string, error) {
+	operation, ok := stringFlag(cmd, "operation")
+	if !ok {
+		return "", nil
+	}
+	for _, o := range fieldUpdateOperations {
+		if strings.EqualFold(o, operation) {
+			return o, nil
+		}
+	}
+	return "", errors.Errorf("invalid operation: %s", operation)
+}
+
+// setFieldUpdateFlags sets the values of a field update for the flags that
+// were passed.  Changing the operation removes values the new operation
+// doesn't use.
+func setFieldUpdateFlags(cmd *cobra.Command, update *workflow.FieldUpdate, operation string) {
+	if operation != "" {
+		update.SetOperation(operation)
+	}
+	if field := TextValue(cmd, "field"); field != nil {
+		update.Field = *field
+	}
+	if label := escapedValue(cmd, "label"); label != nil {
+		update.Name = *label
+	}
+	if v := escapedValue(cmd, "description"); v != nil {
+		update.Description = v
+	}
+	if v := escapedValue(cmd, "literal-value"); v != nil {
+		update.LiteralValue = v
+	}
+	if v := escapedValue(cmd, "formula"); v != nil {
+		update.Formula = v
+	}
+	if v := escapedValue(cmd, "lookup-value"); v != nil {
+		update.LookupValue = v
+	}
+	if v := TextValue(cmd, "lookup-value-type"); v != nil {
+		update.LookupValueType = v
+	}
+	if v := TextValue(cmd, "target-object"); v != nil {
+		update.TargetObject = v
+	}
+	if v := BooleanTextValue(cmd, "reevaluate"); v != nil {
+		update.ReevaluateOnChange = v
+	}
+	if v := BooleanTextValue(cmd, "notify-assignee"); v != nil {
+		update.NotifyAssignee = v
+	}
+}
+
+// escapedValue returns the value of a flag as XML text
+func escapedValue(cmd *cobra.Command, flag string) *TextLiteral {
+	t := TextValue(cmd, flag)
+	if t != nil {
+		t.Text = internal.FormulaEscaper.Replace(t.Text)
+	}
+	return t
+}
+
+func listFieldUpdates(file string, field string) {
 	w, err := workflow.Open(file)
 	if err != nil {
 		log.Warn("parsing workflow failed: " + err.Error())
 		return
 	}
 	objectName := internal.TrimSuffixToEnd(path.Base(file), ".workflow")
-	fieldUpdates := w.GetFieldUpdates()
+	var filters []workflow.FieldUpdateFilter
+	if field != "" {
+		field = strings.TrimPrefix(field, objectName+".")
+		filters = append(filters, func(u workflow.FieldUpdate) bool {
+			return strings.EqualFold(u.Field.Text, field)
+		})
+	}
+	fieldUpdates := w.GetFieldUpdates(filters...)
 	for _, r := range fieldUpdates {
 		fmt.Printf("%s.%s\n", objectName, r.FullName.Text)
 	}
@@ -0,0 +1,123 @@
+package workflow
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/ForceCLI/force-md/cmd/repo"
+	"github.com/ForceCLI/force-md/internal"
+	"github.com/ForceCLI/force-md/metadata/workflow"
+	"github.com/ForceCLI/force-md/repo/migrate"
+)
+
+var (
+	migrateRules      []string
+	migrateActiveOnly bool
+	migrateOutputDir  string
+	migrateReport     string
+	migrateApiVersion string
+)
+
+func init() {
+	MigrateCmd.Flags().StringSliceVarP(&migrateRules, "rule", "r", nil, "rule name (default all rules)")
+	MigrateCmd.Flags().BoolVarP(&migrateActiveOnly, "active", "a", false, "only migrate active rules")
+	MigrateCmd.Flags().StringVarP(&migrateOutputDir, "output-dir", "d", ".", "directory for the generated flows")
+	MigrateCmd.Flags().StringVar(&migrateReport, "report", "", "file for the migration report (default stdout)")
+	MigrateCmd.Flags().StringVar(&migrateApiVersion, "api-version", migrate.DefaultApiVersion, "API version of the generated flows")
+}
+
+var MigrateCmd = &cobra.Command{
+	Use:   "migrate [flags] [filename]...",
+	Short: "Convert workflow rules to flows",
+	Long: `
+Convert workflow rules to record-triggered flows.
+
+	Each rule becomes a draft flow named Object_Rule.  The rule's criteria
+	items or formula become the flow's entry criteria.  Immediate field
+	updates become an assignment in a before-save flow if the rule has no
+	other actions, or an update of the triggering record in an after-save
+	flow.  Email alerts become send email actions, and time triggers become
+	scheduled paths.
+
+	Anything that can't be translated, such as tasks, outbound messages,
+	cross-object field updates, and unsupported criteria, is written to a
+	Markdown report.  When the --project is loaded, field types are used to
+	choose the types of filter values and formulas.
+`,
+	Example: `
+$ force-md workflows migrate -r Set_Priority -d force-app/main/default/flows src/workflows/Case.workflow
+
+$ force-md workflows migrate --project . --active -d force-app/main/default/flows --report migration.md
+`,
+	Args:                  repo.FilesRequired,
+	DisableFlagsInUseLine: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		opts := migrate.Options{ApiVersion: migrateApiVersion}
+		if repo.Project != "" {
+			opts.Field = migrate.RepoFields(repo.Metadata)
+		}
+		var migrations []migrate.Migration
+		for _, file := range repo.Files(args, workflow.NAME) {
+			w, err := workflow.Open(file)
+			if err != nil {
+				log.Warn("parsing workflow failed: " + err.Error())
+				continue
+			}
+			object := internal.TrimSuffixToEnd(path.Base(file), ".workflow")
+			for _, rule := range w.GetRules(ruleFilters(object)...) {
+				m := migrate.WorkflowRule(w, object, rule, opts)
+				m.Flow.Tidy()
+				target := filepath.Join(migrateOutputDir, m.Name+".flow-meta.xml")
+				if err := internal.WriteToFile(m.Flow, target); err != nil {
+					return errors.Wrap(err, "writing "+target)
+				}
+				migrations = append(migrations, m)
+			}
+		}
+		out := os.Stdout
+		if migrateReport != "" {
+			f, err := os.Create(migrateReport)
+			if err != nil {
+				return errors.Wrap(err, "creating report")
+			}
+			defer f.Close()
+			out = f
+		}
+		writeReport(out, migrations)
+		return nil
+	},
+}
+
+func ruleFilters(object string) []workflow.RuleFilter {
+	var filters []workflow.RuleFilter
+	if migrateActiveOnly {
+		filters = append(filters, func(r workflow.Rule) bool {
+			return r.Active.ToBool()
+		})
+	}
+	if len(migrateRules) > 0 {
+		filters = append(filters, ruleNameFilter(object, migrateRules))
+	}
+	return filters
+}
+
+func writeReport(out *os.File, migrations []migrate.Migration) {
+	fmt.Fprintln(out, "# Workflow Rule Migration")
+	for _, m := range migrations {
+		fmt.Fprintf(out, "\n## %s\n\n", m.Rule)
+		fmt.Fprintf(out, "Flow: %s\n\n", m.Name)
+		if len(m.Issues) == 0 {
+			fmt.Fprintln(out, "Fully translated.")
+			continue
+		}
+		for _, issue := range m.Issues {
+			fmt.Fprintf(out, "- %s\n", issue)
+		}
+	}
+}
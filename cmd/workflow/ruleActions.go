@@ -0,0 +1,71 @@
+package workflow
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ForceCLI/force-md/metadata/workflow"
+)
+
+func init() {
+	for _, c := range []*cobra.Command{addRuleActionCmd, deleteRuleActionCmd} {
+		c.Flags().StringP("rule", "r", "", "rule name")
+		c.Flags().StringP("action", "a", "", "action name")
+		c.Flags().StringP("type", "t", "", "action type, e.g. FieldUpdate, Alert, Task, or OutboundMessage")
+		c.MarkFlagRequired("rule")
+		c.MarkFlagRequired("action")
+	}
+	addRuleActionCmd.MarkFlagRequired("type")
+
+	ruleActionsCmd.AddCommand(addRuleActionCmd)
+	ruleActionsCmd.AddCommand(deleteRuleActionCmd)
+}
+
+var ruleActionsCmd = &cobra.Command{
+	Use:   "actions",
+	Short: "Manage workflow rule actions",
+}
+
+var addRuleActionCmd = &cobra.Command{
+	Use:   "add -r RuleName -a ActionName -t Type [filename]...",
+	Short: "Add action to workflow rule",
+	Long: `
+Add an immediate action to a workflow rule.
+
+	Field updates, alerts, and tasks must exist in the workflow.
+`,
+	Example: `
+$ force-md workflows rules actions add -r Escalate -a Set_Priority -t FieldUpdate src/workflows/Case.workflow
+`,
+	Args:                  cobra.MinimumNArgs(1),
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		rule, _ := cmd.Flags().GetString("rule")
+		action, _ := cmd.Flags().GetString("action")
+		actionType, _ := cmd.Flags().GetString("type")
+		for _, file := range args {
+			updateWorkflow(file, func(w *workflow.Workflow, object string) error {
+				return w.AddRuleAction(strings.TrimPrefix(rule, object+"."), strings.TrimPrefix(action, object+"."), actionType)
+			})
+		}
+	},
+}
+
+var deleteRuleActionCmd = &cobra.Command{
+	Use:                   "delete -r RuleName -a ActionName [-t Type] [filename]...",
+	Short:                 "Remove action from workflow rule",
+	Long:                  "Remove an immediate action from a workflow rule.  The action itself isn't deleted.",
+	Args:                  cobra.MinimumNArgs(1),
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		rule, _ := cmd.Flags().GetString("rule")
+		action, _ := cmd.Flags().GetString("action")
+		actionType, _ := cmd.Flags().GetString("type")
+		for _, file := range args {
+			updateWorkflow(file, func(w *workflow.Workflow, object string) error {
+				return w.DeleteRuleAction(strings.TrimPrefix(rule, object+"."), strings.TrimPrefix(action, object+"."), actionType)
+			})
+		}
+	},
+}
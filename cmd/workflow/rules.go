@@ -1,6 +1,7 @@
 package workflow
 
 import (
+	"errors"
 	"fmt"
 	"path"
 	"strings"
@@ -8,6 +9,7 @@ import (
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
+	"github.com/ForceCLI/force-md/cmd/repo"
 	"github.com/ForceCLI/force-md/internal"
 	"github.com/ForceCLI/force-md/metadata/workflow"
 )
@@ -21,8 +23,19 @@ func init() {
 	deleteRuleCmd.MarkFlagRequired("alert")
 
 	listRulesCmd.Flags().BoolVarP(&active, "active", "a", false, "active")
+	listRulesCmd.Flags().StringP("field", "f", "", "only list rules that reference field (Object.Field)")
+
+	for _, c := range []*cobra.Command{activateRulesCmd, deactivateRulesCmd} {
+		c.Flags().StringSliceP("rule", "r", nil, "rule name")
+		c.Flags().StringP("field", "f", "", "rules that reference field (Object.Field)")
+	}
+
 	RulesCmd.AddCommand(listRulesCmd)
 	RulesCmd.AddCommand(deleteRuleCmd)
+	RulesCmd.AddCommand(activateRulesCmd)
+	RulesCmd.AddCommand(deactivateRulesCmd)
+	RulesCmd.AddCommand(ruleActionsCmd)
+	RulesCmd.AddCommand(criteriaCmd)
 }
 
 var RulesCmd = &cobra.Command{
@@ -35,12 +48,51 @@ var listRulesCmd = &cobra.Command{
 	Short: "List workflow rules",
 	Args:  cobra.MinimumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
+		field, _ := cmd.Flags().GetString("field")
 		for _, file := range args {
-			listRules(file)
+			listRules(file, field)
 		}
 	},
 }
 
+var activateRulesCmd = &cobra.Command{
+	Use:   "activate [flags] [filename]...",
+	Short: "Activate workflow rules",
+	Long: `
+Activate workflow rules by name, or rules that reference a field.
+
+	A field is referenced by a rule's criteria items, formula, field updates,
+	or time trigger.
+`,
+	Example: `
+$ force-md workflows rules activate -r Case.Escalate src/workflows/Case.workflow
+`,
+	Args:                  repo.FilesRequired,
+	DisableFlagsInUseLine: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setRulesActive(cmd, args, true)
+	},
+}
+
+var deactivateRulesCmd = &cobra.Command{
+	Use:   "deactivate [flags] [filename]...",
+	Short: "Deactivate workflow rules",
+	Long: `
+Deactivate workflow rules by name, or rules that reference a field.
+
+	A field is referenced by a rule's criteria items, formula, field updates,
+	or time trigger.
+`,
+	Example: `
+$ force-md workflows rules deactivate --project . -f Account.Retired__c
+`,
+	Args:                  repo.FilesRequired,
+	DisableFlagsInUseLine: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setRulesActive(cmd, args, false)
+	},
+}
+
 var deleteRuleCmd = &cobra.Command{
 	Use:                   "delete -a RuleName [filename]...",
 	Short:                 "Delete workflow alert",
@@ -55,7 +107,7 @@ var deleteRuleCmd = &cobra.Command{
 	},
 }
 
-func listRules(file string) {
+func listRules(file string, field string) {
 	w, err := workflow.Open(file)
 	if err != nil {
 		log.Warn("parsing workflow failed: " + err.Error())
@@ -68,6 +120,13 @@ func listRules(file string) {
 			return r.Active.Text == "true"
 		})
 	}
+	if field != "" {
+		object, fieldName, _ := strings.Cut(field, ".")
+		if !strings.EqualFold(object, objectName) {
+			return
+		}
+		filters = append(filters, w.RuleReferencesField(objectName, fieldName))
+	}
 	rules := w.GetRules(filters...)
 	for _, r := range rules {
 		active := "inactive"
@@ -97,3 +156,82 @@ func deleteRule(file string, ruleName string) {
 		return
 	}
 }
+
+func setRulesActive(cmd *cobra.Command, args []string, activate bool) error {
+	rules, _ := cmd.Flags().GetStringSlice("rule")
+	field, _ := cmd.Flags().GetString("field")
+	if len(rules) == 0 && field == "" {
+		return errors.New("requires --rule or --field")
+	}
+	state := "deactivated"
+	if activate {
+		state = "activated"
+	}
+	for _, file := range repo.Files(args, workflow.NAME) {
+		w, err := workflow.Open(file)
+		if err != nil {
+			log.Warn("parsing workflow failed: " + err.Error())
+			continue
+		}
+		objectName := internal.TrimSuffixToEnd(path.Base(file), ".workflow")
+		var filters []workflow.RuleFilter
+		if len(rules) > 0 {
+			filters = append(filters, ruleNameFilter(objectName, rules))
+		}
+		if field != "" {
+			object, fieldName, _ := strings.Cut(field, ".")
+			if !strings.EqualFold(object, objectName) {
+				continue
+			}
+			filters = append(filters, w.RuleReferencesField(objectName, fieldName))
+		}
+		changed := false
+		for _, r := range w.GetRules(filters...) {
+			if r.Active.ToBool() == activate {
+				continue
+			}
+			if err := w.SetRuleActive(r.FullName, activate); err != nil {
+				log.Warn(fmt.Sprintf("update failed for %s: %s", file, err.Error()))
+				continue
+			}
+			changed = true
+			fmt.Printf("%s.%s: %s\n", objectName, r.FullName, state)
+		}
+		if !changed {
+			continue
+		}
+		if err := internal.WriteToFile(w, file); err != nil {
+			log.Warn("update failed: " + err.Error())
+		}
+	}
+	return nil
+}
+
+// ruleNameFilter matches rules by name, optionally qualified by the object
+func ruleNameFilter(objectName string, names []string) workflow.RuleFilter {
+	return func(r workflow.Rule) bool {
+		for _, name := range names {
+			if strings.EqualFold(r.FullName, name) || strings.EqualFold(objectName+"."+r.FullName, name) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// updateWorkflow applies an update to a workflow and writes it back to file
+func updateWorkflow(file string, update func(w *workflow.Workflow, objectName string) error) {
+	w, err := workflow.Open(file)
+	if err != nil {
+		log.Warn("parsing workflow failed: " + err.Error())
+		return
+	}
+	objectName := internal.TrimSuffixToEnd(path.Base(file), ".workflow")
+	if err := update(w, objectName); err != nil {
+		log.Warn(fmt.Sprintf("update failed for %s: %s", file, err.Error()))
+		return
+	}
+	if err := internal.WriteToFile(w, file); err != nil {
+		log.Warn("update failed: " + err.Error())
+	}
+}
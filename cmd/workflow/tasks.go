@@ -0,0 +1,197 @@
+package workflow
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	. "github.com/ForceCLI/force-md/general"
+	"github.com/ForceCLI/force-md/internal"
+	"github.com/ForceCLI/force-md/metadata/workflow"
+)
+
+func init() {
+	for _, c := range []*cobra.Command{addTaskCmd, editTaskCmd} {
+		c.Flags().StringP("task", "t", "", "task name")
+		c.Flags().StringP("subject", "s", "", "subject")
+		c.Flags().StringP("assigned-to", "a", "", "user or role the task is assigned to")
+		c.Flags().String("assigned-to-type", "", "assignee type, e.g. user, role, owner, or creator")
+		c.Flags().StringP("description", "d", "", "description")
+		c.Flags().Int("due-date-offset", 0, "days from the offset field until the task is due")
+		c.Flags().String("offset-from-field", "", "date field the due date is offset from, e.g. Case.CreatedDate")
+		c.Flags().StringP("priority", "p", "", "priority")
+		c.Flags().String("status", "", "status")
+		c.Flags().Bool("notify-assignee", false, "notify the assignee")
+		c.Flags().Bool("no-notify-assignee", false, "don't notify the assignee")
+		c.Flags().SortFlags = false
+		c.MarkFlagRequired("task")
+	}
+
+	deleteTaskCmd.Flags().StringP("task", "t", "", "task name")
+	deleteTaskCmd.MarkFlagRequired("task")
+
+	TasksCmd.AddCommand(listTasksCmd)
+	TasksCmd.AddCommand(addTaskCmd)
+	TasksCmd.AddCommand(editTaskCmd)
+	TasksCmd.AddCommand(deleteTaskCmd)
+}
+
+var TasksCmd = &cobra.Command{
+	Use:   "tasks",
+	Short: "Manage workflow tasks",
+}
+
+var listTasksCmd = &cobra.Command{
+	Use:                   "list [filename]...",
+	Short:                 "List workflow tasks",
+	Args:                  cobra.MinimumNArgs(1),
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		for _, file := range args {
+			listTasks(file)
+		}
+	},
+}
+
+var addTaskCmd = &cobra.Command{
+	Use:   "add -t TaskName [flags] [filename]...",
+	Short: "Add workflow task",
+	Long: `
+Add a task to workflows.
+
+	By default, the task is assigned to the record owner, due when the rule
+	is triggered, has Normal priority and Not Started status, and its subject
+	is the task name.
+`,
+	Example: `
+$ force-md workflows tasks add -t Follow_Up -s "Follow up" --due-date-offset 2 src/workflows/Case.workflow
+`,
+	Args:                  cobra.MinimumNArgs(1),
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		task := workflow.Task{}
+		task.FullName.Text, _ = cmd.Flags().GetString("task")
+		setTaskFlags(cmd, &task)
+		if task.AssignedToType.Text == "" {
+			task.AssignedToType.Text = "owner"
+		}
+		if task.DueDateOffset.Text == "" {
+			task.DueDateOffset.Text = "0"
+		}
+		if task.NotifyAssignee.Text == "" {
+			task.NotifyAssignee.Text = "false"
+		}
+		if task.Priority.Text == "" {
+			task.Priority.Text = "Normal"
+		}
+		task.Protected.Text = "false"
+		if task.Status.Text == "" {
+			task.Status.Text = "Not Started"
+		}
+		if task.Subject.Text == "" {
+			task.Subject.Text = strings.ReplaceAll(task.FullName.Text, "_", " ")
+		}
+		for _, file := range args {
+			updateWorkflow(file, func(w *workflow.Workflow, object string) error {
+				t := task
+				t.FullName.Text = strings.TrimPrefix(t.FullName.Text, object+".")
+				return w.AddTask(t)
+			})
+		}
+	},
+}
+
+var editTaskCmd = &cobra.Command{
+	Use:                   "edit -t TaskName [flags] [filename]...",
+	Short:                 "Edit workflow task",
+	Args:                  cobra.MinimumNArgs(1),
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		name, _ := cmd.Flags().GetString("task")
+		for _, file := range args {
+			updateWorkflow(file, func(w *workflow.Workflow, object string) error {
+				name := strings.TrimPrefix(name, object+".")
+				t, err := w.GetTask(name)
+				if err != nil {
+					return err
+				}
+				setTaskFlags(cmd, &t)
+				return w.UpdateTask(name, t)
+			})
+		}
+	},
+}
+
+var deleteTaskCmd = &cobra.Command{
+	Use:                   "delete -t TaskName [filename]...",
+	Short:                 "Delete workflow task",
+	Long:                  "Delete workflow task.  Tasks used by rules can't be deleted.",
+	Args:                  cobra.MinimumNArgs(1),
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		name, _ := cmd.Flags().GetString("task")
+		for _, file := range args {
+			updateWorkflow(file, func(w *workflow.Workflow, object string) error {
+				return w.DeleteTask(strings.TrimPrefix(name, object+"."))
+			})
+		}
+	},
+}
+
+// setTaskFlags sets the values of a task for the flags that were passed
+func setTaskFlags(cmd *cobra.Command, task *workflow.Task) {
+	if v, ok := stringFlag(cmd, "subject"); ok {
+		task.Subject.Text = v
+	}
+	if v, ok := stringFlag(cmd, "assigned-to"); ok {
+		task.AssignedTo = &struct {
+			Text string `xml:",chardata"`
+		}{Text: v}
+	}
+	if v, ok := stringFlag(cmd, "assigned-to-type"); ok {
+		task.AssignedToType.Text = v
+	}
+	if v := escapedValue(cmd, "description"); v != nil {
+		task.Description = v
+	}
+	if cmd.Flags().Changed("due-date-offset") {
+		offset, _ := cmd.Flags().GetInt("due-date-offset")
+		task.DueDateOffset.Text = strconv.Itoa(offset)
+	}
+	if v, ok := stringFlag(cmd, "offset-from-field"); ok {
+		task.OffsetFromField = &struct {
+			Text string `xml:",chardata"`
+		}{Text: v}
+	}
+	if v, ok := stringFlag(cmd, "priority"); ok {
+		task.Priority.Text = v
+	}
+	if v, ok := stringFlag(cmd, "status"); ok {
+		task.Status.Text = v
+	}
+	if b := BooleanTextValue(cmd, "notify-assignee"); b != nil {
+		task.NotifyAssignee.Text = b.Text
+	}
+}
+
+// stringFlag returns the value of a flag if it was set
+func stringFlag(cmd *cobra.Command, flag string) (string, bool) {
+	v, _ := cmd.Flags().GetString(flag)
+	return v, cmd.Flags().Changed(flag)
+}
+
+func listTasks(file string) {
+	w, err := workflow.Open(file)
+	if err != nil {
+		log.Warn("parsing workflow failed: " + err.Error())
+		return
+	}
+	objectName := internal.TrimSuffixToEnd(path.Base(file), ".workflow")
+	for _, t := range w.GetTasks() {
+		fmt.Printf("%s.%s: %s\n", objectName, t.FullName.Text, t.Subject.Text)
+	}
+}
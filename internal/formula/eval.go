@@ -0,0 +1,394 @@
+package formula
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/antlr4-go/antlr/v4"
+	"github.com/octoberswimmer/sformula/parser"
+)
+
+// Env contains the values available to a formula
+type Env struct {
+	// The fields of the record, keyed by field name.  Related records are
+	// maps keyed by relationship name, e.g. Account or Parent__r.
+	Record map[string]any
+	// The record before it was changed, used by PRIORVALUE and ISCHANGED.
+	// Nil for new records.
+	Prior map[string]any
+	// Global merge fields by name, e.g. $User or $Profile
+	Globals map[string]any
+	// The current time, used by TODAY and NOW.  Defaults to time.Now.
+	Now time.Time
+	// Treat blank numbers as zero in arithmetic and comparisons
+	BlanksAsZero bool
+}
+
+// Evaluate evaluates a formula against a record.  The result is nil for
+// blank values, or a string, float64, bool, Date, or time.Time.  Field
+// values in the record can be given as text, e.g. dates as 2024-01-31.
+func Evaluate(f string, env Env) (any, error) {
+	tree, _, err := Parse(f)
+	if err != nil {
+		return nil, err
+	}
+	if env.Now.IsZero() {
+		env.Now = time.Now()
+	}
+	e := &evaluator{env: env}
+	return e.value(tree)
+}
+
+type evaluator struct {
+	env Env
+}
+
+func (e *evaluator) value(tree antlr.Tree) (any, error) {
+	switch ctx := tree.(type) {
+	case *parser.CompilationUnitContext:
+		return e.value(ctx.Expression())
+	case *parser.PrimaryExpressionContext:
+		return e.value(ctx.Primary())
+	case *parser.PrimaryContext:
+		if ctx.Literal() != nil {
+			return literal(ctx.Literal().(*parser.LiteralContext))
+		}
+		return e.value(ctx.Expression())
+	case *parser.FunctionCallExpressionContext:
+		return e.call(ctx.FunctionCall())
+	case *parser.FieldReferenceExpressionContext:
+		return e.field(ctx.FieldReference(), e.env.Record), nil
+	case *parser.VariableExpressionContext:
+		return e.field(ctx.FieldReference(), e.env.Record), nil
+	case *parser.NegationExpressionContext:
+		v, err := e.value(ctx.Expression())
+		if err != nil {
+			return nil, err
+		}
+		if ctx.BANG() != nil {
+			b, err := toBool(v)
+			return !b, err
+		}
+		n, ok, err := toNumber(v)
+		if err != nil || !ok {
+			return nil, err
+		}
+		return -n, nil
+	case *parser.PositiveExpressionContext:
+		return e.value(ctx.GetChild(1))
+	case *parser.ExponentiationExpressionContext:
+		return e.binary(ctx.Expression(0), ctx.Expression(1), e.arith(parser.FormulaLexerCARET))
+	case *parser.ArithExpressionContext:
+		op := ctx.GetChild(1).(antlr.TerminalNode).GetSymbol().GetTokenType()
+		return e.binary(ctx.Expression(0), ctx.Expression(1), e.arith(op))
+	case *parser.ConcatExpressionContext:
+		return e.binary(ctx.Expression(0), ctx.Expression(1), func(a, b any) (any, error) {
+			return toText(a) + toText(b), nil
+		})
+	case *parser.CompareExpressionContext:
+		return e.binary(ctx.Expression(0), ctx.Expression(1), func(a, b any) (any, error) {
+			c, ok, err := e.compare(a, b)
+			if err != nil || !ok {
+				return false, err
+			}
+			switch {
+			case ctx.GT() != nil && ctx.ASSIGN() != nil:
+				return c >= 0, nil
+			case ctx.GT() != nil:
+				return c > 0, nil
+			case ctx.ASSIGN() != nil:
+				return c <= 0, nil
+			}
+			return c < 0, nil
+		})
+	case *parser.EqualityExpressionContext:
+		return e.binary(ctx.Expression(0), ctx.Expression(1), func(a, b any) (any, error) {
+			equal := e.equal(a, b)
+			if ctx.NOTEQUAL() != nil || ctx.LESSANDGREATER() != nil {
+				return !equal, nil
+			}
+			return equal, nil
+		})
+	case *parser.LogicExpressionContext:
+		a, err := e.boolean(ctx.Expression(0))
+		if err != nil {
+			return nil, err
+		}
+		if ctx.AND() != nil && !a || ctx.OR() != nil && a {
+			return a, nil
+		}
+		return e.boolean(ctx.Expression(1))
+	}
+	return nil, fmt.Errorf("unsupported expression: %s", tree.(antlr.ParseTree).GetText())
+}
+
+func (e *evaluator) binary(left, right antlr.Tree, op func(a, b any) (any, error)) (any, error) {
+	a, err := e.value(left)
+	if err != nil {
+		return nil, err
+	}
+	b, err := e.value(right)
+	if err != nil {
+		return nil, err
+	}
+	return op(a, b)
+}
+
+func (e *evaluator) boolean(tree antlr.Tree) (bool, error) {
+	v, err := e.value(tree)
+	if err != nil {
+		return false, err
+	}
+	return toBool(v)
+}
+
+func literal(ctx *parser.LiteralContext) (any, error) {
+	text := ctx.GetText()
+	switch {
+	case ctx.StringLiteral() != nil:
+		return unquote(text), nil
+	case ctx.IntegerLiteral() != nil, ctx.FloatingPointLiteral() != nil:
+		return strconv.ParseFloat(text, 64)
+	case ctx.BooleanLiteral() != nil:
+		return strings.EqualFold(text, "true"), nil
+	}
+	return nil, nil
+}
+
+var unescaper = strings.NewReplacer(`\"`, `"`, `\'`, `'`, `\\`, `\`, `\n`, "\n", `\r`, "\r", `\t`, "\t")
+
+func unquote(s string) string {
+	return unescaper.Replace(s[1 : len(s)-1])
+}
+
+// referenceParts returns the names in a field reference, e.g. Account, Owner,
+// and Email for Account.Owner.Email
+func referenceParts(ref parser.IFieldReferenceContext) []string {
+	var parts []string
+	for _, id := range fieldReferenceIdentifiers(ref.(*parser.FieldReferenceContext)) {
+		parts = append(parts, id.GetText())
+	}
+	return parts
+}
+
+func (e *evaluator) field(ref parser.IFieldReferenceContext, record map[string]any) any {
+	return e.lookup(referenceParts(ref), record)
+}
+
+// lookup returns the value of a field on a record, a related record, or a
+// global merge field.  Missing fields are blank.
+func (e *evaluator) lookup(parts []string, record map[string]any) any {
+	var current any = record
+	switch {
+	case strings.EqualFold(parts[0], "$Record"):
+		parts = parts[1:]
+	case strings.EqualFold(parts[0], "$Record__Prior"):
+		current = e.env.Prior
+		parts = parts[1:]
+	case strings.HasPrefix(parts[0], "$"):
+		current = e.env.Globals
+	}
+	for _, p := range parts {
+		m, ok := current.(map[string]any)
+		if !ok {
+			return nil
+		}
+		current = get(m, p)
+	}
+	return normalize(current)
+}
+
+// get returns a value from a map, ignoring the case of the key
+func get(m map[string]any, key string) any {
+	if v, ok := m[key]; ok {
+		return v
+	}
+	for k, v := range m {
+		if strings.EqualFold(k, key) {
+			return v
+		}
+	}
+	return nil
+}
+
+func normalize(v any) any {
+	switch v := v.(type) {
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	case float32:
+		return float64(v)
+	}
+	return v
+}
+
+// temporal converts dates, date/times, and text containing them to a Date
+// or time.Time
+func temporal(v any) (any, bool) {
+	switch v := v.(type) {
+	case Date, time.Time:
+		return v, true
+	case string:
+		if t, err := time.Parse("2006-01-02", v); err == nil {
+			return Date{t}, true
+		}
+		if looksLikeDate(v) {
+			t, _, _ := toDateTime(v)
+			return t, true
+		}
+	}
+	return nil, false
+}
+
+func isNumber(v any) bool {
+	_, ok := v.(float64)
+	return ok
+}
+
+func (e *evaluator) arith(op int) func(a, b any) (any, error) {
+	return func(a, b any) (any, error) {
+		if op == parser.FormulaLexerADD || op == parser.FormulaLexerSUB {
+			if r, ok, err := dateArith(op, a, b); ok || err != nil {
+				return r, err
+			}
+		}
+		_, aText := a.(string)
+		_, bText := b.(string)
+		if op == parser.FormulaLexerADD && (aText || bText) && !isNumber(a) && !isNumber(b) {
+			return toText(a) + toText(b), nil
+		}
+		x, xOk, err := toNumber(a)
+		if err != nil {
+			return nil, err
+		}
+		y, yOk, err := toNumber(b)
+		if err != nil {
+			return nil, err
+		}
+		if (!xOk || !yOk) && !e.env.BlanksAsZero {
+			return nil, nil
+		}
+		switch op {
+		case parser.FormulaLexerADD:
+			return x + y, nil
+		case parser.FormulaLexerSUB:
+			return x - y, nil
+		case parser.FormulaLexerMUL:
+			return x * y, nil
+		case parser.FormulaLexerDIV:
+			if y == 0 {
+				return nil, fmt.Errorf("division by zero")
+			}
+			return x / y, nil
+		}
+		return math.Pow(x, y), nil
+	}
+}
+
+// dateArith adds days to dates and date/times, and subtracts them to find
+// the number of days between them
+func dateArith(op int, a, b any) (any, bool, error) {
+	ta, aIsDate := temporal(a)
+	tb, bIsDate := temporal(b)
+	switch {
+	case aIsDate && bIsDate && op == parser.FormulaLexerSUB:
+		x, _, _ := toDateTime(ta)
+		y, _, _ := toDateTime(tb)
+		return x.Sub(y).Hours() / 24, true, nil
+	case aIsDate && !bIsDate:
+		n, ok, err := toNumber(b)
+		if err != nil || !ok {
+			return nil, true, err
+		}
+		if op == parser.FormulaLexerSUB {
+			n = -n
+		}
+		return addDays(ta, n), true, nil
+	case bIsDate && !aIsDate && op == parser.FormulaLexerADD:
+		n, ok, err := toNumber(a)
+		if err != nil || !ok {
+			return nil, true, err
+		}
+		return addDays(tb, n), true, nil
+	}
+	return nil, false, nil
+}
+
+func addDays(t any, days float64) any {
+	if d, ok := t.(Date); ok {
+		return Date{d.AddDate(0, 0, int(days))}
+	}
+	return t.(time.Time).Add(time.Duration(days * 24 * float64(time.Hour)))
+}
+
+// compare returns -1, 0, or 1 if a is less than, equal to, or greater than
+// b.  False is returned if either value is blank.
+func (e *evaluator) compare(a, b any) (int, bool, error) {
+	ta, aIsDate := temporal(a)
+	tb, bIsDate := temporal(b)
+	if aIsDate || bIsDate {
+		if isBlank(a) || isBlank(b) {
+			return 0, false, nil
+		}
+		x, _, err := toDateTime(ta)
+		if !aIsDate {
+			x, _, err = toDateTime(a)
+		}
+		if err != nil {
+			return 0, false, err
+		}
+		y, _, err := toDateTime(tb)
+		if !bIsDate {
+			y, _, err = toDateTime(b)
+		}
+		if err != nil {
+			return 0, false, err
+		}
+		return x.Compare(y), true, nil
+	}
+	if isNumber(a) || isNumber(b) {
+		x, xOk, err := toNumber(a)
+		if err != nil {
+			return 0, false, err
+		}
+		y, yOk, err := toNumber(b)
+		if err != nil {
+			return 0, false, err
+		}
+		if (!xOk || !yOk) && !e.env.BlanksAsZero {
+			return 0, false, nil
+		}
+		switch {
+		case x < y:
+			return -1, true, nil
+		case x > y:
+			return 1, true, nil
+		}
+		return 0, true, nil
+	}
+	if isBlank(a) || isBlank(b) {
+		return 0, false, nil
+	}
+	return strings.Compare(toText(a), toText(b)), true, nil
+}
+
+func (e *evaluator) equal(a, b any) bool {
+	if isBlank(a) && isBlank(b) {
+		return true
+	}
+	if _, ok := a.(bool); ok {
+		y, err := toBool(b)
+		return err == nil && a.(bool) == y
+	}
+	if _, ok := b.(bool); ok {
+		return e.equal(b, a)
+	}
+	c, ok, err := e.compare(a, b)
+	return err == nil && ok && c == 0
+}
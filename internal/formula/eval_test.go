@@ -0,0 +1,57 @@
+package formula_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/ForceCLI/force-md/internal/formula"
+)
+
+func TestEvaluate(t *testing.T) {
+	env := Env{
+		Record: map[string]any{
+			"Name":      "Acme",
+			"Amount__c": 1500.0,
+			"Stage__c":  "Closed",
+			"CloseDate": "2024-01-31",
+			"Account":   map[string]any{"Industry": "Energy"},
+		},
+		Prior:   map[string]any{"Stage__c": "Open", "Amount__c": 1500.0},
+		Globals: map[string]any{"$User": map[string]any{"Department": "Sales"}},
+		Now:     time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+	}
+	tests := []struct {
+		formula  string
+		expected string
+	}{
+		{`Amount__c * 2 + 1`, "3001"},
+		{`0.1 + 0.2`, "0.3"},
+		{`IF(ISPICKVAL(Stage__c, "Closed"), "Won", "Lost")`, "Won"},
+		{`CASE(Account.Industry, "Energy", 1, "Retail", 2, 0)`, "1"},
+		{`ISBLANK(Missing__c) && NOT(ISBLANK(Name))`, "true"},
+		{`LEFT(Name, 2) & "-" & UPPER(RIGHT(Name, 2))`, "Ac-ME"},
+		{`CloseDate + 1`, "2024-02-01"},
+		{`ADDMONTHS(CloseDate, 1)`, "2024-02-29"},
+		{`TODAY() - DATE(2024, 2, 1)`, "29"},
+		{`MONTH(CloseDate)`, "1"},
+		{`ISCHANGED(Stage__c) && PRIORVALUE(Stage__c) = "Open"`, "true"},
+		{`ISCHANGED(Amount__c)`, "false"},
+		{`$User.Department = "Sales"`, "true"},
+		{`TEXT(ROUND(-2.5, 0))`, "-3"},
+		{`Missing__c + 1`, ""},
+		{`FIND("me", Name)`, "3"},
+	}
+	for _, tt := range tests {
+		actual, err := Evaluate(tt.formula, env)
+		if err != nil {
+			t.Errorf("unexpected error for %s: %s", tt.formula, err.Error())
+			continue
+		}
+		if Format(actual) != tt.expected {
+			t.Errorf("expected %q, got %q for %s", tt.expected, Format(actual), tt.formula)
+		}
+	}
+	if _, err := Evaluate(`GETSESSIONID()`, env); err == nil {
+		t.Error("expected error for unsupported function")
+	}
+}
@@ -0,0 +1,637 @@
+package formula
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/antlr4-go/antlr/v4"
+	"github.com/octoberswimmer/sformula/parser"
+)
+
+type function func(e *evaluator, args []antlr.Tree) (any, error)
+
+var functions map[string]function
+
+func init() {
+	functions = map[string]function{
+		"ABS":           numeric(math.Abs),
+		"ADDMONTHS":     addMonths,
+		"AND":           and,
+		"BEGINS":        textCompare(strings.HasPrefix),
+		"BLANKVALUE":    blankValue,
+		"BR":            func(*evaluator, []antlr.Tree) (any, error) { return "<br>", nil },
+		"CASE":          caseOf,
+		"CASESAFEID":    caseSafeId,
+		"CEILING":       numeric(ceiling),
+		"CONTAINS":      textCompare(strings.Contains),
+		"DATE":          date,
+		"DATETIMEVALUE": dateTimeValue,
+		"DATEVALUE":     dateValue,
+		"DAY":           datePart(func(d Date) int { return d.Day() }),
+		"EXP":           numeric(math.Exp),
+		"FIND":          find,
+		"FLOOR":         numeric(floor),
+		"HOUR":          hour,
+		"HTMLENCODE":    textFunction(html.EscapeString),
+		"IF":            ifThen,
+		"INCLUDES":      includes,
+		"ISBLANK":       isBlankFunction,
+		"ISCHANGED":     isChanged,
+		"ISNEW":         func(e *evaluator, _ []antlr.Tree) (any, error) { return e.env.Prior == nil, nil },
+		"ISNULL":        isBlankFunction,
+		"ISNUMBER":      isNumberFunction,
+		"ISPICKVAL":     isPickval,
+		"LEFT":          left,
+		"LEN":           length,
+		"LOWER":         textFunction(strings.ToLower),
+		"LPAD":          lpad,
+		"MAX":           extreme(math.Max),
+		"MID":           mid,
+		"MIN":           extreme(math.Min),
+		"MOD":           mod,
+		"MONTH":         datePart(func(d Date) int { return int(d.Month()) }),
+		"NOT":           not,
+		"NOW":           func(e *evaluator, _ []antlr.Tree) (any, error) { return e.env.Now.UTC(), nil },
+		"OR":            or,
+		"PRIORVALUE":    priorValue,
+		"REGEX":         regex,
+		"RIGHT":         right,
+		"ROUND":         round,
+		"SUBSTITUTE":    substitute,
+		"TEXT":          text,
+		"TODAY":         today,
+		"TRIM":          textFunction(strings.TrimSpace),
+		"UPPER":         textFunction(strings.ToUpper),
+		"VALUE":         value,
+		"YEAR":          datePart(func(d Date) int { return d.Year() }),
+	}
+}
+
+func (e *evaluator) call(ctx parser.IFunctionCallContext) (any, error) {
+	fn := ctx.GetChild(0).(antlr.ParserRuleContext)
+	name := strings.ToUpper(fn.GetChild(0).(antlr.TerminalNode).GetText())
+	var args []antlr.Tree
+	for _, child := range fn.GetChildren() {
+		if _, ok := child.(antlr.ParserRuleContext); ok {
+			args = append(args, unwrap(child))
+		}
+	}
+	f, ok := functions[name]
+	if !ok {
+		return nil, fmt.Errorf("unsupported function: %s", name)
+	}
+	v, err := f(e, args)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return v, nil
+}
+
+// unwrap returns the expression within a named function argument, e.g. the
+// logicalExpression of IF
+func unwrap(t antlr.Tree) antlr.Tree {
+	if _, ok := t.(parser.IExpressionContext); ok {
+		return t
+	}
+	if t.GetChildCount() == 1 {
+		return t.GetChild(0)
+	}
+	return t
+}
+
+func (e *evaluator) arg(args []antlr.Tree, i int) (any, error) {
+	if i >= len(args) {
+		return nil, nil
+	}
+	return e.value(args[i])
+}
+
+func (e *evaluator) number(args []antlr.Tree, i int) (float64, bool, error) {
+	v, err := e.arg(args, i)
+	if err != nil {
+		return 0, false, err
+	}
+	n, ok, err := toNumber(v)
+	if !ok && err == nil && e.env.BlanksAsZero {
+		return 0, true, nil
+	}
+	return n, ok, err
+}
+
+func (e *evaluator) text(args []antlr.Tree, i int) (string, error) {
+	v, err := e.arg(args, i)
+	return toText(v), err
+}
+
+// reference returns the names in a field reference passed to a function like
+// PRIORVALUE
+func reference(t antlr.Tree) ([]string, error) {
+	switch ctx := t.(type) {
+	case *parser.FieldReferenceExpressionContext:
+		return referenceParts(ctx.FieldReference()), nil
+	case *parser.VariableExpressionContext:
+		return referenceParts(ctx.FieldReference()), nil
+	case *parser.PrimaryExpressionContext:
+		if p := ctx.Primary().(*parser.PrimaryContext); p.Expression() != nil {
+			return reference(p.Expression())
+		}
+	}
+	return nil, fmt.Errorf("expected field, got %s", t.(antlr.ParseTree).GetText())
+}
+
+func numeric(f func(float64) float64) function {
+	return func(e *evaluator, args []antlr.Tree) (any, error) {
+		n, ok, err := e.number(args, 0)
+		if err != nil || !ok {
+			return nil, err
+		}
+		return f(n), nil
+	}
+}
+
+// ceiling rounds away from zero
+func ceiling(n float64) float64 {
+	if n < 0 {
+		return -math.Ceil(-n)
+	}
+	return math.Ceil(n)
+}
+
+// floor rounds toward zero
+func floor(n float64) float64 {
+	if n < 0 {
+		return -math.Floor(-n)
+	}
+	return math.Floor(n)
+}
+
+func round(e *evaluator, args []antlr.Tree) (any, error) {
+	n, ok, err := e.number(args, 0)
+	if err != nil || !ok {
+		return nil, err
+	}
+	digits, _, err := e.number(args, 1)
+	if err != nil {
+		return nil, err
+	}
+	scale := math.Pow(10, math.Trunc(digits))
+	// math.Round rounds half away from zero
+	return math.Round(n*scale) / scale, nil
+}
+
+func mod(e *evaluator, args []antlr.Tree) (any, error) {
+	n, ok, err := e.number(args, 0)
+	if err != nil || !ok {
+		return nil, err
+	}
+	d, ok, err := e.number(args, 1)
+	if err != nil || !ok {
+		return nil, err
+	}
+	if d == 0 {
+		return nil, fmt.Errorf("division by zero")
+	}
+	return math.Mod(n, d), nil
+}
+
+func extreme(f func(a, b float64) float64) function {
+	return func(e *evaluator, args []antlr.Tree) (any, error) {
+		var result float64
+		for i := range args {
+			n, ok, err := e.number(args, i)
+			if err != nil || !ok {
+				return nil, err
+			}
+			if i == 0 {
+				result = n
+			} else {
+				result = f(result, n)
+			}
+		}
+		return result, nil
+	}
+}
+
+func and(e *evaluator, args []antlr.Tree) (any, error) {
+	for _, a := range args {
+		b, err := e.boolean(a)
+		if err != nil || !b {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func or(e *evaluator, args []antlr.Tree) (any, error) {
+	for _, a := range args {
+		b, err := e.boolean(a)
+		if err != nil || b {
+			return b, err
+		}
+	}
+	return false, nil
+}
+
+func not(e *evaluator, args []antlr.Tree) (any, error) {
+	b, err := e.boolean(args[0])
+	return !b, err
+}
+
+func ifThen(e *evaluator, args []antlr.Tree) (any, error) {
+	b, err := e.boolean(args[0])
+	if err != nil {
+		return nil, err
+	}
+	if b {
+		return e.arg(args, 1)
+	}
+	return e.arg(args, 2)
+}
+
+func caseOf(e *evaluator, args []antlr.Tree) (any, error) {
+	v, err := e.arg(args, 0)
+	if err != nil {
+		return nil, err
+	}
+	for i := 1; i+1 < len(args); i += 2 {
+		match, err := e.arg(args, i)
+		if err != nil {
+			return nil, err
+		}
+		if e.equal(v, match) {
+			return e.arg(args, i+1)
+		}
+	}
+	return e.arg(args, len(args)-1)
+}
+
+func isBlankFunction(e *evaluator, args []antlr.Tree) (any, error) {
+	v, err := e.arg(args, 0)
+	return isBlank(v), err
+}
+
+func blankValue(e *evaluator, args []antlr.Tree) (any, error) {
+	v, err := e.arg(args, 0)
+	if err != nil || !isBlank(v) {
+		return v, err
+	}
+	return e.arg(args, 1)
+}
+
+func isPickval(e *evaluator, args []antlr.Tree) (any, error) {
+	v, err := e.text(args, 0)
+	if err != nil {
+		return nil, err
+	}
+	want, err := e.text(args, 1)
+	return v == want, err
+}
+
+func includes(e *evaluator, args []antlr.Tree) (any, error) {
+	v, err := e.text(args, 0)
+	if err != nil {
+		return nil, err
+	}
+	want, err := e.text(args, 1)
+	if err != nil {
+		return nil, err
+	}
+	for _, selected := range strings.Split(v, ";") {
+		if selected == want && want != "" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func isChanged(e *evaluator, args []antlr.Tree) (any, error) {
+	parts, err := reference(args[0])
+	if err != nil || e.env.Prior == nil {
+		return false, err
+	}
+	return !e.equal(e.lookup(parts, e.env.Record), e.lookup(parts, e.env.Prior)), nil
+}
+
+// priorValue returns the value of a field before the record was changed, or
+// its current value for new records
+func priorValue(e *evaluator, args []antlr.Tree) (any, error) {
+	parts, err := reference(args[0])
+	if err != nil {
+		return nil, err
+	}
+	if e.env.Prior == nil {
+		return e.lookup(parts, e.env.Record), nil
+	}
+	return e.lookup(parts, e.env.Prior), nil
+}
+
+func textFunction(f func(string) string) function {
+	return func(e *evaluator, args []antlr.Tree) (any, error) {
+		s, err := e.text(args, 0)
+		return f(s), err
+	}
+}
+
+func textCompare(f func(s, substr string) bool) function {
+	return func(e *evaluator, args []antlr.Tree) (any, error) {
+		s, err := e.text(args, 0)
+		if err != nil {
+			return nil, err
+		}
+		substr, err := e.text(args, 1)
+		return f(s, substr), err
+	}
+}
+
+func text(e *evaluator, args []antlr.Tree) (any, error) {
+	v, err := e.arg(args, 0)
+	if err != nil || isBlank(v) {
+		return "", err
+	}
+	return toText(v), nil
+}
+
+func value(e *evaluator, args []antlr.Tree) (any, error) {
+	v, err := e.arg(args, 0)
+	if err != nil {
+		return nil, err
+	}
+	n, ok, err := toNumber(v)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return n, nil
+}
+
+func isNumberFunction(e *evaluator, args []antlr.Tree) (any, error) {
+	s, err := e.text(args, 0)
+	if err != nil {
+		return nil, err
+	}
+	_, err = strconv.ParseFloat(strings.TrimSpace(s), 64)
+	return err == nil, nil
+}
+
+func length(e *evaluator, args []antlr.Tree) (any, error) {
+	s, err := e.text(args, 0)
+	return float64(len([]rune(s))), err
+}
+
+// substring returns up to n characters of s starting at the 0-based start
+func substring(s string, start, n int) string {
+	r := []rune(s)
+	if start < 0 {
+		start = 0
+	}
+	if n <= 0 || start >= len(r) {
+		return ""
+	}
+	end := min(start+n, len(r))
+	return string(r[start:end])
+}
+
+func left(e *evaluator, args []antlr.Tree) (any, error) {
+	s, err := e.text(args, 0)
+	if err != nil {
+		return nil, err
+	}
+	n, _, err := e.number(args, 1)
+	return substring(s, 0, int(n)), err
+}
+
+func right(e *evaluator, args []antlr.Tree) (any, error) {
+	s, err := e.text(args, 0)
+	if err != nil {
+		return nil, err
+	}
+	n, _, err := e.number(args, 1)
+	return substring(s, len([]rune(s))-int(n), int(n)), err
+}
+
+func mid(e *evaluator, args []antlr.Tree) (any, error) {
+	s, err := e.text(args, 0)
+	if err != nil {
+		return nil, err
+	}
+	start, _, err := e.number(args, 1)
+	if err != nil {
+		return nil, err
+	}
+	n, _, err := e.number(args, 2)
+	return substring(s, int(start)-1, int(n)), err
+}
+
+func find(e *evaluator, args []antlr.Tree) (any, error) {
+	search, err := e.text(args, 0)
+	if err != nil {
+		return nil, err
+	}
+	s, err := e.text(args, 1)
+	if err != nil {
+		return nil, err
+	}
+	start := 1.0
+	if len(args) > 2 {
+		if start, _, err = e.number(args, 2); err != nil {
+			return nil, err
+		}
+	}
+	r := []rune(s)
+	if search == "" || start < 1 || int(start) > len(r) {
+		return 0.0, nil
+	}
+	i := strings.Index(string(r[int(start)-1:]), search)
+	if i < 0 {
+		return 0.0, nil
+	}
+	return float64(len([]rune(string(r[int(start)-1:])[:i])) + int(start)), nil
+}
+
+func substitute(e *evaluator, args []antlr.Tree) (any, error) {
+	s, err := e.text(args, 0)
+	if err != nil {
+		return nil, err
+	}
+	old, err := e.text(args, 1)
+	if err != nil {
+		return nil, err
+	}
+	replacement, err := e.text(args, 2)
+	if err != nil || old == "" {
+		return s, err
+	}
+	return strings.ReplaceAll(s, old, replacement), nil
+}
+
+func lpad(e *evaluator, args []antlr.Tree) (any, error) {
+	s, err := e.text(args, 0)
+	if err != nil {
+		return nil, err
+	}
+	n, _, err := e.number(args, 1)
+	if err != nil {
+		return nil, err
+	}
+	pad := " "
+	if len(args) > 2 {
+		if pad, err = e.text(args, 2); err != nil {
+			return nil, err
+		}
+	}
+	r := []rune(s)
+	if len(r) >= int(n) {
+		return substring(s, 0, int(n)), nil
+	}
+	if pad == "" {
+		return s, nil
+	}
+	padding := strings.Repeat(pad, int(n)-len(r))
+	return substring(padding, 0, int(n)-len(r)) + s, nil
+}
+
+// regex returns whether the entire text matches a regular expression
+func regex(e *evaluator, args []antlr.Tree) (any, error) {
+	s, err := e.text(args, 0)
+	if err != nil {
+		return nil, err
+	}
+	expr, err := e.text(args, 1)
+	if err != nil {
+		return nil, err
+	}
+	re, err := regexp.Compile(`^(?:` + expr + `)$`)
+	if err != nil {
+		return nil, err
+	}
+	return re.MatchString(s), nil
+}
+
+// caseSafeId converts a 15-character id to its 18-character form
+func caseSafeId(e *evaluator, args []antlr.Tree) (any, error) {
+	id, err := e.text(args, 0)
+	if err != nil || len(id) != 15 {
+		return id, err
+	}
+	const chars = "ABCDEFGHIJKLMNOPQRSTUVWXYZ012345"
+	suffix := ""
+	for i := 0; i < 15; i += 5 {
+		bits := 0
+		for j := 0; j < 5; j++ {
+			if c := id[i+j]; c >= 'A' && c <= 'Z' {
+				bits |= 1 << j
+			}
+		}
+		suffix += string(chars[bits])
+	}
+	return id + suffix, nil
+}
+
+func date(e *evaluator, args []antlr.Tree) (any, error) {
+	var parts [3]int
+	for i := range parts {
+		n, ok, err := e.number(args, i)
+		if err != nil || !ok {
+			return nil, err
+		}
+		parts[i] = int(n)
+	}
+	d := NewDate(parts[0], time.Month(parts[1]), parts[2])
+	if d.Year() != parts[0] || int(d.Month()) != parts[1] || d.Day() != parts[2] {
+		return nil, fmt.Errorf("invalid date: %d-%d-%d", parts[0], parts[1], parts[2])
+	}
+	return d, nil
+}
+
+func dateValue(e *evaluator, args []antlr.Tree) (any, error) {
+	v, err := e.arg(args, 0)
+	if err != nil {
+		return nil, err
+	}
+	d, ok, err := toDate(v)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return d, nil
+}
+
+func dateTimeValue(e *evaluator, args []antlr.Tree) (any, error) {
+	v, err := e.arg(args, 0)
+	if err != nil {
+		return nil, err
+	}
+	t, ok, err := toDateTime(v)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return t, nil
+}
+
+func datePart(f func(Date) int) function {
+	return func(e *evaluator, args []antlr.Tree) (any, error) {
+		v, err := e.arg(args, 0)
+		if err != nil {
+			return nil, err
+		}
+		d, ok, err := toDate(v)
+		if err != nil || !ok {
+			return nil, err
+		}
+		return float64(f(d)), nil
+	}
+}
+
+func hour(e *evaluator, args []antlr.Tree) (any, error) {
+	v, err := e.arg(args, 0)
+	if err != nil {
+		return nil, err
+	}
+	t, ok, err := toDateTime(v)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return float64(t.UTC().Hour()), nil
+}
+
+func today(e *evaluator, _ []antlr.Tree) (any, error) {
+	now := e.env.Now.UTC()
+	return NewDate(now.Year(), now.Month(), now.Day()), nil
+}
+
+// addMonths adds months to a date.  The last day of a month stays the last
+// day of the resulting month, and days past the end of the resulting month
+// become its last day.
+func addMonths(e *evaluator, args []antlr.Tree) (any, error) {
+	v, err := e.arg(args, 0)
+	if err != nil {
+		return nil, err
+	}
+	t, ok, err := toDateTime(v)
+	if err != nil || !ok {
+		return nil, err
+	}
+	n, ok, err := e.number(args, 1)
+	if err != nil || !ok {
+		return nil, err
+	}
+	lastDay := func(year int, month time.Month) int {
+		return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	}
+	first := time.Date(t.Year(), t.Month(), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC).AddDate(0, int(n), 0)
+	day := t.Day()
+	if day == lastDay(t.Year(), t.Month()) || day > lastDay(first.Year(), first.Month()) {
+		day = lastDay(first.Year(), first.Month())
+	}
+	result := first.AddDate(0, 0, day-1)
+	if _, isDateTime := v.(time.Time); isDateTime {
+		return result, nil
+	}
+	if s, isText := v.(string); isText && len(s) > len("2006-01-02") {
+		return result, nil
+	}
+	return Date{result}, nil
+}
@@ -0,0 +1,153 @@
+// Package formula provides analysis of Salesforce formulas.
+package formula
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/antlr4-go/antlr/v4"
+	"github.com/octoberswimmer/sformula/parser"
+)
+
+type errorListener struct {
+	*antlr.DefaultErrorListener
+	logger io.Writer
+}
+
+func (e *errorListener) SyntaxError(_ antlr.Recognizer, _ interface{}, line, column int, msg string, _ antlr.RecognitionException) {
+	_, _ = fmt.Fprintln(e.logger, "line "+strconv.Itoa(line)+":"+strconv.Itoa(column)+" "+msg)
+}
+
+// Parse parses a formula, returning the syntax tree and token stream.
+func Parse(f string) (parser.ICompilationUnitContext, *antlr.CommonTokenStream, error) {
+	input := antlr.NewInputStream(f)
+	lexer := parser.NewFormulaLexer(input)
+	lexer.RemoveErrorListeners()
+
+	var buf bytes.Buffer
+	errors := &errorListener{logger: &buf}
+	lexer.AddErrorListener(errors)
+
+	stream := antlr.NewCommonTokenStream(lexer, antlr.TokenDefaultChannel)
+	p := parser.NewFormulaParser(stream)
+	p.RemoveErrorListeners()
+	p.AddErrorListener(errors)
+
+	tree := p.CompilationUnit()
+	if buf.Len() != 0 {
+		return nil, nil, fmt.Errorf("syntax error: %s", strings.TrimSpace(buf.String()))
+	}
+	return tree, stream, nil
+}
+
+type referenceListener struct {
+	*parser.BaseFormulaParserListener
+	references []string
+}
+
+func (l *referenceListener) EnterFieldReference(ctx *parser.FieldReferenceContext) {
+	// Only the outermost reference is included for references like
+	// Picklist__c.Value
+	if _, nested := ctx.GetParent().(*parser.FieldReferenceContext); nested {
+		return
+	}
+	var parts []string
+	for _, id := range fieldReferenceIdentifiers(ctx) {
+		parts = append(parts, id.GetText())
+	}
+	l.references = append(l.references, strings.Join(parts, "."))
+}
+
+func fieldReferenceIdentifiers(ctx *parser.FieldReferenceContext) []antlr.TerminalNode {
+	if inner, ok := ctx.FieldReference().(*parser.FieldReferenceContext); ok && inner != nil {
+		return fieldReferenceIdentifiers(inner)
+	}
+	return ctx.AllIdentifier()
+}
+
+// FieldReferences returns the field references in a formula in the order in
+// which they appear, e.g. Name, Account.Owner.Email, or $User.Id.
+func FieldReferences(f string) ([]string, error) {
+	tree, _, err := Parse(f)
+	if err != nil {
+		return nil, err
+	}
+	l := &referenceListener{BaseFormulaParserListener: &parser.BaseFormulaParserListener{}}
+	antlr.ParseTreeWalkerDefault.Walk(l, tree)
+	return l.references, nil
+}
+
+type replaceListener struct {
+	*parser.BaseFormulaParserListener
+	replace func(string) (string, bool)
+	edits   []edit
+}
+
+type edit struct {
+	start, stop int
+	text        string
+}
+
+func (l *replaceListener) EnterFieldReference(ctx *parser.FieldReferenceContext) {
+	if _, nested := ctx.GetParent().(*parser.FieldReferenceContext); nested {
+		return
+	}
+	ids := fieldReferenceIdentifiers(ctx)
+	if len(ids) == 0 {
+		return
+	}
+	var parts []string
+	for _, id := range ids {
+		parts = append(parts, id.GetText())
+	}
+	ref := strings.Join(parts, ".")
+	if replacement, ok := l.replace(ref); ok && replacement != ref {
+		l.edits = append(l.edits, edit{
+			start: ids[0].GetSymbol().GetStart(),
+			stop:  ids[len(ids)-1].GetSymbol().GetStop(),
+			text:  replacement,
+		})
+	}
+}
+
+// ReplaceFieldReferences calls replace for each field reference in a formula,
+// and replaces the reference with the value returned if ok is true.  The rest
+// of the formula, including whitespace and comments, is unchanged.  The number
+// of references replaced is returned.
+func ReplaceFieldReferences(f string, replace func(ref string) (replacement string, ok bool)) (string, int, error) {
+	tree, _, err := Parse(f)
+	if err != nil {
+		return f, 0, err
+	}
+	l := &replaceListener{BaseFormulaParserListener: &parser.BaseFormulaParserListener{}, replace: replace}
+	antlr.ParseTreeWalkerDefault.Walk(l, tree)
+	if len(l.edits) == 0 {
+		return f, 0, nil
+	}
+	// Token positions are rune offsets
+	runes := []rune(f)
+	for i := len(l.edits) - 1; i >= 0; i-- {
+		e := l.edits[i]
+		runes = append(runes[:e.start], append([]rune(e.text), runes[e.stop+1:]...)...)
+	}
+	return string(runes), len(l.edits), nil
+}
+
+// Size returns the length of a formula without whitespace and comments.
+func Size(f string) (int, error) {
+	_, stream, err := Parse(f)
+	if err != nil {
+		return 0, err
+	}
+	size := 0
+	for _, t := range stream.GetAllTokens() {
+		if t.GetChannel() != antlr.TokenDefaultChannel || t.GetTokenType() == antlr.TokenEOF {
+			continue
+		}
+		size += len([]rune(t.GetText()))
+	}
+	return size, nil
+}
@@ -0,0 +1,73 @@
+package formula_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	. "github.com/ForceCLI/force-md/internal/formula"
+)
+
+func TestFieldReferences(t *testing.T) {
+	tests := []struct {
+		formula  string
+		expected []string
+	}{
+		{`Name`, []string{"Name"}},
+		{`IF(ISPICKVAL(Status__c, "Open"), Account.Owner.Email, $User.Email)`, []string{"Status__c", "Account.Owner.Email", "$User.Email"}},
+		{`Amount__c * 2 + Parent__r.Discount__c`, []string{"Amount__c", "Parent__r.Discount__c"}},
+		{`"literal" & TEXT(1)`, nil},
+	}
+	for _, tt := range tests {
+		actual, err := FieldReferences(tt.formula)
+		if err != nil {
+			t.Errorf("unexpected error for %s: %s", tt.formula, err.Error())
+			continue
+		}
+		if !reflect.DeepEqual(actual, tt.expected) {
+			t.Errorf("expected %v, got %v for %s", tt.expected, actual, tt.formula)
+		}
+	}
+	if _, err := FieldReferences(`IF(Name`); err == nil {
+		t.Error("expected error for invalid formula")
+	}
+}
+
+func TestReplaceFieldReferences(t *testing.T) {
+	rename := func(ref string) (string, bool) {
+		if ref == "Old__c" || ref == "Account.Old__c" {
+			return strings.Replace(ref, "Old__c", "New__c", 1), true
+		}
+		return "", false
+	}
+	tests := []struct {
+		formula  string
+		expected string
+		count    int
+	}{
+		{`Old__c`, `New__c`, 1},
+		{`IF(Old__c > 1, "Old__c", Account.Old__c) /* Old__c */`, `IF(New__c > 1, "Old__c", Account.New__c) /* Old__c */`, 2},
+		{`Old__cx + Other.Old__c`, `Old__cx + Other.Old__c`, 0},
+		{`"é" & Old__c`, `"é" & New__c`, 1},
+	}
+	for _, tt := range tests {
+		actual, count, err := ReplaceFieldReferences(tt.formula, rename)
+		if err != nil {
+			t.Errorf("unexpected error for %s: %s", tt.formula, err.Error())
+			continue
+		}
+		if actual != tt.expected || count != tt.count {
+			t.Errorf("expected %q (%d), got %q (%d)", tt.expected, tt.count, actual, count)
+		}
+	}
+}
+
+func TestSize(t *testing.T) {
+	size, err := Size("IF( Amount__c > 1, /* big */ 1, 0 )")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if expected := len("IF(Amount__c>1,1,0)"); size != expected {
+		t.Errorf("expected %d, got %d", expected, size)
+	}
+}
@@ -0,0 +1,173 @@
+package formula
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Date is the value of a date.  Date/time values are time.Time.
+type Date struct {
+	time.Time
+}
+
+func NewDate(year int, month time.Month, day int) Date {
+	return Date{time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+}
+
+func (d Date) String() string {
+	return d.Format("2006-01-02")
+}
+
+const dateTimeFormat = "2006-01-02 15:04:05Z"
+
+var dateTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.000-0700",
+	"2006-01-02T15:04:05-0700",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05",
+}
+
+// Format returns the text of a value as TEXT() would, e.g. 2024-01-31 for a
+// date.  Blank values are empty.
+func Format(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		// Round away floating point error, e.g. 0.1 + 0.2
+		rounded := math.Round(v*1e9) / 1e9
+		if rounded == 0 {
+			rounded = 0
+		}
+		return strconv.FormatFloat(rounded, 'f', -1, 64)
+	case Date:
+		return v.String()
+	case time.Time:
+		return v.UTC().Format(dateTimeFormat)
+	}
+	return fmt.Sprint(v)
+}
+
+func isBlank(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	}
+	return false
+}
+
+func toNumber(v any) (float64, bool, error) {
+	switch v := v.(type) {
+	case nil:
+		return 0, false, nil
+	case float64:
+		return v, true, nil
+	case int:
+		return float64(v), true, nil
+	case string:
+		if v == "" {
+			return 0, false, nil
+		}
+		n, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return 0, false, fmt.Errorf("%q is not a number", v)
+		}
+		return n, true, nil
+	case bool:
+		return 0, false, fmt.Errorf("expected number, got boolean")
+	}
+	return 0, false, fmt.Errorf("expected number, got %s", Format(v))
+}
+
+func toText(v any) string {
+	return Format(v)
+}
+
+func toBool(v any) (bool, error) {
+	switch v := v.(type) {
+	case nil:
+		return false, nil
+	case bool:
+		return v, nil
+	case string:
+		switch strings.ToLower(v) {
+		case "true":
+			return true, nil
+		case "false", "":
+			return false, nil
+		}
+	}
+	return false, fmt.Errorf("expected boolean, got %s", Format(v))
+}
+
+// toDate converts dates, date/times, and text in YYYY-MM-DD format to a
+// date
+func toDate(v any) (Date, bool, error) {
+	switch v := v.(type) {
+	case nil:
+		return Date{}, false, nil
+	case Date:
+		return v, true, nil
+	case time.Time:
+		u := v.UTC()
+		return NewDate(u.Year(), u.Month(), u.Day()), true, nil
+	case string:
+		if v == "" {
+			return Date{}, false, nil
+		}
+		if t, err := time.Parse("2006-01-02", v); err == nil {
+			return Date{t}, true, nil
+		}
+		if t, ok, err := toDateTime(v); ok && err == nil {
+			return toDate(t)
+		}
+		return Date{}, false, fmt.Errorf("%q is not a date", v)
+	}
+	return Date{}, false, fmt.Errorf("expected date, got %s", Format(v))
+}
+
+func toDateTime(v any) (time.Time, bool, error) {
+	switch v := v.(type) {
+	case nil:
+		return time.Time{}, false, nil
+	case time.Time:
+		return v, true, nil
+	case Date:
+		return v.Time, true, nil
+	case string:
+		if v == "" {
+			return time.Time{}, false, nil
+		}
+		for _, layout := range dateTimeLayouts {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t.UTC(), true, nil
+			}
+		}
+		if t, err := time.Parse("2006-01-02", v); err == nil {
+			return t, true, nil
+		}
+		return time.Time{}, false, fmt.Errorf("%q is not a date/time", v)
+	}
+	return time.Time{}, false, fmt.Errorf("expected date/time, got %s", Format(v))
+}
+
+// looksLikeDate returns whether text is a date or date/time, so it can be
+// compared with a date
+func looksLikeDate(v any) bool {
+	s, ok := v.(string)
+	if !ok {
+		return false
+	}
+	_, isDateTime, err := toDateTime(s)
+	return isDateTime && err == nil
+}
@@ -0,0 +1,128 @@
+package semantic
+
+import (
+	"bytes"
+	"strings"
+)
+
+// ConflictMarkers combines two versions of a file, surrounding the lines that
+// differ with git-style conflict markers.
+func ConflictMarkers(ours, theirs []byte) []byte {
+	a := strings.SplitAfter(string(ours), "\n")
+	b := strings.SplitAfter(string(theirs), "\n")
+	var out bytes.Buffer
+	var oursHunk, theirsHunk []string
+	flush := func() {
+		if len(oursHunk) == 0 && len(theirsHunk) == 0 {
+			return
+		}
+		out.WriteString("<<<<<<< ours\n")
+		out.WriteString(strings.Join(oursHunk, ""))
+		out.WriteString("=======\n")
+		out.WriteString(strings.Join(theirsHunk, ""))
+		out.WriteString(">>>>>>> theirs\n")
+		oursHunk, theirsHunk = nil, nil
+	}
+	for _, e := range diffLines(a, b) {
+		switch e.op {
+		case equal:
+			flush()
+			out.WriteString(a[e.a])
+		case deleted:
+			oursHunk = append(oursHunk, a[e.a])
+		case inserted:
+			theirsHunk = append(theirsHunk, b[e.b])
+		}
+	}
+	flush()
+	return out.Bytes()
+}
+
+type editOp int
+
+const (
+	equal editOp = iota
+	deleted
+	inserted
+)
+
+type edit struct {
+	op   editOp
+	a, b int
+}
+
+// diffLines returns the shortest edit script from a to b using Myers'
+// algorithm.  The files being compared differ only where there are conflicts,
+// so only the furthest reaching paths for each number of edits are kept.
+func diffLines(a, b []string) []edit {
+	n, m := len(a), len(b)
+	var trace [][]int
+	prev := []int{0}
+	var d int
+	for d = 0; ; d++ {
+		v := make([]int, 2*d+1)
+		done := false
+		for k := -d; k <= d; k += 2 {
+			var x int
+			switch {
+			case d == 0:
+				x = 0
+			case k == -d || (k != d && prev[k-1+d-1] < prev[k+1+d-1]):
+				x = prev[k+1+d-1]
+			default:
+				x = prev[k-1+d-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[k+d] = x
+			if x >= n && y >= m {
+				done = true
+				break
+			}
+		}
+		trace = append(trace, v)
+		prev = v
+		if done {
+			break
+		}
+	}
+
+	var edits []edit
+	x, y := n, m
+	for ; d > 0; d-- {
+		p := trace[d-1]
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && p[k-1+d-1] < p[k+1+d-1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := p[prevK+d-1]
+		prevY := prevX - prevK
+		for x > prevX && y > prevY && (prevK == k+1 || x > prevX+1) {
+			x--
+			y--
+			edits = append(edits, edit{op: equal, a: x, b: y})
+		}
+		if prevK == k+1 {
+			y--
+			edits = append(edits, edit{op: inserted, b: y})
+		} else {
+			x--
+			edits = append(edits, edit{op: deleted, a: x})
+		}
+	}
+	for x > 0 {
+		x--
+		y--
+		edits = append(edits, edit{op: equal, a: x, b: y})
+	}
+	for i, j := 0, len(edits)-1; i < j; i, j = i+1, j-1 {
+		edits[i], edits[j] = edits[j], edits[i]
+	}
+	return edits
+}
@@ -0,0 +1,206 @@
+package semantic
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/ForceCLI/force-md/metadata"
+)
+
+type ChangeKind string
+
+const (
+	Added   ChangeKind = "added"
+	Removed ChangeKind = "removed"
+	Changed ChangeKind = "changed"
+)
+
+// Difference is a change to a value or a list entry.  Path contains the keys
+// of the list entries that contain the change.
+type Difference struct {
+	Kind  ChangeKind `json:"kind"`
+	Path  []string   `json:"path,omitempty"`
+	Type  string     `json:"type,omitempty"`
+	Key   string     `json:"key,omitempty"`
+	Field string     `json:"field,omitempty"`
+	Old   string     `json:"old,omitempty"`
+	New   string     `json:"new,omitempty"`
+}
+
+func (d Difference) String() string {
+	prefix := ""
+	if len(d.Path) > 0 {
+		prefix = strings.Join(d.Path, "/") + ": "
+	}
+	switch d.Kind {
+	case Added, Removed:
+		return fmt.Sprintf("%s%s %s %s", prefix, d.Type, d.Key, d.Kind)
+	default:
+		field := d.Field
+		if field != "" {
+			field += " "
+		}
+		return fmt.Sprintf("%s%s%s→%s", prefix, field, quote(d.Old), quote(d.New))
+	}
+}
+
+func quote(s string) string {
+	if s == "" || strings.ContainsAny(s, "\n\r\t") || strings.TrimSpace(s) != s {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// Diff returns the differences between two values of the same metadata type.
+// List entries are matched by their natural key, so reordering entries isn't
+// a change.
+func Diff(a, b any) []Difference {
+	var diffs []Difference
+	compare(nil, "", reflect.ValueOf(a), reflect.ValueOf(b), &diffs)
+	ua, okA := a.(unknownElements)
+	ub, okB := b.(unknownElements)
+	if okA && okB {
+		diffUnknown(ua.UnknownElements(), ub.UnknownElements(), &diffs)
+	}
+	return diffs
+}
+
+type unknownElements interface {
+	UnknownElements() metadata.UnknownElements
+}
+
+// diffUnknown compares the elements that were preserved because the metadata
+// type doesn't model them
+func diffUnknown(a, b metadata.UnknownElements, diffs *[]Difference) {
+	remaining := make(map[string]int)
+	for _, e := range b {
+		remaining[string(e.Raw)]++
+	}
+	for _, e := range a {
+		if remaining[string(e.Raw)] > 0 {
+			remaining[string(e.Raw)]--
+			continue
+		}
+		*diffs = append(*diffs, Difference{Kind: Removed, Type: "Element", Key: e.Name})
+	}
+	for _, e := range b {
+		if remaining[string(e.Raw)] > 0 {
+			remaining[string(e.Raw)]--
+			*diffs = append(*diffs, Difference{Kind: Added, Type: "Element", Key: e.Name})
+		}
+	}
+}
+
+func joinField(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
+
+func compare(path []string, field string, a, b reflect.Value, diffs *[]Difference) {
+	t := a.Type()
+	if isLeaf(t) {
+		if old, updated := text(a), text(b); old != updated {
+			*diffs = append(*diffs, Difference{Kind: Changed, Path: path, Field: field, Old: old, New: updated})
+		}
+		return
+	}
+	if indirectType(t).Kind() == reflect.Slice {
+		compareLists(path, field, indirect(a), indirect(b), indirectType(t), diffs)
+		return
+	}
+	a, b = indirect(a), indirect(b)
+	st := indirectType(t)
+	if st.Kind() != reflect.Struct {
+		return
+	}
+	if !a.IsValid() {
+		a = reflect.New(st).Elem()
+	}
+	if !b.IsValid() {
+		b = reflect.New(st).Elem()
+	}
+	if i, ok := textField(st); ok {
+		if old, updated := strings.TrimSpace(a.Field(i).String()), strings.TrimSpace(b.Field(i).String()); old != updated {
+			*diffs = append(*diffs, Difference{Kind: Changed, Path: path, Field: field, Old: old, New: updated})
+		}
+	}
+	for _, f := range fields(st) {
+		name := f.name
+		if f.attr {
+			name = "@" + name
+		}
+		compare(path, joinField(field, name), a.Field(f.index), b.Field(f.index), diffs)
+	}
+}
+
+func compareLists(path []string, field string, a, b reflect.Value, t reflect.Type, diffs *[]Difference) {
+	if !a.IsValid() {
+		a = reflect.MakeSlice(t, 0, 0)
+	}
+	if !b.IsValid() {
+		b = reflect.MakeSlice(t, 0, 0)
+	}
+	keys := keyFields(t.Elem())
+	keyed := len(keys) > 0
+	label := singular(field[strings.LastIndex(field, ".")+1:])
+	aOrder, _ := index(a, keys)
+	bOrder, bPositions := index(b, keys)
+	matched := make(map[occurrence]bool)
+	for i, o := range aOrder {
+		j, ok := bPositions[o]
+		if !ok {
+			*diffs = append(*diffs, Difference{Kind: Removed, Path: path, Type: label, Key: displayKey(o, a.Index(i), keyed)})
+			continue
+		}
+		matched[o] = true
+		if keyed {
+			compare(append(path[:len(path):len(path)], displayKey(o, a.Index(i), keyed)), "", a.Index(i), b.Index(j), diffs)
+		}
+	}
+	for j, o := range bOrder {
+		if !matched[o] {
+			*diffs = append(*diffs, Difference{Kind: Added, Path: path, Type: label, Key: displayKey(o, b.Index(j), keyed)})
+		}
+	}
+}
+
+// displayKey identifies a list entry in a difference.  Entries without a
+// natural key are summarized by their values.
+func displayKey(o occurrence, v reflect.Value, keyed bool) string {
+	k := o.key
+	if !keyed && !isLeaf(v.Type()) {
+		k = summary(v)
+	}
+	if o.n == 0 {
+		return k
+	}
+	return fmt.Sprintf("%s[%d]", k, o.n+1)
+}
+
+// summary describes an entry by its non-empty values in a single line
+func summary(v reflect.Value) string {
+	const maxLength = 80
+	v = indirect(v)
+	if !v.IsValid() || v.Kind() != reflect.Struct {
+		return ""
+	}
+	var values []string
+	for _, f := range fields(v.Type()) {
+		fv := v.Field(f.index)
+		if !isLeaf(fv.Type()) {
+			continue
+		}
+		if s := text(fv); s != "" {
+			values = append(values, f.name+"="+s)
+		}
+	}
+	s := "{" + strings.Join(values, ", ") + "}"
+	if len(s) > maxLength {
+		s = s[:maxLength] + "...}"
+	}
+	return s
+}
@@ -0,0 +1,42 @@
+package semantic_test
+
+import (
+	"reflect"
+	"testing"
+
+	. "github.com/ForceCLI/force-md/general"
+	. "github.com/ForceCLI/force-md/internal/semantic"
+	"github.com/ForceCLI/force-md/metadata/permissionset"
+)
+
+func TestDiff(t *testing.T) {
+	old := &permissionset.PermissionSet{
+		Label: "Sales",
+		FieldPermissions: permissionset.FieldPermissionsList{
+			{Field: "Account.Foo__c", Editable: BooleanText{Text: "true"}, Readable: BooleanText{Text: "true"}},
+			{Field: "Account.Bar__c", Editable: BooleanText{Text: "false"}, Readable: BooleanText{Text: "true"}},
+		},
+	}
+	updated := &permissionset.PermissionSet{
+		Label: "Sales",
+		FieldPermissions: permissionset.FieldPermissionsList{
+			{Field: "Account.Baz__c", Editable: BooleanText{Text: "false"}, Readable: BooleanText{Text: "true"}},
+			{Field: "Account.Bar__c", Editable: BooleanText{Text: "false"}, Readable: BooleanText{Text: "true"}},
+			{Field: "Account.Foo__c", Editable: BooleanText{Text: "false"}, Readable: BooleanText{Text: "true"}},
+		},
+	}
+	var actual []string
+	for _, d := range Diff(old, updated) {
+		actual = append(actual, d.String())
+	}
+	expected := []string{
+		"Account.Foo__c: editable true→false",
+		"FieldPermission Account.Baz__c added",
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+	if diffs := Diff(updated, updated); len(diffs) != 0 {
+		t.Errorf("expected no differences, got %v", diffs)
+	}
+}
@@ -0,0 +1,263 @@
+// Package semantic compares and merges metadata structs, matching the entries
+// in lists by their natural keys rather than by position.
+package semantic
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// The elements that identify an entry in a list, in order of preference.  If
+// the entry has the first element of a set, the values of all the elements in
+// the set that it has are combined to form its key.
+var keyElements = [][]string{
+	{"fullName"},
+	{"name"},
+	{"layout", "recordType"},
+	{"field"},
+	{"object"},
+	{"apexClass"},
+	{"apexPage"},
+	{"application"},
+	{"recordType"},
+	{"tab"},
+	{"flow"},
+	{"externalCredentialPrincipal"},
+	{"picklist"},
+	{"developerName"},
+}
+
+var xmlNameType = reflect.TypeOf(xml.Name{})
+
+// xmlField is a struct field that's serialized as an element or attribute
+type xmlField struct {
+	index int
+	name  string
+	attr  bool
+}
+
+// fieldName returns the name of the element or attribute that a struct field
+// is serialized as, or false if it's not serialized as one
+func fieldName(f reflect.StructField) (xmlField, bool) {
+	if f.Anonymous || !f.IsExported() || f.Type == xmlNameType {
+		return xmlField{}, false
+	}
+	tag := f.Tag.Get("xml")
+	if tag == "-" {
+		return xmlField{}, false
+	}
+	name, opts, _ := strings.Cut(tag, ",")
+	attr := false
+	for _, o := range strings.Split(opts, ",") {
+		switch o {
+		case "chardata", "innerxml", "comment":
+			return xmlField{}, false
+		case "attr":
+			attr = true
+		}
+	}
+	if attr && name == "xmlns" {
+		return xmlField{}, false
+	}
+	if name == "" {
+		name = f.Name
+	}
+	if i := strings.LastIndex(name, " "); i >= 0 {
+		name = name[i+1:]
+	}
+	return xmlField{name: name, attr: attr}, true
+}
+
+func fields(t reflect.Type) []xmlField {
+	var result []xmlField
+	for i := 0; i < t.NumField(); i++ {
+		if f, ok := fieldName(t.Field(i)); ok {
+			f.index = i
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+// textField returns the index of the chardata or innerxml field of a struct
+// that holds an element's text
+func textField(t reflect.Type) (int, bool) {
+	if t.Kind() != reflect.Struct {
+		return 0, false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		tag := t.Field(i).Tag.Get("xml")
+		if strings.HasSuffix(tag, ",chardata") || strings.HasSuffix(tag, ",innerxml") {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+// isLeaf returns whether values of type t are compared as text.  Structs are
+// leaves if they hold an element's text and have no child elements.
+func isLeaf(t reflect.Type) bool {
+	t = indirectType(t)
+	switch t.Kind() {
+	case reflect.Struct:
+		if _, ok := textField(t); !ok {
+			return false
+		}
+		for _, f := range fields(t) {
+			if !f.attr {
+				return false
+			}
+		}
+		return true
+	case reflect.Slice:
+		return t.Elem().Kind() == reflect.Uint8
+	case reflect.Map, reflect.Func, reflect.Chan, reflect.Interface:
+		return false
+	}
+	return true
+}
+
+// text returns the value of a leaf
+func text(v reflect.Value) string {
+	v = indirect(v)
+	if !v.IsValid() {
+		return ""
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		i, _ := textField(v.Type())
+		s := v.Field(i).String()
+		if strings.HasSuffix(v.Type().Field(i).Tag.Get("xml"), ",innerxml") {
+			s = html.UnescapeString(s)
+		}
+		return s
+	case reflect.Slice:
+		return string(v.Bytes())
+	case reflect.String:
+		return v.String()
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+func formatValue(v reflect.Value) string {
+	var b bytes.Buffer
+	enc := xml.NewEncoder(&b)
+	if err := enc.EncodeElement(v.Interface(), xml.StartElement{Name: xml.Name{Local: "v"}}); err != nil {
+		return ""
+	}
+	enc.Flush()
+	return strings.TrimSuffix(strings.TrimPrefix(b.String(), "<v>"), "</v>")
+}
+
+// keyFields returns the fields that make up the natural key of a list entry
+// of type t, if any
+func keyFields(t reflect.Type) []xmlField {
+	t = indirectType(t)
+	if t.Kind() != reflect.Struct || isLeaf(t) {
+		return nil
+	}
+	byName := make(map[string]xmlField)
+	for _, f := range fields(t) {
+		if !f.attr && isLeaf(t.Field(f.index).Type) {
+			byName[f.name] = f
+		}
+	}
+	for _, set := range keyElements {
+		if _, ok := byName[set[0]]; !ok {
+			continue
+		}
+		var key []xmlField
+		for _, name := range set {
+			if f, ok := byName[name]; ok {
+				key = append(key, f)
+			}
+		}
+		return key
+	}
+	return nil
+}
+
+// key returns the natural key of a list entry.  Leaves are identified by their
+// text, and entries without a natural key by their entire contents.
+func key(v reflect.Value, keys []xmlField) string {
+	if isLeaf(v.Type()) {
+		return text(v)
+	}
+	v = indirect(v)
+	if !v.IsValid() {
+		return ""
+	}
+	if len(keys) == 0 {
+		return formatValue(v)
+	}
+	var parts []string
+	for _, f := range keys {
+		parts = append(parts, text(v.Field(f.index)))
+	}
+	return strings.TrimRight(strings.Join(parts, ":"), ":")
+}
+
+// singular returns a label for the entries in a list element, e.g.
+// FieldPermission for fieldPermissions
+func singular(name string) string {
+	switch {
+	case strings.HasSuffix(name, "ies"):
+		name = strings.TrimSuffix(name, "ies") + "y"
+	case strings.HasSuffix(name, "sses"), strings.HasSuffix(name, "ches"),
+		strings.HasSuffix(name, "shes"), strings.HasSuffix(name, "xes"):
+		name = strings.TrimSuffix(name, "es")
+	case strings.HasSuffix(name, "s") && !strings.HasSuffix(name, "ss"):
+		name = strings.TrimSuffix(name, "s")
+	}
+	if name == "" {
+		return name
+	}
+	r := []rune(name)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
+
+// An occurrence identifies an entry in a list by its key and the number of
+// earlier entries with the same key
+type occurrence struct {
+	key string
+	n   int
+}
+
+// index returns the occurrence of each entry in a list and the position of
+// each occurrence
+func index(list reflect.Value, keys []xmlField) ([]occurrence, map[occurrence]int) {
+	seen := make(map[string]int)
+	order := make([]occurrence, list.Len())
+	positions := make(map[occurrence]int)
+	for i := 0; i < list.Len(); i++ {
+		k := key(list.Index(i), keys)
+		o := occurrence{key: k, n: seen[k]}
+		seen[k]++
+		order[i] = o
+		positions[o] = i
+	}
+	return order, positions
+}
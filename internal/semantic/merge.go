@@ -0,0 +1,216 @@
+package semantic
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/ForceCLI/force-md/metadata"
+)
+
+// Merge performs a three-way merge of two values of the same metadata type
+// that were changed from a common base.  List entries are matched by their
+// natural key, so independent additions, removals, and changes are combined.
+//
+// Two results are returned, which are identical unless there are conflicts.
+// Where both sides changed the same value differently, or one side removed
+// an entry that the other changed, the first result has our version and the
+// second has theirs.  If base is nil, the values are merged as if both sides
+// added them.  Elements that were preserved because the metadata type doesn't
+// model them are combined from both sides.
+func Merge(base, ours, theirs any) (any, any, int) {
+	t := reflect.TypeOf(ours)
+	o := reflect.Zero(t)
+	if base != nil {
+		o = reflect.ValueOf(base)
+	}
+	a, b, conflicts := merge(o, reflect.ValueOf(ours), reflect.ValueOf(theirs), t)
+	ra, rb := a.Interface(), b.Interface()
+	if unknown, ok := mergeUnknown(base, ours, theirs); ok {
+		for _, m := range []any{ra, rb} {
+			if s, ok := m.(unknownElementSetter); ok {
+				s.SetUnknownElements(unknown)
+			}
+		}
+	}
+	return ra, rb, conflicts
+}
+
+type unknownElementSetter interface {
+	SetUnknownElements(metadata.UnknownElements)
+}
+
+// mergeUnknown combines the elements that were preserved from both sides.
+// Elements added by either side are kept, and elements removed by either
+// side are removed.
+func mergeUnknown(base, ours, theirs any) (metadata.UnknownElements, bool) {
+	uo, okOurs := ours.(unknownElements)
+	ut, okTheirs := theirs.(unknownElements)
+	if !okOurs || !okTheirs {
+		return nil, false
+	}
+	count := func(elements metadata.UnknownElements) map[string]int {
+		counts := make(map[string]int)
+		for _, e := range elements {
+			counts[unknownKey(e)]++
+		}
+		return counts
+	}
+	var baseCounts map[string]int
+	if ub, ok := base.(unknownElements); ok {
+		baseCounts = count(ub.UnknownElements())
+	}
+	oursCounts, theirsCounts := count(uo.UnknownElements()), count(ut.UnknownElements())
+	remaining := make(map[string]int)
+	for k := range oursCounts {
+		remaining[k] = mergedCount(baseCounts[k], oursCounts[k], theirsCounts[k])
+	}
+	for k := range theirsCounts {
+		remaining[k] = mergedCount(baseCounts[k], oursCounts[k], theirsCounts[k])
+	}
+	var merged metadata.UnknownElements
+	for _, elements := range []metadata.UnknownElements{uo.UnknownElements(), ut.UnknownElements()} {
+		for _, e := range elements {
+			if k := unknownKey(e); remaining[k] > 0 {
+				remaining[k]--
+				merged = append(merged, e)
+			}
+		}
+	}
+	return merged, true
+}
+
+func unknownKey(e metadata.UnknownElement) string {
+	return e.Path() + "\x00" + string(e.Raw)
+}
+
+// mergedCount returns the number of copies of an element to keep
+func mergedCount(base, ours, theirs int) int {
+	switch {
+	case ours == theirs, base == theirs:
+		return ours
+	case base == ours:
+		return theirs
+	}
+	return max(ours, theirs)
+}
+
+func same(a, b reflect.Value) bool {
+	var diffs []Difference
+	compare(nil, "", a, b, &diffs)
+	return len(diffs) == 0
+}
+
+func merge(o, a, b reflect.Value, t reflect.Type) (reflect.Value, reflect.Value, int) {
+	if isLeaf(t) {
+		switch old, ours, theirs := text(o), text(a), text(b); {
+		case ours == theirs, old == theirs:
+			return a, a, 0
+		case old == ours:
+			return b, b, 0
+		}
+		return a, b, 1
+	}
+	switch {
+	case same(a, b), same(o, b):
+		return a, a, 0
+	case same(o, a):
+		return b, b, 0
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		if a.IsNil() || b.IsNil() {
+			return a, b, 1
+		}
+		base := reflect.Zero(t.Elem())
+		if !o.IsNil() {
+			base = o.Elem()
+		}
+		ra, rb, conflicts := merge(base, a.Elem(), b.Elem(), t.Elem())
+		pa, pb := reflect.New(t.Elem()), reflect.New(t.Elem())
+		pa.Elem().Set(ra)
+		pb.Elem().Set(rb)
+		return pa, pb, conflicts
+	case reflect.Slice:
+		return mergeLists(o, a, b, t)
+	case reflect.Struct:
+		ra, rb := reflect.New(t).Elem(), reflect.New(t).Elem()
+		ra.Set(a)
+		rb.Set(a)
+		conflicts := 0
+		if i, ok := textField(t); ok {
+			old := strings.TrimSpace(o.Field(i).String())
+			ours := strings.TrimSpace(a.Field(i).String())
+			theirs := strings.TrimSpace(b.Field(i).String())
+			switch {
+			case ours == theirs, old == theirs:
+			case old == ours:
+				ra.Field(i).Set(b.Field(i))
+				rb.Field(i).Set(b.Field(i))
+			default:
+				rb.Field(i).Set(b.Field(i))
+				conflicts++
+			}
+		}
+		for _, f := range fields(t) {
+			fa, fb, c := merge(o.Field(f.index), a.Field(f.index), b.Field(f.index), t.Field(f.index).Type)
+			ra.Field(f.index).Set(fa)
+			rb.Field(f.index).Set(fb)
+			conflicts += c
+		}
+		return ra, rb, conflicts
+	}
+	return a, b, 1
+}
+
+func mergeLists(o, a, b reflect.Value, t reflect.Type) (reflect.Value, reflect.Value, int) {
+	keys := keyFields(t.Elem())
+	keyed := len(keys) > 0
+	zero := reflect.Zero(t.Elem())
+	_, oPositions := index(o, keys)
+	aOrder, aPositions := index(a, keys)
+	bOrder, bPositions := index(b, keys)
+	ra, rb := reflect.MakeSlice(t, 0, a.Len()), reflect.MakeSlice(t, 0, b.Len())
+	conflicts := 0
+	for i, k := range aOrder {
+		ea := a.Index(i)
+		oi, inBase := oPositions[k]
+		j, inTheirs := bPositions[k]
+		switch {
+		case inTheirs && keyed:
+			base := zero
+			if inBase {
+				base = o.Index(oi)
+			}
+			ma, mb, c := merge(base, ea, b.Index(j), t.Elem())
+			ra = reflect.Append(ra, ma)
+			rb = reflect.Append(rb, mb)
+			conflicts += c
+		case inTheirs, !inBase:
+			// Unchanged, or added by us
+			ra = reflect.Append(ra, ea)
+			rb = reflect.Append(rb, ea)
+		case keyed && !same(o.Index(oi), ea):
+			// Changed by us and removed by them
+			ra = reflect.Append(ra, ea)
+			conflicts++
+		}
+	}
+	for j, k := range bOrder {
+		if _, inOurs := aPositions[k]; inOurs {
+			continue
+		}
+		eb := b.Index(j)
+		oi, inBase := oPositions[k]
+		switch {
+		case !inBase:
+			// Added by them
+			ra = reflect.Append(ra, eb)
+			rb = reflect.Append(rb, eb)
+		case keyed && !same(o.Index(oi), eb):
+			// Removed by us and changed by them
+			rb = reflect.Append(rb, eb)
+			conflicts++
+		}
+	}
+	return ra, rb, conflicts
+}
@@ -0,0 +1,102 @@
+package semantic_test
+
+import (
+	"reflect"
+	"testing"
+
+	. "github.com/ForceCLI/force-md/general"
+	. "github.com/ForceCLI/force-md/internal/semantic"
+	"github.com/ForceCLI/force-md/metadata"
+	"github.com/ForceCLI/force-md/metadata/permissionset"
+)
+
+func fieldPermissions(field string, editable string) permissionset.FieldPermissions {
+	return permissionset.FieldPermissions{
+		Field:    field,
+		Editable: BooleanText{Text: editable},
+		Readable: BooleanText{Text: "true"},
+	}
+}
+
+func TestMerge(t *testing.T) {
+	base := &permissionset.PermissionSet{
+		Label: "Sales",
+		FieldPermissions: permissionset.FieldPermissionsList{
+			fieldPermissions("Account.Foo__c", "false"),
+			fieldPermissions("Account.Bar__c", "false"),
+		},
+	}
+	ours := &permissionset.PermissionSet{
+		Label: "Sales",
+		FieldPermissions: permissionset.FieldPermissionsList{
+			fieldPermissions("Account.Foo__c", "true"),
+			fieldPermissions("Account.Bar__c", "false"),
+			fieldPermissions("Account.Ours__c", "false"),
+		},
+	}
+	theirs := &permissionset.PermissionSet{
+		Label: "Sales Users",
+		FieldPermissions: permissionset.FieldPermissionsList{
+			fieldPermissions("Account.Theirs__c", "false"),
+			fieldPermissions("Account.Foo__c", "false"),
+		},
+	}
+	mergedOurs, mergedTheirs, conflicts := Merge(base, ours, theirs)
+	if conflicts != 0 {
+		t.Errorf("expected no conflicts, got %d", conflicts)
+	}
+	expected := &permissionset.PermissionSet{
+		Label: "Sales Users",
+		FieldPermissions: permissionset.FieldPermissionsList{
+			fieldPermissions("Account.Foo__c", "true"),
+			fieldPermissions("Account.Ours__c", "false"),
+			fieldPermissions("Account.Theirs__c", "false"),
+		},
+	}
+	if !reflect.DeepEqual(mergedOurs, expected) || !reflect.DeepEqual(mergedTheirs, expected) {
+		t.Errorf("expected %v, got %v and %v", expected, mergedOurs, mergedTheirs)
+	}
+
+	theirs.FieldPermissions[1] = fieldPermissions("Account.Foo__c", "")
+	mergedOurs, mergedTheirs, conflicts = Merge(base, ours, theirs)
+	if conflicts != 1 {
+		t.Errorf("expected 1 conflict, got %d", conflicts)
+	}
+	if reflect.DeepEqual(mergedOurs, mergedTheirs) {
+		t.Error("expected conflicting values to differ")
+	}
+}
+
+func TestMergeUnknownElements(t *testing.T) {
+	removed := metadata.UnknownElement{Name: "removed", Raw: []byte("<removed/>")}
+	kept := metadata.UnknownElement{Name: "kept", Raw: []byte("<kept/>")}
+	addedByUs := metadata.UnknownElement{Name: "ours", Raw: []byte("<ours/>")}
+	addedByThem := metadata.UnknownElement{Name: "theirs", After: "label", Raw: []byte("<theirs/>")}
+
+	base := &permissionset.PermissionSet{Label: "Sales"}
+	base.SetUnknownElements(metadata.UnknownElements{removed, kept})
+	ours := &permissionset.PermissionSet{Label: "Sales"}
+	ours.SetUnknownElements(metadata.UnknownElements{removed, kept, addedByUs})
+	theirs := &permissionset.PermissionSet{Label: "Sales Users"}
+	theirs.SetUnknownElements(metadata.UnknownElements{kept, addedByThem})
+
+	mergedOurs, mergedTheirs, conflicts := Merge(base, ours, theirs)
+	if conflicts != 0 {
+		t.Errorf("expected no conflicts, got %d", conflicts)
+	}
+	expected := metadata.UnknownElements{kept, addedByUs, addedByThem}
+	for _, m := range []any{mergedOurs, mergedTheirs} {
+		if unknown := m.(*permissionset.PermissionSet).UnknownElements(); !reflect.DeepEqual(unknown, expected) {
+			t.Errorf("expected %v, got %v", expected, unknown)
+		}
+	}
+}
+
+func TestConflictMarkers(t *testing.T) {
+	ours := "a\nb\nc\nd\n"
+	theirs := "a\nB\nc\nd\ne\n"
+	expected := "a\n<<<<<<< ours\nb\n=======\nB\n>>>>>>> theirs\nc\nd\n<<<<<<< ours\n=======\ne\n>>>>>>> theirs\n"
+	if actual := string(ConflictMarkers([]byte(ours), []byte(theirs))); actual != expected {
+		t.Errorf("expected %q, got %q", expected, actual)
+	}
+}
@@ -0,0 +1,105 @@
+package internal
+
+import (
+	"slices"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/ForceCLI/force-md/metadata"
+)
+
+type unknownElementHolder interface {
+	UnknownElements() metadata.UnknownElements
+}
+
+type insertion struct {
+	offset int64
+	order  int
+	text   []byte
+}
+
+// InsertUnknownElements adds elements that weren't modeled by the metadata
+// type back into the serialized xml.  Nested elements are added to the
+// element with the same content that contained them in the source file.
+// Each element is placed after the last occurrence of the element it
+// followed in the source file.  If that element is no longer present, it's
+// placed in alphabetical order among its parent's children, which matches the
+// schema order of most metadata types.
+func InsertUnknownElements(b []byte, unknown metadata.UnknownElements) []byte {
+	if len(unknown) == 0 {
+		return b
+	}
+	elements, err := metadata.ScanElements(b, nil)
+	if err != nil || len(elements) == 0 {
+		return b
+	}
+
+	var insertions []insertion
+	closed := make(map[int64]bool)
+	for i, u := range unknown {
+		parent, ok := unknownParent(elements, u)
+		if !ok {
+			log.Warnf("could not preserve unrecognized element %s because its parent element changed", u.Path())
+			continue
+		}
+		offset := parent.ContentStart
+		anchored := false
+		if u.After != "" {
+			for _, c := range parent.Children {
+				if c.Name == u.After {
+					offset = c.End
+					anchored = true
+				}
+			}
+		}
+		if !anchored && u.After != "" {
+			for _, c := range parent.Children {
+				if c.Name < u.Name {
+					offset = c.End
+				}
+			}
+		}
+		indent := strings.Repeat("    ", len(u.Parent))
+		text := append([]byte("\n    "+indent), u.Raw...)
+		insertions = append(insertions, insertion{offset: offset, order: i, text: text})
+		if len(parent.Children) == 0 && !closed[parent.Start] {
+			insertions = append(insertions, insertion{offset: parent.ContentStart, order: len(unknown), text: []byte("\n" + indent)})
+			closed[parent.Start] = true
+		}
+	}
+	sort.SliceStable(insertions, func(i, j int) bool {
+		if insertions[i].offset != insertions[j].offset {
+			return insertions[i].offset > insertions[j].offset
+		}
+		return insertions[i].order > insertions[j].order
+	})
+
+	result := append([]byte(nil), b...)
+	for _, ins := range insertions {
+		result = append(result[:ins.offset], append(ins.text, result[ins.offset:]...)...)
+	}
+	return result
+}
+
+// unknownParent returns the element that should contain an unknown element
+func unknownParent(elements []metadata.Element, u metadata.UnknownElement) (metadata.Element, bool) {
+	if len(u.Parent) == 0 {
+		// The root element ends last
+		return elements[len(elements)-1], true
+	}
+	var matches []metadata.Element
+	for _, e := range elements {
+		if slices.Equal(e.Path, u.Parent) && e.Key == u.ParentKey {
+			matches = append(matches, e)
+		}
+	}
+	switch {
+	case len(matches) == 0:
+		return metadata.Element{}, false
+	case u.ParentIndex < len(matches):
+		return matches[u.ParentIndex], true
+	}
+	return matches[len(matches)-1], true
+}
@@ -0,0 +1,134 @@
+package internal_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/ForceCLI/force-md/internal"
+	"github.com/ForceCLI/force-md/metadata"
+	"github.com/ForceCLI/force-md/metadata/profile"
+)
+
+func TestInsertUnknownElements(t *testing.T) {
+	input := `<Profile>
+    <custom>false</custom>
+    <fieldPermissions>
+        <field>Account.Name</field>
+    </fieldPermissions>
+    <userLicense>Salesforce</userLicense>
+</Profile>`
+	tests := []struct {
+		unknown  metadata.UnknownElements
+		expected string
+	}{
+		{
+			unknown: metadata.UnknownElements{
+				{Name: "externalDataSourceAccesses", After: "custom", Raw: []byte(`<externalDataSourceAccesses/>`)},
+			},
+			expected: `<Profile>
+    <custom>false</custom>
+    <externalDataSourceAccesses/>
+    <fieldPermissions>
+        <field>Account.Name</field>
+    </fieldPermissions>
+    <userLicense>Salesforce</userLicense>
+</Profile>`,
+		},
+		{
+			unknown: metadata.UnknownElements{
+				{Name: "aaa", Raw: []byte(`<aaa>1</aaa>`)},
+				{Name: "servicePresenceStatusAccesses", After: "missing", Raw: []byte(`<servicePresenceStatusAccesses/>`)},
+				{Name: "zzz", After: "userLicense", Raw: []byte(`<zzz>1</zzz>`)},
+				{Name: "zzz", After: "userLicense", Raw: []byte(`<zzz>2</zzz>`)},
+			},
+			expected: `<Profile>
+    <aaa>1</aaa>
+    <custom>false</custom>
+    <fieldPermissions>
+        <field>Account.Name</field>
+    </fieldPermissions>
+    <servicePresenceStatusAccesses/>
+    <userLicense>Salesforce</userLicense>
+    <zzz>1</zzz>
+    <zzz>2</zzz>
+</Profile>`,
+		},
+	}
+
+	for _, test := range tests {
+		result := InsertUnknownElements([]byte(input), test.unknown)
+		if string(result) != test.expected {
+			t.Errorf("Expected: %s\nGot: %s", test.expected, result)
+		}
+	}
+}
+
+func TestMarshalNestedUnknownElements(t *testing.T) {
+	input := `<?xml version="1.0" encoding="UTF-8"?>
+<Profile xmlns="http://soap.sforce.com/2006/04/metadata">
+    <custom>false</custom>
+    <fieldPermissions>
+        <editable>true</editable>
+        <field>Account.Name</field>
+        <newThing>1</newThing>
+        <readable>true</readable>
+    </fieldPermissions>
+    <fieldPermissions>
+        <editable>false</editable>
+        <field>Account.Foo__c</field>
+        <readable>true</readable>
+    </fieldPermissions>
+    <userLicense>Salesforce</userLicense>
+</Profile>
+`
+	expected := `<?xml version="1.0" encoding="UTF-8"?>
+<Profile xmlns="http://soap.sforce.com/2006/04/metadata">
+    <custom>false</custom>
+    <fieldPermissions>
+        <editable>false</editable>
+        <field>Account.Foo__c</field>
+        <readable>true</readable>
+    </fieldPermissions>
+    <fieldPermissions>
+        <editable>true</editable>
+        <field>Account.Name</field>
+        <newThing>1</newThing>
+        <readable>true</readable>
+    </fieldPermissions>
+    <userLicense>Salesforce</userLicense>
+</Profile>
+`
+	file := filepath.Join(t.TempDir(), "Admin.profile")
+	if err := os.WriteFile(file, []byte(input), 0644); err != nil {
+		t.Fatal(err)
+	}
+	p, err := profile.Open(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unknown := p.UnknownElements()
+	if len(unknown) != 1 || unknown[0].Path() != "fieldPermissions/newThing" {
+		t.Fatalf("Expected fieldPermissions/newThing to be preserved, got %v", unknown)
+	}
+
+	p.Tidy()
+	result, err := Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(result) != expected {
+		t.Errorf("Expected: %s\nGot: %s", expected, result)
+	}
+
+	// The element can't be kept once the entry that contained it changes
+	p.FieldPermissions[1].Editable.Text = "false"
+	result, err = Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(result), "newThing") {
+		t.Errorf("Expected newThing to be dropped from changed entry, got %s", result)
+	}
+}
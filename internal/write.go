@@ -31,6 +31,9 @@ func Marshal(t interface{}) ([]byte, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "serializing metadata")
 	}
+	if u, ok := t.(unknownElementHolder); ok {
+		m = InsertUnknownElements(m, u.UnknownElements())
+	}
 	m = SelfClosing(m)
 	if ConvertNumericXMLEntities {
 		m = htmlEntities(m)
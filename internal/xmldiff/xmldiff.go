@@ -0,0 +1,289 @@
+// Package xmldiff compares the structure of two xml documents.
+package xmldiff
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/net/html/charset"
+)
+
+type ChangeKind string
+
+const (
+	Lost    ChangeKind = "lost"
+	Added   ChangeKind = "added"
+	Altered ChangeKind = "altered"
+	// Reordered means the elements with the same name under Path are in a
+	// different order
+	Reordered ChangeKind = "reordered"
+)
+
+type Difference struct {
+	Kind ChangeKind
+	Path string
+	Old  string
+	New  string
+}
+
+func (d Difference) String() string {
+	switch d.Kind {
+	case Lost:
+		return fmt.Sprintf("%s: lost %s", d.Path, d.Old)
+	case Added:
+		return fmt.Sprintf("%s: added %s", d.Path, d.New)
+	case Reordered:
+		return fmt.Sprintf("%s: reordered", d.Path)
+	default:
+		return fmt.Sprintf("%s: altered %q → %q", d.Path, d.Old, d.New)
+	}
+}
+
+type node struct {
+	name     string
+	attrs    map[string]string
+	text     string
+	children []*node
+	key      string
+	// unordered is set if the order of the node's siblings with the same
+	// name should be ignored
+	unordered bool
+}
+
+// Compare returns the differences between two xml documents.  Elements with
+// the same name must be in the same order, except for the elements whose
+// paths are listed in unordered, e.g. Profile/fieldPermissions.  The order of
+// elements with different names is ignored, as is whitespace between
+// elements.
+func Compare(original, updated []byte, unordered ...string) ([]Difference, error) {
+	paths := make(map[string]bool)
+	for _, p := range unordered {
+		paths["/"+strings.Trim(p, "/")] = true
+	}
+	a, err := parse(original, paths)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing original")
+	}
+	b, err := parse(updated, paths)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing updated")
+	}
+	var diffs []Difference
+	if a.name != b.name {
+		return append(diffs, Difference{Kind: Altered, Path: "/", Old: a.name, New: b.name}), nil
+	}
+	compareNodes("/"+a.name, a, b, &diffs)
+	return diffs, nil
+}
+
+func parse(b []byte, unordered map[string]bool) (*node, error) {
+	dec := xml.NewDecoder(bytes.NewReader(b))
+	dec.CharsetReader = charset.NewReaderLabel
+	var stack []*node
+	var root *node
+	for {
+		t, err := dec.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch e := t.(type) {
+		case xml.StartElement:
+			n := &node{name: qualifiedName(e.Name), attrs: make(map[string]string)}
+			for _, a := range e.Attr {
+				n.attrs[qualifiedName(a.Name)] = a.Value
+			}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, n)
+			} else if root == nil {
+				root = n
+			}
+			stack = append(stack, n)
+		case xml.EndElement:
+			if len(stack) == 0 {
+				return nil, errors.New("unexpected end element " + e.Name.Local)
+			}
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].text += string(e)
+			}
+		}
+	}
+	if root == nil {
+		return nil, errors.New("no root element")
+	}
+	root.canonicalize("/"+root.name, unordered)
+	return root, nil
+}
+
+func qualifiedName(n xml.Name) string {
+	if n.Space == "" {
+		return n.Local
+	}
+	return n.Space + ":" + n.Local
+}
+
+// canonicalize normalizes whitespace and computes a key for each node that
+// ignores the order of children with different names, and of children with
+// the same name if their path is unordered.
+func (n *node) canonicalize(path string, unordered map[string]bool) {
+	if len(n.children) > 0 && strings.TrimSpace(n.text) == "" {
+		n.text = ""
+	}
+	var b strings.Builder
+	b.WriteString("<" + n.name)
+	for _, k := range sortedKeys(n.attrs) {
+		fmt.Fprintf(&b, " %s=%q", k, n.attrs[k])
+	}
+	b.WriteString(">")
+	b.WriteString(n.text)
+	groups := groupByName(n.children)
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		childPath := path + "/" + name
+		keys := make([]string, len(groups[name]))
+		for i, c := range groups[name] {
+			c.unordered = unordered[childPath]
+			c.canonicalize(childPath, unordered)
+			keys[i] = c.key
+		}
+		if unordered[childPath] {
+			sort.Strings(keys)
+		}
+		b.WriteString(strings.Join(keys, ""))
+	}
+	b.WriteString("</" + n.name + ">")
+	n.key = b.String()
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func compareNodes(path string, a, b *node, diffs *[]Difference) {
+	if a.key == b.key {
+		return
+	}
+	for _, k := range sortedKeys(a.attrs) {
+		if v, ok := b.attrs[k]; !ok {
+			*diffs = append(*diffs, Difference{Kind: Lost, Path: path + "/@" + k, Old: a.attrs[k]})
+		} else if v != a.attrs[k] {
+			*diffs = append(*diffs, Difference{Kind: Altered, Path: path + "/@" + k, Old: a.attrs[k], New: v})
+		}
+	}
+	for _, k := range sortedKeys(b.attrs) {
+		if _, ok := a.attrs[k]; !ok {
+			*diffs = append(*diffs, Difference{Kind: Added, Path: path + "/@" + k, New: b.attrs[k]})
+		}
+	}
+	if a.text != b.text {
+		*diffs = append(*diffs, Difference{Kind: Altered, Path: path, Old: a.text, New: b.text})
+	}
+
+	aByName := groupByName(a.children)
+	bByName := groupByName(b.children)
+	for _, name := range namesOf(a.children, b.children) {
+		compareGroup(path+"/"+name, aByName[name], bByName[name], diffs)
+	}
+}
+
+// compareGroup compares the children of two nodes that have the same name.
+// Unchanged children are removed, then the remaining children are paired up
+// in order.
+func compareGroup(path string, a, b []*node, diffs *[]Difference) {
+	remaining := make(map[string]int)
+	for _, c := range b {
+		remaining[c.key]++
+	}
+	var lost, kept []*node
+	for _, c := range a {
+		if remaining[c.key] > 0 {
+			remaining[c.key]--
+			kept = append(kept, c)
+			continue
+		}
+		lost = append(lost, c)
+	}
+	var added []*node
+	var keptOrder []string
+	for _, c := range b {
+		if remaining[c.key] > 0 {
+			remaining[c.key]--
+			added = append(added, c)
+			continue
+		}
+		keptOrder = append(keptOrder, c.key)
+	}
+
+	if len(kept) > 0 && !kept[0].unordered {
+		for i, c := range kept {
+			if c.key != keptOrder[i] {
+				*diffs = append(*diffs, Difference{Kind: Reordered, Path: path})
+				break
+			}
+		}
+	}
+
+	if len(lost) == len(added) {
+		for i := range lost {
+			compareNodes(path, lost[i], added[i], diffs)
+		}
+		return
+	}
+	for _, c := range lost {
+		*diffs = append(*diffs, Difference{Kind: Lost, Path: path, Old: c.summary()})
+	}
+	for _, c := range added {
+		*diffs = append(*diffs, Difference{Kind: Added, Path: path, New: c.summary()})
+	}
+}
+
+func groupByName(nodes []*node) map[string][]*node {
+	grouped := make(map[string][]*node)
+	for _, n := range nodes {
+		grouped[n.name] = append(grouped[n.name], n)
+	}
+	return grouped
+}
+
+func namesOf(lists ...[]*node) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, l := range lists {
+		for _, n := range l {
+			if !seen[n.name] {
+				seen[n.name] = true
+				names = append(names, n.name)
+			}
+		}
+	}
+	return names
+}
+
+// summary describes an element in a single line for reporting.
+func (n *node) summary() string {
+	const maxLength = 120
+	s := n.key
+	if len(s) > maxLength {
+		s = s[:maxLength] + "..."
+	}
+	return s
+}
@@ -0,0 +1,86 @@
+package xmldiff_test
+
+import (
+	"testing"
+
+	. "github.com/ForceCLI/force-md/internal/xmldiff"
+)
+
+func TestCompare(t *testing.T) {
+	original := `<?xml version="1.0" encoding="UTF-8"?>
+<Layout xmlns="http://soap.sforce.com/2006/04/metadata">
+    <layoutSections>
+        <label>Information</label>
+        <layoutColumns>
+            <layoutItems>
+                <field>Name</field>
+            </layoutItems>
+            <layoutItems>
+                <field>Status__c</field>
+            </layoutItems>
+        </layoutColumns>
+    </layoutSections>
+    <showRunAssignmentRulesCheckbox>false</showRunAssignmentRulesCheckbox>
+</Layout>
+`
+	tests := []struct {
+		name      string
+		updated   string
+		unordered []string
+		expected  []Difference
+	}{
+		{
+			name:    "unchanged except for whitespace and order of different elements",
+			updated: `<Layout xmlns="http://soap.sforce.com/2006/04/metadata"><showRunAssignmentRulesCheckbox>false</showRunAssignmentRulesCheckbox><layoutSections><layoutColumns><layoutItems><field>Name</field></layoutItems><layoutItems><field>Status__c</field></layoutItems></layoutColumns><label>Information</label></layoutSections></Layout>`,
+		},
+		{
+			name:    "reordered",
+			updated: `<Layout xmlns="http://soap.sforce.com/2006/04/metadata"><layoutSections><label>Information</label><layoutColumns><layoutItems><field>Status__c</field></layoutItems><layoutItems><field>Name</field></layoutItems></layoutColumns></layoutSections><showRunAssignmentRulesCheckbox>false</showRunAssignmentRulesCheckbox></Layout>`,
+			expected: []Difference{
+				{Kind: Reordered, Path: "/Layout/layoutSections/layoutColumns/layoutItems"},
+			},
+		},
+		{
+			name:      "reordered in unordered list",
+			updated:   `<Layout xmlns="http://soap.sforce.com/2006/04/metadata"><layoutSections><label>Information</label><layoutColumns><layoutItems><field>Status__c</field></layoutItems><layoutItems><field>Name</field></layoutItems></layoutColumns></layoutSections><showRunAssignmentRulesCheckbox>false</showRunAssignmentRulesCheckbox></Layout>`,
+			unordered: []string{"Layout/layoutSections/layoutColumns/layoutItems"},
+		},
+		{
+			name:    "lost",
+			updated: `<Layout xmlns="http://soap.sforce.com/2006/04/metadata"><layoutSections><label>Information</label><layoutColumns><layoutItems><field>Name</field></layoutItems><layoutItems><field>Status__c</field></layoutItems></layoutColumns></layoutSections></Layout>`,
+			expected: []Difference{
+				{Kind: Lost, Path: "/Layout/showRunAssignmentRulesCheckbox", Old: "<showRunAssignmentRulesCheckbox>false</showRunAssignmentRulesCheckbox>"},
+			},
+		},
+		{
+			name:    "altered",
+			updated: `<Layout xmlns="http://soap.sforce.com/2006/04/metadata"><layoutSections><label>Details</label><layoutColumns><layoutItems><field>Name</field></layoutItems><layoutItems><field>Status__c</field></layoutItems></layoutColumns></layoutSections><showRunAssignmentRulesCheckbox>false</showRunAssignmentRulesCheckbox></Layout>`,
+			expected: []Difference{
+				{Kind: Altered, Path: "/Layout/layoutSections/label", Old: "Information", New: "Details"},
+			},
+		},
+		{
+			name:    "attribute lost",
+			updated: `<Layout><layoutSections><label>Information</label><layoutColumns><layoutItems><field>Name</field></layoutItems><layoutItems><field>Status__c</field></layoutItems></layoutColumns></layoutSections><showRunAssignmentRulesCheckbox>false</showRunAssignmentRulesCheckbox></Layout>`,
+			expected: []Difference{
+				{Kind: Lost, Path: "/Layout/@xmlns", Old: "http://soap.sforce.com/2006/04/metadata"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		diffs, err := Compare([]byte(original), []byte(test.updated), test.unordered...)
+		if err != nil {
+			t.Fatalf("%s: %s", test.name, err.Error())
+		}
+		if len(diffs) != len(test.expected) {
+			t.Errorf("%s: expected %v, got %v", test.name, test.expected, diffs)
+			continue
+		}
+		for i := range diffs {
+			if diffs[i] != test.expected[i] {
+				t.Errorf("%s: expected %v, got %v", test.name, test.expected[i], diffs[i])
+			}
+		}
+	}
+}
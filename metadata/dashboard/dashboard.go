@@ -598,6 +598,10 @@ func (c *Dashboard) SetMetadata(m metadata.MetadataInfo) {
 	c.MetadataInfo = m
 }
 
+func (c *Dashboard) NameFromPath(path string) metadata.MetadataObjectName {
+	return metadata.FolderNameFromPath(path, "dashboards")
+}
+
 func (c *Dashboard) Type() metadata.MetadataType {
 	return NAME
 }
@@ -37,6 +37,10 @@ func (c *DashboardFolder) SetMetadata(m metadata.MetadataInfo) {
 	c.MetadataInfo = m
 }
 
+func (c *DashboardFolder) NameFromPath(path string) metadata.MetadataObjectName {
+	return metadata.FolderNameFromPath(path, "dashboards")
+}
+
 func (c *DashboardFolder) Type() metadata.MetadataType {
 	return NAME
 }
@@ -32,6 +32,10 @@ func (c *DocumentFolder) SetMetadata(m metadata.MetadataInfo) {
 	c.MetadataInfo = m
 }
 
+func (c *DocumentFolder) NameFromPath(path string) metadata.MetadataObjectName {
+	return metadata.FolderNameFromPath(path, "documents")
+}
+
 func (c *DocumentFolder) Type() metadata.MetadataType {
 	return NAME
 }
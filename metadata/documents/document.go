@@ -38,6 +38,10 @@ func (c *Document) SetMetadata(m metadata.MetadataInfo) {
 	c.MetadataInfo = m
 }
 
+func (c *Document) NameFromPath(path string) metadata.MetadataObjectName {
+	return metadata.FolderNameFromPath(path, "documents")
+}
+
 func (c *Document) Type() metadata.MetadataType {
 	return NAME
 }
@@ -34,6 +34,10 @@ func (c *EmailFolder) SetMetadata(m metadata.MetadataInfo) {
 	c.MetadataInfo = m
 }
 
+func (c *EmailFolder) NameFromPath(path string) metadata.MetadataObjectName {
+	return metadata.FolderNameFromPath(path, "email")
+}
+
 func (c *EmailFolder) Type() metadata.MetadataType {
 	return NAME
 }
@@ -53,6 +53,10 @@ func (c *EmailTemplate) SetMetadata(m metadata.MetadataInfo) {
 	c.MetadataInfo = m
 }
 
+func (c *EmailTemplate) NameFromPath(path string) metadata.MetadataObjectName {
+	return metadata.FolderNameFromPath(path, "email")
+}
+
 func (c *EmailTemplate) Type() metadata.MetadataType {
 	return NAME
 }
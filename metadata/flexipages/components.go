@@ -0,0 +1,112 @@
+package flexipage
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var ComponentNotFoundError = errors.New("component not found")
+
+type ComponentLocation struct {
+	Region     string
+	Name       string
+	Identifier string
+	Visibility *VisibilityRule
+	Instance   *ComponentInstance
+}
+
+// Components returns the component instances in each region
+func (p *FlexiPage) Components() []ComponentLocation {
+	var components []ComponentLocation
+	for r := range p.FlexiPageRegions {
+		region := &p.FlexiPageRegions[r]
+		for i := range region.ItemInstances {
+			c := region.ItemInstances[i].ComponentInstance
+			if c == nil {
+				continue
+			}
+			components = append(components, ComponentLocation{
+				Region:     region.Name.String(),
+				Name:       c.ComponentName.String(),
+				Identifier: c.Identifier.String(),
+				Visibility: c.VisibilityRule,
+				Instance:   c,
+			})
+		}
+	}
+	return components
+}
+
+// SameComponent returns whether two component names are the same, ignoring
+// the default c namespace
+func SameComponent(a, b string) bool {
+	return strings.EqualFold(strings.TrimPrefix(a, "c:"), strings.TrimPrefix(b, "c:"))
+}
+
+// RemoveComponent removes all instances of a component.  Facets that hold
+// the content of removed components, such as the columns of a field section,
+// are removed too.  It returns the number of instances removed.
+func (p *FlexiPage) RemoveComponent(name string) (int, error) {
+	count := 0
+	var facets []string
+	for r := range p.FlexiPageRegions {
+		region := &p.FlexiPageRegions[r]
+		items := region.ItemInstances[:0]
+		for _, i := range region.ItemInstances {
+			if i.ComponentInstance != nil && SameComponent(i.ComponentInstance.ComponentName.String(), name) {
+				count++
+				facets = append(facets, p.facetsOf(i.ComponentInstance)...)
+				continue
+			}
+			items = append(items, i)
+		}
+		region.ItemInstances = items
+	}
+	if count == 0 {
+		return 0, ComponentNotFoundError
+	}
+	for len(facets) > 0 {
+		facet := facets[0]
+		facets = facets[1:]
+		for r, region := range p.FlexiPageRegions {
+			if region.Name.String() != facet {
+				continue
+			}
+			for _, i := range region.ItemInstances {
+				if i.ComponentInstance != nil {
+					facets = append(facets, p.facetsOf(i.ComponentInstance)...)
+				}
+			}
+			p.FlexiPageRegions = append(p.FlexiPageRegions[:r], p.FlexiPageRegions[r+1:]...)
+			break
+		}
+	}
+	return count, nil
+}
+
+// facetsOf returns the names of the facet regions referenced by a
+// component's properties
+func (p *FlexiPage) facetsOf(c *ComponentInstance) []string {
+	regions := make(map[string]bool)
+	for _, r := range p.FlexiPageRegions {
+		if r.Type.String() == "Facet" {
+			regions[r.Name.String()] = true
+		}
+	}
+	var facets []string
+	for _, prop := range c.ComponentInstanceProperties {
+		if v := prop.Value.String(); regions[v] {
+			facets = append(facets, v)
+		}
+		if prop.ValueList == nil {
+			continue
+		}
+		for _, item := range prop.ValueList.ValueListItems {
+			if v := item.Value.String(); regions[v] {
+				facets = append(facets, v)
+			}
+		}
+	}
+	return facets
+}
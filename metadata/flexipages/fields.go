@@ -0,0 +1,158 @@
+package flexipage
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	. "github.com/ForceCLI/force-md/general"
+)
+
+var FieldExistsError = errors.New("field already exists")
+var FieldNotFoundError = errors.New("field not found")
+
+// Behaviors are the values of the uiBehavior property of field instances
+var Behaviors = []string{"none", "readonly", "required"}
+
+type FieldLocation struct {
+	Region     string
+	Field      string
+	Identifier string
+	Behavior   string
+	Visibility *VisibilityRule
+}
+
+// Fields returns the field instances in each region.  Field items are
+// prefixed with Record, e.g. Record.Name.
+func (p *FlexiPage) Fields() []FieldLocation {
+	var fields []FieldLocation
+	for _, region := range p.FlexiPageRegions {
+		for _, i := range region.ItemInstances {
+			f := i.FieldInstance
+			if f == nil {
+				continue
+			}
+			location := FieldLocation{
+				Region:     region.Name.String(),
+				Field:      f.FieldItem.String(),
+				Identifier: f.Identifier.String(),
+				Visibility: f.VisibilityRule,
+			}
+			for _, prop := range f.FieldInstanceProperties {
+				if prop.Name.String() == "uiBehavior" {
+					location.Behavior = prop.Value.String()
+				}
+			}
+			fields = append(fields, location)
+		}
+	}
+	return fields
+}
+
+func fieldItem(field string) string {
+	if strings.HasPrefix(field, "Record.") {
+		return field
+	}
+	return "Record." + field
+}
+
+func sameField(a, b string) bool {
+	return strings.EqualFold(fieldItem(a), fieldItem(b))
+}
+
+// AddField adds a field to a region, usually a facet holding a column of a
+// field section.  The field is added after the field named by after, or at
+// the end of the region if after is empty.
+func (p *FlexiPage) AddField(region, field, behavior, after string) error {
+	for _, f := range p.Fields() {
+		if sameField(f.Field, field) {
+			return FieldExistsError
+		}
+	}
+	valid := false
+	for _, b := range Behaviors {
+		valid = valid || b == behavior
+	}
+	if !valid {
+		return errors.New("invalid behavior: " + behavior)
+	}
+	var r *FlexiPageRegion
+	for i := range p.FlexiPageRegions {
+		if p.FlexiPageRegions[i].Name.String() == region {
+			r = &p.FlexiPageRegions[i]
+		}
+	}
+	if r == nil {
+		return errors.New("region not found: " + region)
+	}
+	position := len(r.ItemInstances)
+	if after != "" {
+		position = -1
+		for i, item := range r.ItemInstances {
+			if item.FieldInstance != nil && sameField(item.FieldInstance.FieldItem.String(), after) {
+				position = i + 1
+			}
+		}
+		if position < 0 {
+			return errors.New("field not found in region: " + after)
+		}
+	}
+	f := &FieldInstance{
+		FieldItem:  TextLiteral{Text: fieldItem(field)},
+		Identifier: &TextLiteral{Text: p.uniqueIdentifier(fieldIdentifier(fieldItem(field)))},
+	}
+	f.FieldInstanceProperties = append(f.FieldInstanceProperties, struct {
+		Name  TextLiteral  `xml:"name"`
+		Value *TextLiteral `xml:"value"`
+	}{Name: TextLiteral{Text: "uiBehavior"}, Value: &TextLiteral{Text: behavior}})
+	item := ItemInstance{FieldInstance: f}
+	r.ItemInstances = append(r.ItemInstances[:position], append([]ItemInstance{item}, r.ItemInstances[position:]...)...)
+	return nil
+}
+
+// RemoveField removes a field from all regions
+func (p *FlexiPage) RemoveField(field string) error {
+	found := false
+	for r := range p.FlexiPageRegions {
+		region := &p.FlexiPageRegions[r]
+		items := region.ItemInstances[:0]
+		for _, i := range region.ItemInstances {
+			if i.FieldInstance != nil && sameField(i.FieldInstance.FieldItem.String(), field) {
+				found = true
+				continue
+			}
+			items = append(items, i)
+		}
+		region.ItemInstances = items
+	}
+	if !found {
+		return FieldNotFoundError
+	}
+	return nil
+}
+
+// fieldIdentifier follows App Builder's identifiers, e.g. RecordStatus_cField
+// for Record.Status__c
+func fieldIdentifier(item string) string {
+	return strings.NewReplacer(".", "", "__", "_").Replace(item) + "Field"
+}
+
+func (p *FlexiPage) uniqueIdentifier(identifier string) string {
+	used := make(map[string]bool)
+	for _, region := range p.FlexiPageRegions {
+		for _, i := range region.ItemInstances {
+			if i.FieldInstance != nil {
+				used[i.FieldInstance.Identifier.String()] = true
+			}
+			if i.ComponentInstance != nil {
+				used[i.ComponentInstance.Identifier.String()] = true
+			}
+		}
+	}
+	unique := identifier
+	for n := 2; used[unique]; n++ {
+		unique = fmt.Sprintf("%s%d", identifier, n)
+	}
+	return unique
+}
@@ -3,6 +3,7 @@ package flexipage
 import (
 	"encoding/xml"
 
+	. "github.com/ForceCLI/force-md/general"
 	"github.com/ForceCLI/force-md/internal"
 	"github.com/ForceCLI/force-md/metadata"
 )
@@ -13,141 +14,80 @@ func init() {
 	internal.TypeRegistry.Register(NAME, func(path string) (metadata.RegisterableMetadata, error) { return Open(path) })
 }
 
+type Criterion struct {
+	LeftValue  TextLiteral  `xml:"leftValue"`
+	Operator   TextLiteral  `xml:"operator"`
+	RightValue *TextLiteral `xml:"rightValue"`
+}
+
+type VisibilityRule struct {
+	BooleanFilter *TextLiteral `xml:"booleanFilter"`
+	Criteria      []Criterion  `xml:"criteria"`
+}
+
+type ComponentInstanceProperty struct {
+	Name      TextLiteral  `xml:"name"`
+	Type      *TextLiteral `xml:"type"`
+	Value     *TextLiteral `xml:"value"`
+	ValueList *struct {
+		ValueListItems []struct {
+			Value          TextLiteral     `xml:"value"`
+			VisibilityRule *VisibilityRule `xml:"visibilityRule"`
+		} `xml:"valueListItems"`
+	} `xml:"valueList"`
+}
+
+type ComponentInstance struct {
+	ComponentInstanceProperties []ComponentInstanceProperty `xml:"componentInstanceProperties"`
+	ComponentName               TextLiteral                 `xml:"componentName"`
+	Identifier                  *TextLiteral                `xml:"identifier"`
+	VisibilityRule              *VisibilityRule             `xml:"visibilityRule"`
+}
+
+type FieldInstance struct {
+	FieldInstanceProperties []struct {
+		Name  TextLiteral  `xml:"name"`
+		Value *TextLiteral `xml:"value"`
+	} `xml:"fieldInstanceProperties"`
+	FieldItem      TextLiteral     `xml:"fieldItem"`
+	Identifier     *TextLiteral    `xml:"identifier"`
+	VisibilityRule *VisibilityRule `xml:"visibilityRule"`
+}
+
+type ItemInstance struct {
+	ComponentInstance *ComponentInstance `xml:"componentInstance"`
+	FieldInstance     *FieldInstance     `xml:"fieldInstance"`
+}
+
+type FlexiPageRegion struct {
+	Appendable    *TextLiteral   `xml:"appendable"`
+	ItemInstances []ItemInstance `xml:"itemInstances"`
+	Mode          *TextLiteral   `xml:"mode"`
+	Name          TextLiteral    `xml:"name"`
+	Prependable   *TextLiteral   `xml:"prependable"`
+	Replaceable   *TextLiteral   `xml:"replaceable"`
+	Type          TextLiteral    `xml:"type"`
+}
+
 type FlexiPage struct {
 	metadata.MetadataInfo
-	XMLName          xml.Name `xml:"FlexiPage"`
-	Xmlns            string   `xml:"xmlns,attr"`
-	FlexiPageRegions []struct {
-		ItemInstances []struct {
-			ComponentInstance struct {
-				ComponentInstanceProperties []struct {
-					Name struct {
-						Text string `xml:",chardata"`
-					} `xml:"name"`
-					ValueList struct {
-						ValueListItems []struct {
-							Value struct {
-								Text string `xml:",chardata"`
-							} `xml:"value"`
-							VisibilityRule struct {
-								BooleanFilter struct {
-									Text string `xml:",chardata"`
-								} `xml:"booleanFilter"`
-								Criteria []struct {
-									LeftValue struct {
-										Text string `xml:",chardata"`
-									} `xml:"leftValue"`
-									Operator struct {
-										Text string `xml:",chardata"`
-									} `xml:"operator"`
-									RightValue struct {
-										Text string `xml:",chardata"`
-									} `xml:"rightValue"`
-								} `xml:"criteria"`
-							} `xml:"visibilityRule"`
-						} `xml:"valueListItems"`
-					} `xml:"valueList"`
-					Value struct {
-						Text string `xml:",chardata"`
-					} `xml:"value"`
-					Type struct {
-						Text string `xml:",chardata"`
-					} `xml:"type"`
-				} `xml:"componentInstanceProperties"`
-				ComponentName struct {
-					Text string `xml:",chardata"`
-				} `xml:"componentName"`
-				Identifier struct {
-					Text string `xml:",chardata"`
-				} `xml:"identifier"`
-				VisibilityRule struct {
-					Criteria []struct {
-						LeftValue struct {
-							Text string `xml:",chardata"`
-						} `xml:"leftValue"`
-						Operator struct {
-							Text string `xml:",chardata"`
-						} `xml:"operator"`
-						RightValue struct {
-							Text string `xml:",chardata"`
-						} `xml:"rightValue"`
-					} `xml:"criteria"`
-					BooleanFilter struct {
-						Text string `xml:",chardata"`
-					} `xml:"booleanFilter"`
-				} `xml:"visibilityRule"`
-			} `xml:"componentInstance"`
-			FieldInstance struct {
-				FieldInstanceProperties struct {
-					Name struct {
-						Text string `xml:",chardata"`
-					} `xml:"name"`
-					Value struct {
-						Text string `xml:",chardata"`
-					} `xml:"value"`
-				} `xml:"fieldInstanceProperties"`
-				FieldItem struct {
-					Text string `xml:",chardata"`
-				} `xml:"fieldItem"`
-				Identifier struct {
-					Text string `xml:",chardata"`
-				} `xml:"identifier"`
-				VisibilityRule struct {
-					Criteria []struct {
-						LeftValue struct {
-							Text string `xml:",chardata"`
-						} `xml:"leftValue"`
-						Operator struct {
-							Text string `xml:",chardata"`
-						} `xml:"operator"`
-						RightValue struct {
-							Text string `xml:",chardata"`
-						} `xml:"rightValue"`
-					} `xml:"criteria"`
-					BooleanFilter struct {
-						Text string `xml:",chardata"`
-					} `xml:"booleanFilter"`
-				} `xml:"visibilityRule"`
-			} `xml:"fieldInstance"`
-		} `xml:"itemInstances"`
-		Name struct {
-			Text string `xml:",chardata"`
-		} `xml:"name"`
-		Type struct {
-			Text string `xml:",chardata"`
-		} `xml:"type"`
-		Mode struct {
-			Text string `xml:",chardata"`
-		} `xml:"mode"`
-	} `xml:"flexiPageRegions"`
-	MasterLabel struct {
-		Text string `xml:",chardata"`
-	} `xml:"masterLabel"`
-	SobjectType struct {
-		Text string `xml:",chardata"`
-	} `xml:"sobjectType"`
-	Template struct {
-		Name struct {
-			Text string `xml:",chardata"`
-		} `xml:"name"`
-		Properties struct {
-			Name struct {
-				Text string `xml:",chardata"`
-			} `xml:"name"`
-			Value struct {
-				Text string `xml:",chardata"`
-			} `xml:"value"`
+	XMLName            xml.Name          `xml:"FlexiPage"`
+	Xmlns              string            `xml:"xmlns,attr"`
+	Description        *TextLiteral      `xml:"description"`
+	FlexiPageRegions   []FlexiPageRegion `xml:"flexiPageRegions"`
+	MasterLabel        TextLiteral       `xml:"masterLabel"`
+	ParentFlexiPage    *TextLiteral      `xml:"parentFlexiPage"`
+	PlatformActionList *TextLiteral      `xml:"platformActionList"`
+	QuickActionList    *TextLiteral      `xml:"quickActionList"`
+	SobjectType        *TextLiteral      `xml:"sobjectType"`
+	Template           struct {
+		Name       TextLiteral `xml:"name"`
+		Properties []struct {
+			Name  TextLiteral  `xml:"name"`
+			Value *TextLiteral `xml:"value"`
 		} `xml:"properties"`
 	} `xml:"template"`
-	FlexiPageType struct {
-		Text string `xml:",chardata"`
-	} `xml:"type"`
-	ParentFlexiPage struct {
-		Text string `xml:",chardata"`
-	} `xml:"parentFlexiPage"`
-	Description struct {
-		Text string `xml:",chardata"`
-	} `xml:"description"`
+	FlexiPageType TextLiteral `xml:"type"`
 }
 
 func (c *FlexiPage) SetMetadata(m metadata.MetadataInfo) {
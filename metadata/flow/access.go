@@ -0,0 +1,202 @@
+package flow
+
+import (
+	"strings"
+)
+
+type Operation string
+
+const (
+	Read   Operation = "read"
+	Create Operation = "create"
+	Update Operation = "update"
+	Delete Operation = "delete"
+)
+
+// Access is an object and fields read or written by a flow element
+type Access struct {
+	Element   ElementName
+	Operation Operation
+	// The object, if it can be determined
+	Object string
+	Fields []string
+}
+
+// DataAccess returns the objects and fields that the flow's elements read and
+// write.  Fields of the triggering record used in the entry criteria or
+// referenced through $Record are read by the start element.  Assignments to
+// fields of $Record in before-save flows are updates of the triggering
+// object.
+func (f *Flow) DataAccess() []Access {
+	var access []Access
+	add := func(element ElementName, op Operation, object string, fields ...string) {
+		access = append(access, Access{Element: element, Operation: op, Object: object, Fields: unique(fields)})
+	}
+	if object := f.TriggerObject(); object != "" {
+		add("start", Read, object, f.recordFields()...)
+	}
+	for _, e := range f.RecordLookups {
+		var fields []string
+		for _, q := range e.QueriedFields {
+			fields = append(fields, q.Text)
+		}
+		for _, o := range e.OutputAssignments {
+			fields = append(fields, o.Field.Text)
+		}
+		for _, filter := range e.Filters {
+			fields = append(fields, filter.Field)
+		}
+		if e.SortField != nil {
+			fields = append(fields, e.SortField.Text)
+		}
+		add(e.Name, Read, e.Object, fields...)
+	}
+	for _, e := range f.RecordCreates {
+		var fields []string
+		for _, a := range e.InputAssignments {
+			fields = append(fields, a.Field.Text)
+		}
+		object := ""
+		if e.Object != nil {
+			object = e.Object.Text
+		} else if e.InputReference != nil {
+			object = f.ObjectOf(e.InputReference.Text)
+		}
+		add(ElementName(e.Name.Text), Create, object, fields...)
+	}
+	for _, e := range f.RecordUpdates {
+		var fields []string
+		for _, a := range e.InputAssignments {
+			fields = append(fields, a.Field.Text)
+		}
+		object := ""
+		if e.Object != nil {
+			object = e.Object.Text
+		} else if e.InputReference != nil {
+			object = f.ObjectOf(e.InputReference.Text)
+		}
+		add(ElementName(e.Name.Text), Update, object, fields...)
+	}
+	for _, e := range f.RecordDeletes {
+		object := ""
+		if e.Object != nil {
+			object = *e.Object
+		} else if e.InputReference != nil {
+			object = f.ObjectOf(e.InputReference.Text)
+		}
+		add(e.Name, Delete, object)
+	}
+	if f.Start != nil && f.Start.TriggerType != nil && *f.Start.TriggerType == "RecordBeforeSave" {
+		for _, e := range f.Assignments {
+			var fields []string
+			for _, a := range e.AssignmentItems {
+				if field, ok := strings.CutPrefix(a.AssignToReference, "$Record."); ok && !strings.Contains(field, ".") {
+					fields = append(fields, field)
+				}
+			}
+			if len(fields) > 0 {
+				add(e.Name, Update, f.TriggerObject(), fields...)
+			}
+		}
+	}
+	return access
+}
+
+// TriggerObject returns the object of a record-triggered flow, if any
+func (f *Flow) TriggerObject() string {
+	if f.Start == nil || f.Start.Object == nil {
+		return ""
+	}
+	return *f.Start.Object
+}
+
+// ObjectOf returns the object type of a record or record collection
+// reference: $Record, a record variable, the output of a Get Records
+// element, or the current item of a loop over one of them
+func (f *Flow) ObjectOf(reference string) string {
+	return f.objectOf(reference, make(map[string]bool))
+}
+
+func (f *Flow) objectOf(reference string, visited map[string]bool) string {
+	if reference == "$Record" || reference == "$Record__Prior" {
+		return f.TriggerObject()
+	}
+	for _, v := range f.Variables {
+		if v.Name == reference && v.ObjectType != nil {
+			return v.ObjectType.Text
+		}
+	}
+	for _, l := range f.RecordLookups {
+		if string(l.Name) == reference {
+			return l.Object
+		}
+	}
+	for _, l := range f.Loops {
+		if l.Name.Text == reference && !visited[reference] {
+			visited[reference] = true
+			return f.objectOf(l.CollectionReference.Text, visited)
+		}
+	}
+	return ""
+}
+
+// recordFields returns the fields of the triggering record used in the entry
+// criteria or referenced through $Record or $Record__Prior
+func (f *Flow) recordFields() []string {
+	var fields []string
+	for _, filter := range f.Start.Filters {
+		fields = append(fields, filter.Field.Text)
+	}
+	addReference := func(ref string) {
+		for _, prefix := range []string{"$Record.", "$Record__Prior."} {
+			if field, ok := strings.CutPrefix(ref, prefix); ok && !strings.Contains(field, ".") {
+				fields = append(fields, field)
+			}
+		}
+	}
+	addValue := func(v *Value) {
+		if v != nil && v.ElementReference != nil {
+			addReference(v.ElementReference.Text)
+		}
+	}
+	for _, d := range f.Decisions {
+		for _, r := range d.Rules {
+			for _, c := range r.Conditions {
+				addReference(c.LeftValueReference)
+				addValue(c.RightValue)
+			}
+		}
+	}
+	for _, a := range f.Assignments {
+		for _, item := range a.AssignmentItems {
+			addValue(item.Value)
+		}
+	}
+	for _, l := range f.RecordLookups {
+		for _, filter := range l.Filters {
+			addValue(filter.Value)
+		}
+	}
+	for _, u := range f.RecordUpdates {
+		for _, filter := range u.Filters {
+			addValue(filter.Value)
+		}
+		for _, a := range u.InputAssignments {
+			addValue(a.Value)
+		}
+	}
+	return fields
+}
+
+func unique(values []string) []string {
+	seen := make(map[string]bool)
+	var result []string
+	for _, v := range values {
+		if v == "" || seen[v] {
+			continue
+		}
+		seen[v] = true
+		result = append(result, v)
+	}
+	return result
+}
@@ -0,0 +1,142 @@
+package flow
+
+// Connector is a path from one element to another
+type Connector struct {
+	Target ElementName
+	// The decision outcome, scheduled path, or loop path, if any
+	Label string
+	Fault bool
+}
+
+// Element is a node in a flow, e.g. a decision or record update
+type Element struct {
+	Type       string
+	Name       ElementName
+	Label      string
+	Connectors []Connector
+}
+
+// Elements returns the elements of the flow in the order they appear in the
+// metadata
+func (f *Flow) Elements() []Element {
+	var elements []Element
+	add := func(t string, name ElementName, label string, connectors ...Connector) {
+		var valid []Connector
+		for _, c := range connectors {
+			if c.Target != "" {
+				valid = append(valid, c)
+			}
+		}
+		elements = append(elements, Element{Type: t, Name: name, Label: label, Connectors: valid})
+	}
+	for _, e := range f.ActionCalls {
+		add("ActionCall", e.Name, e.Label.Text, next(e.Connector), fault(e.FaultConnector))
+	}
+	for _, e := range f.Assignments {
+		add("Assignment", e.Name, e.Label.Text, next(e.Connector))
+	}
+	for _, e := range f.CollectionProcessors {
+		add("CollectionProcessor", ElementName(e.Name.Text), e.Label.Text, Connector{Target: e.Connector.TargetReference})
+	}
+	for _, e := range f.CustomErrors {
+		add("CustomError", ElementName(e.Name.Text), e.Label.Text)
+	}
+	for _, e := range f.Decisions {
+		var connectors []Connector
+		for _, r := range e.Rules {
+			if r.Connector != nil {
+				connectors = append(connectors, Connector{Target: r.Connector.TargetReference, Label: r.Label.Text})
+			}
+		}
+		if e.DefaultConnector != nil {
+			connectors = append(connectors, Connector{Target: e.DefaultConnector.TargetReference, Label: e.DefaultConnectorLabel.Text})
+		}
+		add("Decision", e.Name, e.Label.Text, connectors...)
+	}
+	for _, e := range f.Loops {
+		connectors := []Connector{{Target: e.NextValueConnector.TargetReference, Label: "For Each"}}
+		if e.NoMoreValuesConnector != nil {
+			connectors = append(connectors, Connector{Target: e.NoMoreValuesConnector.TargetReference, Label: "After Last"})
+		}
+		add("Loop", ElementName(e.Name.Text), e.Label.Text, connectors...)
+	}
+	for _, e := range f.RecordCreates {
+		add("RecordCreate", ElementName(e.Name.Text), e.Label.Text, next(e.Connector), fault(e.FaultConnector))
+	}
+	for _, e := range f.RecordDeletes {
+		add("RecordDelete", e.Name, e.Label.Text, next(e.Connector), fault(e.FaultConnector))
+	}
+	for _, e := range f.RecordLookups {
+		add("RecordLookup", e.Name, e.Label.Text, Connector{Target: e.Connector.TargetReference}, fault(e.FaultConnector))
+	}
+	for _, e := range f.RecordRollbacks {
+		add("RecordRollback", ElementName(e.Name.Text), e.Label.Text, next(e.Connector))
+	}
+	for _, e := range f.RecordUpdates {
+		add("RecordUpdate", ElementName(e.Name.Text), e.Label.Text, next(e.Connector), fault(e.FaultConnector))
+	}
+	for _, e := range f.Screens {
+		add("Screen", e.Name, e.Label.Text, next(e.Connector))
+	}
+	for _, e := range f.Subflows {
+		add("Subflow", ElementName(e.Name.Text), e.Label.Text, next(e.Connector))
+	}
+	for _, e := range f.Waits {
+		add("Wait", ElementName(e.Name.Text), e.Label.Text, Connector{Target: e.WaitEvents.Connector.TargetReference, Label: e.WaitEvents.Label.Text})
+	}
+	return elements
+}
+
+// StartConnectors returns the connectors from the start of the flow,
+// including the connectors of scheduled paths
+func (f *Flow) StartConnectors() []Connector {
+	var connectors []Connector
+	if f.StartElementReference != nil && f.StartElementReference.Text != "" {
+		connectors = append(connectors, Connector{Target: ElementName(f.StartElementReference.Text)})
+	}
+	if f.Start == nil {
+		return connectors
+	}
+	if f.Start.Connector != nil && f.Start.Connector.TargetReference != "" {
+		connectors = append(connectors, Connector{Target: f.Start.Connector.TargetReference})
+	}
+	for _, p := range f.Start.ScheduledPaths {
+		label := ""
+		if p.Label != nil {
+			label = p.Label.Text
+		}
+		connectors = append(connectors, Connector{Target: p.Connector.TargetReference, Label: label})
+	}
+	return connectors
+}
+
+func next(c any) Connector {
+	return Connector{Target: targetOf(c)}
+}
+
+func fault(c any) Connector {
+	return Connector{Target: targetOf(c), Label: "Fault", Fault: true}
+}
+
+// targetOf returns the target of a connector.  Connectors are anonymous
+// structs of different shapes, so the target is found by type switch.
+func targetOf(c any) ElementName {
+	switch c := c.(type) {
+	case *struct {
+		IsGoTo *struct {
+			Text string `xml:",chardata"`
+		} `xml:"isGoTo"`
+		TargetReference ElementName `xml:"targetReference"`
+	}:
+		if c != nil {
+			return c.TargetReference
+		}
+	case *struct {
+		TargetReference ElementName `xml:"targetReference"`
+	}:
+		if c != nil {
+			return c.TargetReference
+		}
+	}
+	return ""
+}
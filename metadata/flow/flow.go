@@ -157,6 +157,12 @@ func (v Value) String() string {
 	if v.BooleanValue != nil {
 		return v.BooleanValue.String()
 	}
+	if v.NumberValue != nil {
+		return v.NumberValue.Text
+	}
+	if v.DateTimeValue != nil {
+		return v.DateTimeValue.Text
+	}
 	return ""
 }
 
@@ -0,0 +1,115 @@
+package flow
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The name of the start node in graphs
+const startNode = "_start"
+
+// DOT returns the control graph of the flow in Graphviz DOT format.  Edges
+// are labeled with decision outcomes, loop paths, and scheduled paths, and
+// fault paths are dashed.
+func (f *Flow) DOT() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "digraph %s {\n", dotQuote(string(f.GetMetadataInfo().Name())))
+	b.WriteString("\tnode [shape=box, style=rounded];\n")
+	fmt.Fprintf(&b, "\t%s [label=\"Start\", shape=oval];\n", dotQuote(startNode))
+	for _, e := range f.Elements() {
+		label := e.Label
+		if label == "" {
+			label = string(e.Name)
+		}
+		attrs := fmt.Sprintf("label=%s", dotQuote(label+"\n"+e.Type))
+		switch e.Type {
+		case "Decision":
+			attrs += ", shape=diamond, style=\"\""
+		case "Loop":
+			attrs += ", shape=hexagon, style=\"\""
+		}
+		fmt.Fprintf(&b, "\t%s [%s];\n", dotQuote(string(e.Name)), attrs)
+	}
+	edge := func(from ElementName, c Connector) {
+		var attrs []string
+		if c.Label != "" {
+			attrs = append(attrs, "label="+dotQuote(c.Label))
+		}
+		if c.Fault {
+			attrs = append(attrs, "style=dashed", "color=red")
+		}
+		fmt.Fprintf(&b, "\t%s -> %s", dotQuote(string(from)), dotQuote(string(c.Target)))
+		if len(attrs) > 0 {
+			fmt.Fprintf(&b, " [%s]", strings.Join(attrs, ", "))
+		}
+		b.WriteString(";\n")
+	}
+	for _, c := range f.StartConnectors() {
+		edge(startNode, c)
+	}
+	for _, e := range f.Elements() {
+		for _, c := range e.Connectors {
+			edge(e.Name, c)
+		}
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// Mermaid returns the control graph of the flow as a Mermaid flowchart
+func (f *Flow) Mermaid() string {
+	var b strings.Builder
+	b.WriteString("flowchart TD\n")
+	fmt.Fprintf(&b, "\t%s([Start])\n", startNode)
+	for _, e := range f.Elements() {
+		label := e.Label
+		if label == "" {
+			label = string(e.Name)
+		}
+		text := mermaidQuote(label + "<br/><i>" + e.Type + "</i>")
+		switch e.Type {
+		case "Decision":
+			fmt.Fprintf(&b, "\t%s{%s}\n", mermaidId(e.Name), text)
+		case "Loop":
+			fmt.Fprintf(&b, "\t%s{{%s}}\n", mermaidId(e.Name), text)
+		default:
+			fmt.Fprintf(&b, "\t%s[%s]\n", mermaidId(e.Name), text)
+		}
+	}
+	edge := func(from ElementName, c Connector) {
+		arrow := "-->"
+		if c.Fault {
+			arrow = "-.->"
+		}
+		if c.Label != "" {
+			arrow += "|" + mermaidQuote(c.Label) + "|"
+		}
+		fmt.Fprintf(&b, "\t%s %s %s\n", mermaidId(from), arrow, mermaidId(c.Target))
+	}
+	for _, c := range f.StartConnectors() {
+		edge(startNode, c)
+	}
+	for _, e := range f.Elements() {
+		for _, c := range e.Connectors {
+			edge(e.Name, c)
+		}
+	}
+	return b.String()
+}
+
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
+
+// mermaidId returns a node id for an element.  "end" is a keyword in
+// Mermaid, so names are suffixed if they match it.
+func mermaidId(name ElementName) string {
+	if strings.EqualFold(string(name), "end") {
+		return string(name) + "_"
+	}
+	return string(name)
+}
+
+func mermaidQuote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, "#quot;") + `"`
+}
@@ -12,6 +12,7 @@ type MetadataInfo struct {
 	path     MetadataFilePath
 	name     MetadataObjectName
 	contents []byte
+	unknown  UnknownElements
 }
 
 func (m MetadataInfo) NameFromPath(path string) MetadataObjectName {
@@ -30,6 +31,18 @@ func (m MetadataInfo) Contents() []byte {
 	return m.contents
 }
 
+// UnknownElements returns the elements that were preserved from the source
+// file because the metadata type doesn't model them.
+func (m MetadataInfo) UnknownElements() UnknownElements {
+	return m.unknown
+}
+
+// SetUnknownElements replaces the elements that will be added back when the
+// metadata is serialized.
+func (m *MetadataInfo) SetUnknownElements(unknown UnknownElements) {
+	m.unknown = unknown
+}
+
 func (m MetadataInfo) GetMetadataInfo() MetadataInfo {
 	return m
 }
@@ -39,3 +52,21 @@ func NameFromPath(path string) MetadataObjectName {
 	ext := filepath.Ext(name)
 	return MetadataObjectName(strings.TrimSuffix(name, ext))
 }
+
+// FolderNameFromPath returns the name of folder-based metadata, such as a
+// report, including the folders within dir that contain it, e.g.
+// reports/Sales/Pipeline.report-meta.xml is Sales/Pipeline, so components
+// with the same name in different folders can be distinguished.
+func FolderNameFromPath(path string, dir string) MetadataObjectName {
+	p := "/" + filepath.ToSlash(filepath.Clean(path))
+	i := strings.LastIndex(p, "/"+dir+"/")
+	if i < 0 {
+		return NameFromPath(path)
+	}
+	relative := p[i+len(dir)+2:]
+	j := strings.LastIndex(relative, "/")
+	if j < 0 {
+		return NameFromPath(path)
+	}
+	return MetadataObjectName(relative[:j+1]) + NameFromPath(relative)
+}
@@ -0,0 +1,242 @@
+package layout
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	. "github.com/ForceCLI/force-md/general"
+)
+
+var FieldExistsError = errors.New("field already exists")
+var FieldNotFoundError = errors.New("field not found")
+
+var Behaviors = []string{"Edit", "Required", "Readonly"}
+
+type FieldLocation struct {
+	Field    string
+	Behavior string
+	Section  string
+	Column   int
+}
+
+// Fields returns the location of each field in the layout's sections
+func (l *Layout) Fields() []FieldLocation {
+	var locations []FieldLocation
+	for _, s := range l.LayoutSections {
+		for c, column := range s.LayoutColumns {
+			for _, i := range column.LayoutItems {
+				if i.Field == nil {
+					continue
+				}
+				locations = append(locations, FieldLocation{
+					Field:    i.Field.String(),
+					Behavior: i.Behavior.String(),
+					Section:  s.Label.String(),
+					Column:   c + 1,
+				})
+			}
+		}
+	}
+	return locations
+}
+
+func (l *Layout) section(label string) (*LayoutSection, error) {
+	for i, s := range l.LayoutSections {
+		if strings.EqualFold(s.Label.String(), label) {
+			return &l.LayoutSections[i], nil
+		}
+	}
+	return nil, errors.New("section not found: " + label)
+}
+
+func (l *Layout) hasField(field string) bool {
+	for _, f := range l.Fields() {
+		if strings.EqualFold(f.Field, field) {
+			return true
+		}
+	}
+	return false
+}
+
+// AddField adds a field to a column of a section.  Columns are numbered from
+// 1.  The field is added after the field named by after, or at the end of the
+// column if after is empty.
+func (l *Layout) AddField(field, behavior, section string, column int, after string) error {
+	if l.hasField(field) {
+		return FieldExistsError
+	}
+	if err := validateBehavior(behavior); err != nil {
+		return err
+	}
+	s, err := l.section(section)
+	if err != nil {
+		return err
+	}
+	if column < 1 || column > len(s.LayoutColumns) {
+		return fmt.Errorf("section %s has %d columns", section, len(s.LayoutColumns))
+	}
+	item := LayoutItem{
+		Behavior: &TextLiteral{Text: behavior},
+		Field:    &TextLiteral{Text: field},
+	}
+	c := &s.LayoutColumns[column-1]
+	position := len(c.LayoutItems)
+	if after != "" {
+		if !columnHasField(*c, after) {
+			return errors.New("field not found in column: " + after)
+		}
+		for i, existing := range c.LayoutItems {
+			if existing.Field != nil && strings.EqualFold(existing.Field.String(), after) {
+				position = i + 1
+			}
+		}
+	}
+	c.LayoutItems = append(c.LayoutItems[:position], append([]LayoutItem{item}, c.LayoutItems[position:]...)...)
+	return nil
+}
+
+// DeleteField removes a field from the layout's sections, related content,
+// mini layout, and highlights panel
+func (l *Layout) DeleteField(field string) error {
+	found := false
+	for s := range l.LayoutSections {
+		for c := range l.LayoutSections[s].LayoutColumns {
+			column := &l.LayoutSections[s].LayoutColumns[c]
+			items := column.LayoutItems[:0]
+			for _, i := range column.LayoutItems {
+				if i.Field != nil && strings.EqualFold(i.Field.String(), field) {
+					found = true
+					continue
+				}
+				items = append(items, i)
+			}
+			column.LayoutItems = items
+		}
+	}
+	if l.RelatedContent != nil {
+		items := l.RelatedContent.RelatedContentItems[:0]
+		for _, i := range l.RelatedContent.RelatedContentItems {
+			if i.LayoutItem.Field != nil && strings.EqualFold(i.LayoutItem.Field.String(), field) {
+				found = true
+				continue
+			}
+			items = append(items, i)
+		}
+		l.RelatedContent.RelatedContentItems = items
+	}
+	if l.MiniLayout != nil {
+		fields := l.MiniLayout.Fields[:0]
+		for _, f := range l.MiniLayout.Fields {
+			if strings.EqualFold(f.Text, field) {
+				found = true
+				continue
+			}
+			fields = append(fields, f)
+		}
+		l.MiniLayout.Fields = fields
+	}
+	if l.SummaryLayout != nil {
+		items := l.SummaryLayout.SummaryLayoutItems[:0]
+		for _, i := range l.SummaryLayout.SummaryLayoutItems {
+			if i.Field != nil && strings.EqualFold(i.Field.String(), field) {
+				found = true
+				continue
+			}
+			items = append(items, i)
+		}
+		l.SummaryLayout.SummaryLayoutItems = items
+	}
+	if !found {
+		return FieldNotFoundError
+	}
+	return nil
+}
+
+// MoveField moves a field to a column of a section, keeping its behavior
+func (l *Layout) MoveField(field, section string, column int, after string) error {
+	var behavior string
+	found := false
+	for _, f := range l.Fields() {
+		if strings.EqualFold(f.Field, field) {
+			field = f.Field
+			behavior = f.Behavior
+			found = true
+		}
+	}
+	if !found {
+		return FieldNotFoundError
+	}
+	s, err := l.section(section)
+	if err != nil {
+		return err
+	}
+	if column < 1 || column > len(s.LayoutColumns) {
+		return fmt.Errorf("section %s has %d columns", section, len(s.LayoutColumns))
+	}
+	if strings.EqualFold(field, after) {
+		return errors.New("can't move a field after itself")
+	}
+	if after != "" && !columnHasField(s.LayoutColumns[column-1], after) {
+		return errors.New("field not found in column: " + after)
+	}
+	l.deleteLayoutItem(field)
+	return l.AddField(field, behavior, section, column, after)
+}
+
+func (l *Layout) deleteLayoutItem(field string) {
+	for s := range l.LayoutSections {
+		for c := range l.LayoutSections[s].LayoutColumns {
+			column := &l.LayoutSections[s].LayoutColumns[c]
+			items := column.LayoutItems[:0]
+			for _, i := range column.LayoutItems {
+				if i.Field == nil || !strings.EqualFold(i.Field.String(), field) {
+					items = append(items, i)
+				}
+			}
+			column.LayoutItems = items
+		}
+	}
+}
+
+// SetFieldBehavior sets whether a field is editable, required, or read-only
+func (l *Layout) SetFieldBehavior(field, behavior string) error {
+	if err := validateBehavior(behavior); err != nil {
+		return err
+	}
+	found := false
+	for s := range l.LayoutSections {
+		for c := range l.LayoutSections[s].LayoutColumns {
+			items := l.LayoutSections[s].LayoutColumns[c].LayoutItems
+			for i := range items {
+				if items[i].Field != nil && strings.EqualFold(items[i].Field.String(), field) {
+					items[i].Behavior = &TextLiteral{Text: behavior}
+					found = true
+				}
+			}
+		}
+	}
+	if !found {
+		return FieldNotFoundError
+	}
+	return nil
+}
+
+func validateBehavior(behavior string) error {
+	for _, b := range Behaviors {
+		if b == behavior {
+			return nil
+		}
+	}
+	return errors.New("invalid behavior: " + behavior)
+}
+
+func columnHasField(c LayoutColumn, field string) bool {
+	for _, i := range c.LayoutItems {
+		if i.Field != nil && strings.EqualFold(i.Field.String(), field) {
+			return true
+		}
+	}
+	return false
+}
@@ -3,6 +3,7 @@ package layout
 import (
 	"encoding/xml"
 
+	. "github.com/ForceCLI/force-md/general"
 	"github.com/ForceCLI/force-md/internal"
 	"github.com/ForceCLI/force-md/metadata"
 )
@@ -13,182 +14,128 @@ func init() {
 	internal.TypeRegistry.Register(NAME, func(path string) (metadata.RegisterableMetadata, error) { return Open(path) })
 }
 
-type Layout struct {
-	metadata.MetadataInfo
-	XMLName        xml.Name `xml:"Layout"`
-	Xmlns          string   `xml:"xmlns,attr"`
+type LayoutItem struct {
+	AnalyticsCloudComponent *TextLiteral `xml:"analyticsCloudComponent"`
+	Behavior                *TextLiteral `xml:"behavior"`
+	Canvas                  *TextLiteral `xml:"canvas"`
+	Component               *TextLiteral `xml:"component"`
+	CustomLink              *TextLiteral `xml:"customLink"`
+	EmptySpace              *BooleanText `xml:"emptySpace"`
+	Field                   *TextLiteral `xml:"field"`
+	Height                  *IntegerText `xml:"height"`
+	Page                    *TextLiteral `xml:"page"`
+	ReportChartComponent    *TextLiteral `xml:"reportChartComponent"`
+	Scontrol                *TextLiteral `xml:"scontrol"`
+	ShowLabel               *BooleanText `xml:"showLabel"`
+	ShowScrollbars          *BooleanText `xml:"showScrollbars"`
+	Width                   *TextLiteral `xml:"width"`
+}
+
+type LayoutColumn struct {
+	LayoutItems []LayoutItem `xml:"layoutItems"`
+	Reserved    *TextLiteral `xml:"reserved"`
+}
+
+type LayoutSection struct {
+	CustomLabel   *BooleanText   `xml:"customLabel"`
+	DetailHeading *BooleanText   `xml:"detailHeading"`
+	EditHeading   *BooleanText   `xml:"editHeading"`
+	Label         *TextLiteral   `xml:"label"`
+	LayoutColumns []LayoutColumn `xml:"layoutColumns"`
+	Style         TextLiteral    `xml:"style"`
+}
+
+type PlatformActionListItem struct {
+	ActionName TextLiteral  `xml:"actionName"`
+	ActionType TextLiteral  `xml:"actionType"`
+	SortOrder  IntegerText  `xml:"sortOrder"`
+	Subtype    *TextLiteral `xml:"subtype"`
+}
+
+type QuickActionListItem struct {
+	QuickActionName TextLiteral `xml:"quickActionName"`
+}
+
+type RelatedList struct {
+	CustomButtons []struct {
+		Text string `xml:",chardata"`
+	} `xml:"customButtons"`
 	ExcludeButtons []struct {
 		Text string `xml:",chardata"`
 	} `xml:"excludeButtons"`
-	LayoutSections []struct {
-		CustomLabel struct {
-			Text string `xml:",chardata"`
-		} `xml:"customLabel"`
-		DetailHeading struct {
-			Text string `xml:",chardata"`
-		} `xml:"detailHeading"`
-		EditHeading struct {
-			Text string `xml:",chardata"`
-		} `xml:"editHeading"`
-		Label struct {
-			Text string `xml:",chardata"`
-		} `xml:"label"`
-		LayoutColumns []struct {
-			LayoutItems []struct {
-				Behavior struct {
-					Text string `xml:",chardata"`
-				} `xml:"behavior"`
-				Field struct {
-					Text string `xml:",chardata"`
-				} `xml:"field"`
-				CustomLink struct {
-					Text string `xml:",chardata"`
-				} `xml:"customLink"`
-				EmptySpace struct {
-					Text string `xml:",chardata"`
-				} `xml:"emptySpace"`
-				Height struct {
-					Text string `xml:",chardata"`
-				} `xml:"height"`
-				Page struct {
-					Text string `xml:",chardata"`
-				} `xml:"page"`
-				ShowLabel struct {
-					Text string `xml:",chardata"`
-				} `xml:"showLabel"`
-				ShowScrollbars struct {
-					Text string `xml:",chardata"`
-				} `xml:"showScrollbars"`
-				Width struct {
-					Text string `xml:",chardata"`
-				} `xml:"width"`
-			} `xml:"layoutItems"`
-		} `xml:"layoutColumns"`
-		Style struct {
-			Text string `xml:",chardata"`
-		} `xml:"style"`
-	} `xml:"layoutSections"`
-	PlatformActionList struct {
-		ActionListContext struct {
-			Text string `xml:",chardata"`
-		} `xml:"actionListContext"`
-		PlatformActionListItems []struct {
-			ActionName struct {
-				Text string `xml:",chardata"`
-			} `xml:"actionName"`
-			ActionType struct {
-				Text string `xml:",chardata"`
-			} `xml:"actionType"`
-			SortOrder struct {
-				Text string `xml:",chardata"`
-			} `xml:"sortOrder"`
-		} `xml:"platformActionListItems"`
-	} `xml:"platformActionList"`
-	RelatedLists []struct {
-		ExcludeButtons []struct {
-			Text string `xml:",chardata"`
-		} `xml:"excludeButtons"`
-		Fields []struct {
-			Text string `xml:",chardata"`
-		} `xml:"fields"`
-		RelatedList struct {
-			Text string `xml:",chardata"`
-		} `xml:"relatedList"`
-		SortField struct {
-			Text string `xml:",chardata"`
-		} `xml:"sortField"`
-		SortOrder struct {
-			Text string `xml:",chardata"`
-		} `xml:"sortOrder"`
-		CustomButtons struct {
-			Text string `xml:",chardata"`
-		} `xml:"customButtons"`
-	} `xml:"relatedLists"`
-	RelatedObjects []struct {
-		Text string `xml:",chardata"`
-	} `xml:"relatedObjects"`
-	ShowEmailCheckbox struct {
+	Fields []struct {
 		Text string `xml:",chardata"`
-	} `xml:"showEmailCheckbox"`
-	ShowHighlightsPanel struct {
-		Text string `xml:",chardata"`
-	} `xml:"showHighlightsPanel"`
-	ShowInteractionLogPanel struct {
-		Text string `xml:",chardata"`
-	} `xml:"showInteractionLogPanel"`
-	ShowRunAssignmentRulesCheckbox struct {
-		Text string `xml:",chardata"`
-	} `xml:"showRunAssignmentRulesCheckbox"`
-	ShowSubmitAndAttachButton struct {
-		Text string `xml:",chardata"`
-	} `xml:"showSubmitAndAttachButton"`
-	SummaryLayout struct {
-		MasterLabel struct {
-			Text string `xml:",chardata"`
-		} `xml:"masterLabel"`
-		SizeX struct {
-			Text string `xml:",chardata"`
-		} `xml:"sizeX"`
-		SizeY struct {
-			Text string `xml:",chardata"`
-		} `xml:"sizeY"`
-		SummaryLayoutStyle struct {
-			Text string `xml:",chardata"`
-		} `xml:"summaryLayoutStyle"`
-		SummaryLayoutItems []struct {
-			Field struct {
-				Text string `xml:",chardata"`
-			} `xml:"field"`
-			PosX struct {
-				Text string `xml:",chardata"`
-			} `xml:"posX"`
-			PosY struct {
-				Text string `xml:",chardata"`
-			} `xml:"posY"`
-		} `xml:"summaryLayoutItems"`
-	} `xml:"summaryLayout"`
-	QuickActionList struct {
-		QuickActionListItems []struct {
-			QuickActionName struct {
-				Text string `xml:",chardata"`
-			} `xml:"quickActionName"`
-		} `xml:"quickActionListItems"`
-	} `xml:"quickActionList"`
-	RelatedContent struct {
-		RelatedContentItems []struct {
-			LayoutItem struct {
-				Behavior struct {
-					Text string `xml:",chardata"`
-				} `xml:"behavior"`
-				Field struct {
-					Text string `xml:",chardata"`
-				} `xml:"field"`
-			} `xml:"layoutItem"`
-		} `xml:"relatedContentItems"`
-	} `xml:"relatedContent"`
+	} `xml:"fields"`
+	RelatedList TextLiteral  `xml:"relatedList"`
+	SortField   *TextLiteral `xml:"sortField"`
+	SortOrder   *TextLiteral `xml:"sortOrder"`
+}
+
+type Layout struct {
+	metadata.MetadataInfo
+	XMLName       xml.Name `xml:"Layout"`
+	Xmlns         string   `xml:"xmlns,attr"`
 	CustomButtons []struct {
 		Text string `xml:",chardata"`
 	} `xml:"customButtons"`
-	EmailDefault struct {
-		Text string `xml:",chardata"`
-	} `xml:"emailDefault"`
-	RunAssignmentRulesDefault struct {
-		Text string `xml:",chardata"`
-	} `xml:"runAssignmentRulesDefault"`
-	ShowKnowledgeComponent struct {
+	CustomConsoleComponents *TextLiteral `xml:"customConsoleComponents"`
+	EmailDefault            *BooleanText `xml:"emailDefault"`
+	ExcludeButtons          []struct {
 		Text string `xml:",chardata"`
-	} `xml:"showKnowledgeComponent"`
-	ShowSolutionSection struct {
+	} `xml:"excludeButtons"`
+	FeedLayout *TextLiteral `xml:"feedLayout"`
+	Headers    []struct {
 		Text string `xml:",chardata"`
-	} `xml:"showSolutionSection"`
-	MiniLayout struct {
+	} `xml:"headers"`
+	LayoutSections []LayoutSection `xml:"layoutSections"`
+	MiniLayout     *struct {
 		Fields []struct {
 			Text string `xml:",chardata"`
 		} `xml:"fields"`
+		RelatedLists []RelatedList `xml:"relatedLists"`
 	} `xml:"miniLayout"`
 	MultilineLayoutFields []struct {
 		Text string `xml:",chardata"`
 	} `xml:"multilineLayoutFields"`
+	PlatformActionList *struct {
+		ActionListContext       TextLiteral              `xml:"actionListContext"`
+		PlatformActionListItems []PlatformActionListItem `xml:"platformActionListItems"`
+		RelatedSourceEntity     *TextLiteral             `xml:"relatedSourceEntity"`
+	} `xml:"platformActionList"`
+	QuickActionList *struct {
+		QuickActionListItems []QuickActionListItem `xml:"quickActionListItems"`
+	} `xml:"quickActionList"`
+	RelatedContent *struct {
+		RelatedContentItems []struct {
+			LayoutItem LayoutItem `xml:"layoutItem"`
+		} `xml:"relatedContentItems"`
+	} `xml:"relatedContent"`
+	RelatedLists   []RelatedList `xml:"relatedLists"`
+	RelatedObjects []struct {
+		Text string `xml:",chardata"`
+	} `xml:"relatedObjects"`
+	RunAssignmentRulesDefault      *BooleanText `xml:"runAssignmentRulesDefault"`
+	ShowEmailCheckbox              *BooleanText `xml:"showEmailCheckbox"`
+	ShowHighlightsPanel            *BooleanText `xml:"showHighlightsPanel"`
+	ShowInteractionLogPanel        *BooleanText `xml:"showInteractionLogPanel"`
+	ShowKnowledgeComponent         *BooleanText `xml:"showKnowledgeComponent"`
+	ShowRunAssignmentRulesCheckbox *BooleanText `xml:"showRunAssignmentRulesCheckbox"`
+	ShowSolutionSection            *BooleanText `xml:"showSolutionSection"`
+	ShowSubmitAndAttachButton      *BooleanText `xml:"showSubmitAndAttachButton"`
+	SummaryLayout                  *struct {
+		MasterLabel        TextLiteral  `xml:"masterLabel"`
+		SizeX              IntegerText  `xml:"sizeX"`
+		SizeY              IntegerText  `xml:"sizeY"`
+		SizeZ              *IntegerText `xml:"sizeZ"`
+		SummaryLayoutItems []struct {
+			CustomLink *TextLiteral `xml:"customLink"`
+			Field      *TextLiteral `xml:"field"`
+			PosX       IntegerText  `xml:"posX"`
+			PosY       IntegerText  `xml:"posY"`
+			PosZ       *IntegerText `xml:"posZ"`
+		} `xml:"summaryLayoutItems"`
+		SummaryLayoutStyle TextLiteral `xml:"summaryLayoutStyle"`
+	} `xml:"summaryLayout"`
 }
 
 func (c *Layout) SetMetadata(m metadata.MetadataInfo) {
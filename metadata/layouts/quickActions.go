@@ -0,0 +1,94 @@
+package layout
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	. "github.com/ForceCLI/force-md/general"
+)
+
+var QuickActionExistsError = errors.New("quick action already exists")
+var QuickActionNotFoundError = errors.New("quick action not found")
+
+// QuickActions returns the quick actions in the publisher.  If the layout
+// overrides the actions in Lightning Experience, those are included too.
+func (l *Layout) QuickActions() []string {
+	var actions []string
+	seen := make(map[string]bool)
+	if l.QuickActionList != nil {
+		for _, a := range l.QuickActionList.QuickActionListItems {
+			actions = append(actions, a.QuickActionName.String())
+			seen[strings.ToLower(a.QuickActionName.String())] = true
+		}
+	}
+	if l.PlatformActionList != nil {
+		for _, a := range l.PlatformActionList.PlatformActionListItems {
+			if a.ActionType.String() == "QuickAction" && !seen[strings.ToLower(a.ActionName.String())] {
+				actions = append(actions, a.ActionName.String())
+			}
+		}
+	}
+	return actions
+}
+
+// AddQuickAction adds a quick action to the end of the publisher, and to the
+// Lightning Experience actions if the layout overrides them
+func (l *Layout) AddQuickAction(action string) error {
+	for _, a := range l.QuickActions() {
+		if strings.EqualFold(a, action) {
+			return QuickActionExistsError
+		}
+	}
+	if l.QuickActionList == nil {
+		l.QuickActionList = &struct {
+			QuickActionListItems []QuickActionListItem `xml:"quickActionListItems"`
+		}{}
+	}
+	l.QuickActionList.QuickActionListItems = append(l.QuickActionList.QuickActionListItems, QuickActionListItem{
+		QuickActionName: TextLiteral{Text: action},
+	})
+	if l.PlatformActionList != nil {
+		items := l.PlatformActionList.PlatformActionListItems
+		l.PlatformActionList.PlatformActionListItems = append(items, PlatformActionListItem{
+			ActionName: TextLiteral{Text: action},
+			ActionType: TextLiteral{Text: "QuickAction"},
+			SortOrder:  IntegerText{Text: strconv.Itoa(len(items))},
+		})
+	}
+	return nil
+}
+
+// DeleteQuickAction removes a quick action from the publisher and the
+// Lightning Experience actions, renumbering the remaining actions
+func (l *Layout) DeleteQuickAction(action string) error {
+	found := false
+	if l.QuickActionList != nil {
+		items := l.QuickActionList.QuickActionListItems[:0]
+		for _, a := range l.QuickActionList.QuickActionListItems {
+			if strings.EqualFold(a.QuickActionName.String(), action) {
+				found = true
+				continue
+			}
+			items = append(items, a)
+		}
+		l.QuickActionList.QuickActionListItems = items
+	}
+	if l.PlatformActionList != nil {
+		items := l.PlatformActionList.PlatformActionListItems[:0]
+		for _, a := range l.PlatformActionList.PlatformActionListItems {
+			if a.ActionType.String() == "QuickAction" && strings.EqualFold(a.ActionName.String(), action) {
+				found = true
+				continue
+			}
+			a.SortOrder.Text = strconv.Itoa(len(items))
+			items = append(items, a)
+		}
+		l.PlatformActionList.PlatformActionListItems = items
+	}
+	if !found {
+		return QuickActionNotFoundError
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package layout
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+
+	. "github.com/ForceCLI/force-md/general"
+)
+
+var RelatedListExistsError = errors.New("related list already exists")
+var RelatedListNotFoundError = errors.New("related list not found")
+
+// AddRelatedList adds a related list to the end of the layout's related lists
+func (l *Layout) AddRelatedList(relatedList string, fields []string, sortField, sortOrder string) error {
+	for _, r := range l.RelatedLists {
+		if strings.EqualFold(r.RelatedList.String(), relatedList) {
+			return RelatedListExistsError
+		}
+	}
+	r := RelatedList{RelatedList: TextLiteral{Text: relatedList}}
+	for _, f := range fields {
+		r.Fields = append(r.Fields, struct {
+			Text string `xml:",chardata"`
+		}{Text: f})
+	}
+	if sortField != "" {
+		r.SortField = &TextLiteral{Text: sortField}
+		if sortOrder == "" {
+			sortOrder = "Asc"
+		}
+		r.SortOrder = &TextLiteral{Text: sortOrder}
+	}
+	l.RelatedLists = append(l.RelatedLists, r)
+	return nil
+}
+
+func (l *Layout) DeleteRelatedList(relatedList string) error {
+	found := false
+	newRelatedLists := l.RelatedLists[:0]
+	for _, r := range l.RelatedLists {
+		if strings.EqualFold(r.RelatedList.String(), relatedList) {
+			found = true
+			continue
+		}
+		newRelatedLists = append(newRelatedLists, r)
+	}
+	if !found {
+		return RelatedListNotFoundError
+	}
+	l.RelatedLists = newRelatedLists
+	return nil
+}
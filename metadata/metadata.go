@@ -53,6 +53,7 @@ func ParseMetadataXmlIfPossible(i MetadataPointer, path string) ([]byte, error)
 	meta := MetadataInfo{}
 	meta.path = MetadataFilePath(path)
 	meta.contents = contents
+	meta.unknown = findUnknownElements(i, path, contents)
 	name := i.NameFromPath(path)
 	meta.name = name
 	i.SetMetadata(meta)
@@ -87,6 +88,7 @@ func ParseMetadataXml(i MetadataPointer, path string) error {
 	meta := MetadataInfo{}
 	meta.path = MetadataFilePath(path)
 	meta.contents = contents
+	meta.unknown = findUnknownElements(i, path, contents)
 	name := i.NameFromPath(path)
 	meta.name = name
 	i.SetMetadata(meta)
@@ -0,0 +1,46 @@
+package mutingpermissionset
+
+import (
+	"encoding/xml"
+
+	"github.com/ForceCLI/force-md/internal"
+	"github.com/ForceCLI/force-md/metadata"
+	"github.com/ForceCLI/force-md/metadata/permissionset"
+)
+
+const NAME = "MutingPermissionSet"
+
+func init() {
+	internal.TypeRegistry.Register(NAME, func(path string) (metadata.RegisterableMetadata, error) { return Open(path) })
+}
+
+// MutingPermissionSet disables permissions granted by the permission sets in
+// a permission set group.  A permission that is enabled in the muting
+// permission set is muted.
+type MutingPermissionSet struct {
+	metadata.MetadataInfo
+	XMLName           xml.Name                            `xml:"MutingPermissionSet"`
+	Xmlns             string                              `xml:"xmlns,attr"`
+	ClassAccesses     permissionset.ApexClassList         `xml:"classAccesses"`
+	CustomPermissions permissionset.CustomPermissionList  `xml:"customPermissions"`
+	Description       *permissionset.Description          `xml:"description"`
+	FieldPermissions  permissionset.FieldPermissionsList  `xml:"fieldPermissions"`
+	Label             string                              `xml:"label"`
+	ObjectPermissions permissionset.ObjectPermissionsList `xml:"objectPermissions"`
+	PageAccesses      permissionset.PageAccessList        `xml:"pageAccesses"`
+	TabSettings       permissionset.TabSettingsList       `xml:"tabSettings"`
+	UserPermissions   permissionset.UserPermissionList    `xml:"userPermissions"`
+}
+
+func (c *MutingPermissionSet) SetMetadata(m metadata.MetadataInfo) {
+	c.MetadataInfo = m
+}
+
+func (c *MutingPermissionSet) Type() metadata.MetadataType {
+	return NAME
+}
+
+func Open(path string) (*MutingPermissionSet, error) {
+	p := &MutingPermissionSet{}
+	return p, metadata.ParseMetadataXml(p, path)
+}
@@ -0,0 +1,277 @@
+package objects
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/ForceCLI/force-md/internal"
+	"github.com/ForceCLI/force-md/metadata/objects/businessprocess"
+	"github.com/ForceCLI/force-md/metadata/objects/compactlayout"
+	"github.com/ForceCLI/force-md/metadata/objects/field"
+	"github.com/ForceCLI/force-md/metadata/objects/fieldset"
+	"github.com/ForceCLI/force-md/metadata/objects/index"
+	"github.com/ForceCLI/force-md/metadata/objects/listview"
+	"github.com/ForceCLI/force-md/metadata/objects/recordtype"
+	"github.com/ForceCLI/force-md/metadata/objects/sharingreason"
+	"github.com/ForceCLI/force-md/metadata/objects/validationrule"
+	"github.com/ForceCLI/force-md/metadata/objects/weblink"
+)
+
+// The directory and file suffix used for each child component in sfdx source
+// format
+type childComponent struct {
+	dir    string
+	suffix string
+}
+
+var (
+	businessProcessFiles = childComponent{"businessProcesses", ".businessProcess-meta.xml"}
+	compactLayoutFiles   = childComponent{"compactLayouts", ".compactLayout-meta.xml"}
+	fieldFiles           = childComponent{"fields", ".field-meta.xml"}
+	fieldSetFiles        = childComponent{"fieldSets", ".fieldSet-meta.xml"}
+	indexFiles           = childComponent{"indexes", ".index-meta.xml"}
+	listViewFiles        = childComponent{"listViews", ".listView-meta.xml"}
+	recordTypeFiles      = childComponent{"recordTypes", ".recordType-meta.xml"}
+	sharingReasonFiles   = childComponent{"sharingReasons", ".sharingReason-meta.xml"}
+	validationRuleFiles  = childComponent{"validationRules", ".validationRule-meta.xml"}
+	webLinkFiles         = childComponent{"webLinks", ".webLink-meta.xml"}
+)
+
+const objectFileSuffix = ".object-meta.xml"
+
+func (c childComponent) write(objectDir, name string, m any) error {
+	dir := filepath.Join(objectDir, c.dir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return errors.Wrap(err, "creating directory")
+	}
+	return internal.WriteToFile(m, filepath.Join(dir, name+c.suffix))
+}
+
+func (c childComponent) files(objectDir string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(objectDir, c.dir, "*"+c.suffix))
+	if err != nil {
+		return nil, errors.Wrap(err, "listing "+c.dir)
+	}
+	return files, nil
+}
+
+// Decompose writes the object in sfdx source format.  The object is written
+// to dir/ObjectName/ObjectName.object-meta.xml, and each child component is
+// written to its own file in a subdirectory named for the type of component,
+// e.g. dir/ObjectName/fields/Field__c.field-meta.xml.
+func (o *CustomObject) Decompose(dir string) error {
+	objectName := string(o.GetMetadataInfo().Name())
+	if objectName == "" {
+		return errors.New("object name unknown")
+	}
+	objectDir := filepath.Join(dir, objectName)
+	if err := os.MkdirAll(objectDir, 0755); err != nil {
+		return errors.Wrap(err, "creating directory")
+	}
+
+	parent := *o
+	parent.BusinessProcesses = nil
+	parent.CompactLayouts = nil
+	parent.FieldSets = nil
+	parent.Fields = nil
+	parent.Indexes = nil
+	parent.ListViews = nil
+	parent.RecordTypes = nil
+	parent.SharingReasons = nil
+	parent.ValidationRules = nil
+	parent.WebLinks = nil
+	if err := internal.WriteToFile(&parent, filepath.Join(objectDir, objectName+objectFileSuffix)); err != nil {
+		return errors.Wrap(err, "writing object")
+	}
+
+	for _, c := range o.BusinessProcesses {
+		m := businessprocess.BusinessProcessMetadata{Xmlns: o.Xmlns, BusinessProcess: c}
+		if err := businessProcessFiles.write(objectDir, c.FullName.Text, m); err != nil {
+			return errors.Wrap(err, "writing business process")
+		}
+	}
+	for _, c := range o.CompactLayouts {
+		m := compactlayout.CompactLayoutMetadata{Xmlns: o.Xmlns, CompactLayout: c}
+		if err := compactLayoutFiles.write(objectDir, c.FullName.Text, m); err != nil {
+			return errors.Wrap(err, "writing compact layout")
+		}
+	}
+	for _, c := range o.FieldSets {
+		m := fieldset.FieldSetMetadata{Xmlns: o.Xmlns, FieldSet: c}
+		if err := fieldSetFiles.write(objectDir, c.FullName, m); err != nil {
+			return errors.Wrap(err, "writing field set")
+		}
+	}
+	for _, c := range o.Fields {
+		m := field.CustomField{Xmlns: o.Xmlns, Field: c}
+		if err := fieldFiles.write(objectDir, c.FullName, m); err != nil {
+			return errors.Wrap(err, "writing field")
+		}
+	}
+	for _, c := range o.Indexes {
+		m := index.Index{Xmlns: o.Xmlns, BigObjectIndex: c}
+		if err := indexFiles.write(objectDir, c.FullName, m); err != nil {
+			return errors.Wrap(err, "writing index")
+		}
+	}
+	for _, c := range o.ListViews {
+		m := listview.ListViewMetadata{Xmlns: o.Xmlns, ListView: c}
+		if err := listViewFiles.write(objectDir, c.FullName.Text, m); err != nil {
+			return errors.Wrap(err, "writing list view")
+		}
+	}
+	for _, c := range o.RecordTypes {
+		m := recordtype.RecordTypeMetadata{Xmlns: o.Xmlns, RecordType: c}
+		if err := recordTypeFiles.write(objectDir, c.FullName, m); err != nil {
+			return errors.Wrap(err, "writing record type")
+		}
+	}
+	for _, c := range o.SharingReasons {
+		m := sharingreason.SharingReasonMetadata{Xmlns: o.Xmlns, SharingReason: c}
+		if err := sharingReasonFiles.write(objectDir, c.FullName, m); err != nil {
+			return errors.Wrap(err, "writing sharing reason")
+		}
+	}
+	for _, c := range o.ValidationRules {
+		m := validationrule.ValidationRule{Xmlns: o.Xmlns, Rule: c}
+		if err := validationRuleFiles.write(objectDir, c.FullName, m); err != nil {
+			return errors.Wrap(err, "writing validation rule")
+		}
+	}
+	for _, c := range o.WebLinks {
+		m := weblink.WebLinkMetadata{Xmlns: o.Xmlns, WebLink: c}
+		if err := webLinkFiles.write(objectDir, c.FullName, m); err != nil {
+			return errors.Wrap(err, "writing web link")
+		}
+	}
+	return nil
+}
+
+// Compose reads an object in sfdx source format from objectDir, which must be
+// named for the object, and combines its child components into a single
+// object.  The object is tidied so its child components are in a stable
+// order.
+func Compose(objectDir string) (*CustomObject, error) {
+	objectDir = filepath.Clean(objectDir)
+	objectName := filepath.Base(objectDir)
+	o, err := Open(filepath.Join(objectDir, objectName+objectFileSuffix))
+	if err != nil {
+		return nil, errors.Wrap(err, "opening object")
+	}
+
+	files, err := businessProcessFiles.files(objectDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		c, err := businessprocess.Open(file)
+		if err != nil {
+			return nil, errors.Wrap(err, "opening business process")
+		}
+		o.BusinessProcesses = append(o.BusinessProcesses, c.BusinessProcess)
+	}
+
+	if files, err = compactLayoutFiles.files(objectDir); err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		c, err := compactlayout.Open(file)
+		if err != nil {
+			return nil, errors.Wrap(err, "opening compact layout")
+		}
+		o.CompactLayouts = append(o.CompactLayouts, c.CompactLayout)
+	}
+
+	if files, err = fieldSetFiles.files(objectDir); err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		c, err := fieldset.Open(file)
+		if err != nil {
+			return nil, errors.Wrap(err, "opening field set")
+		}
+		o.FieldSets = append(o.FieldSets, c.FieldSet)
+	}
+
+	if files, err = fieldFiles.files(objectDir); err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		c, err := field.Open(file)
+		if err != nil {
+			return nil, errors.Wrap(err, "opening field")
+		}
+		o.Fields = append(o.Fields, c.Field)
+	}
+
+	if files, err = indexFiles.files(objectDir); err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		c, err := index.Open(file)
+		if err != nil {
+			return nil, errors.Wrap(err, "opening index")
+		}
+		o.Indexes = append(o.Indexes, c.BigObjectIndex)
+	}
+
+	if files, err = listViewFiles.files(objectDir); err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		c, err := listview.Open(file)
+		if err != nil {
+			return nil, errors.Wrap(err, "opening list view")
+		}
+		o.ListViews = append(o.ListViews, c.ListView)
+	}
+
+	if files, err = recordTypeFiles.files(objectDir); err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		c, err := recordtype.Open(file)
+		if err != nil {
+			return nil, errors.Wrap(err, "opening record type")
+		}
+		o.RecordTypes = append(o.RecordTypes, c.RecordType)
+	}
+
+	if files, err = sharingReasonFiles.files(objectDir); err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		c, err := sharingreason.Open(file)
+		if err != nil {
+			return nil, errors.Wrap(err, "opening sharing reason")
+		}
+		o.SharingReasons = append(o.SharingReasons, c.SharingReason)
+	}
+
+	if files, err = validationRuleFiles.files(objectDir); err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		c, err := validationrule.Open(file)
+		if err != nil {
+			return nil, errors.Wrap(err, "opening validation rule")
+		}
+		o.ValidationRules = append(o.ValidationRules, c.Rule)
+	}
+
+	if files, err = webLinkFiles.files(objectDir); err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		c, err := weblink.Open(file)
+		if err != nil {
+			return nil, errors.Wrap(err, "opening web link")
+		}
+		o.WebLinks = append(o.WebLinks, c.WebLink)
+	}
+
+	o.Tidy()
+	return o, nil
+}
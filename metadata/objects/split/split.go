@@ -10,10 +10,17 @@ import (
 
 var pathRegex = regexp.MustCompile(`.*/objects/([^/]+)/[^/]+/([^/]+\.[^-]+)-meta\.xml$`)
 
+// Match child components outside of an objects directory, e.g. when an object
+// has been decomposed into a different directory
+var childDirRegex = regexp.MustCompile(`(?:^|/)([^/]+)/(?:businessProcesses|compactLayouts|fieldSets|fields|indexes|listViews|recordTypes|sharingReasons|validationRules|webLinks)/([^/]+\.[^-]+)-meta\.xml$`)
+
 func NameFromPath(path string) metadata.MetadataObjectName {
 	path = filepath.ToSlash(filepath.Clean(path))
 
 	matches := pathRegex.FindStringSubmatch(path)
+	if len(matches) != 3 {
+		matches = childDirRegex.FindStringSubmatch(path)
+	}
 
 	if len(matches) != 3 {
 		log.Warnf("Could not match regex: %s", path)
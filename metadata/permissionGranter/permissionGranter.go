@@ -20,10 +20,7 @@ type PermissionGranter interface {
 	GetEnabledPageAccesses() []string
 	GetVisibleRecordTypes() []string
 	GetEnabledUserPermissions() []string
-	/*
-		* TODO: Decide how to map tab visiblity between profiles and permission sets
-			GetVisibleTabs() []string
-	*/
+	GetVisibleTabs() []permissionset.TabSettings
 }
 
 func Open(path string) (PermissionGranter, error) {
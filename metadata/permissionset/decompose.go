@@ -0,0 +1,176 @@
+package permissionset
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/ForceCLI/force-md/general"
+	"github.com/ForceCLI/force-md/internal"
+	"github.com/ForceCLI/force-md/metadata"
+)
+
+const (
+	ObjectSettingsDir    = "objectSettings"
+	ObjectSettingsSuffix = ".objectSettings-meta.xml"
+	fileSuffix           = ".permissionset-meta.xml"
+)
+
+// ObjectSettings contains the permissions for a single object when a
+// permission set is decomposed.  Its root element isn't a deployable metadata
+// type, so the files aren't mistaken for permission sets when a project is
+// loaded.
+type ObjectSettings struct {
+	metadata.MetadataInfo
+	XMLName                xml.Name                 `xml:"ObjectSettings"`
+	Xmlns                  string                   `xml:"xmlns,attr"`
+	FieldPermissions       FieldPermissionsList     `xml:"fieldPermissions"`
+	ObjectPermissions      ObjectPermissionsList    `xml:"objectPermissions"`
+	RecordTypeVisibilities RecordTypeVisibilityList `xml:"recordTypeVisibilities"`
+}
+
+func (c *ObjectSettings) SetMetadata(m metadata.MetadataInfo) {
+	c.MetadataInfo = m
+}
+
+func (c *ObjectSettings) Tidy() {
+	c.FieldPermissions.Tidy()
+	c.ObjectPermissions.Tidy()
+	c.RecordTypeVisibilities.Tidy()
+}
+
+func OpenObjectSettings(path string) (*ObjectSettings, error) {
+	p := &ObjectSettings{}
+	return p, metadata.ParseMetadataXml(p, path)
+}
+
+// ObjectName returns the object that a field or record type belongs to
+func ObjectName(name string) string {
+	objectName, _, _ := strings.Cut(name, ".")
+	return objectName
+}
+
+// Decompose writes the permission set to dir/Name/Name.permissionset-meta.xml,
+// with the field permissions, object permissions, and record type visibilities
+// for each object written to
+// dir/Name/objectSettings/Object.objectSettings-meta.xml.
+func (p *PermissionSet) Decompose(dir string) error {
+	name := string(p.GetMetadataInfo().Name())
+	if name == "" {
+		return errors.New("permission set name unknown")
+	}
+	settings := make(map[string]*ObjectSettings)
+	objectSettings := func(objectName string) *ObjectSettings {
+		if _, ok := settings[objectName]; !ok {
+			settings[objectName] = &ObjectSettings{Xmlns: p.Xmlns}
+		}
+		return settings[objectName]
+	}
+	for _, f := range p.FieldPermissions {
+		s := objectSettings(ObjectName(f.Field))
+		s.FieldPermissions = append(s.FieldPermissions, f)
+	}
+	for _, o := range p.ObjectPermissions {
+		s := objectSettings(o.Object)
+		s.ObjectPermissions = append(s.ObjectPermissions, o)
+	}
+	for _, r := range p.RecordTypeVisibilities {
+		s := objectSettings(ObjectName(r.RecordType))
+		s.RecordTypeVisibilities = append(s.RecordTypeVisibilities, r)
+	}
+
+	parent := *p
+	parent.FieldPermissions = nil
+	parent.ObjectPermissions = nil
+	parent.RecordTypeVisibilities = nil
+	return WriteDecomposed(dir, name, fileSuffix, &parent, settings)
+}
+
+// WriteDecomposed writes parent, which has had its object-specific settings
+// removed, to dir/name/name+suffix, and the settings for each object to
+// dir/name/objectSettings/Object.objectSettings-meta.xml.  Existing object
+// settings are removed so objects without permissions aren't left behind.
+func WriteDecomposed[S general.Tidyable](dir, name, suffix string, parent general.Tidyable, settings map[string]S) error {
+	decomposedDir := filepath.Join(dir, name)
+	settingsDir := filepath.Join(decomposedDir, ObjectSettingsDir)
+	if err := os.MkdirAll(settingsDir, 0755); err != nil {
+		return errors.Wrap(err, "creating directory")
+	}
+	existing, err := ObjectSettingsFiles(decomposedDir)
+	if err != nil {
+		return err
+	}
+	for _, file := range existing {
+		if err := os.Remove(file); err != nil {
+			return errors.Wrap(err, "removing object settings")
+		}
+	}
+
+	parent.Tidy()
+	if err := internal.WriteToFile(parent, filepath.Join(decomposedDir, name+suffix)); err != nil {
+		return errors.Wrap(err, "writing "+name+suffix)
+	}
+	for objectName, s := range settings {
+		s.Tidy()
+		if err := internal.WriteToFile(s, filepath.Join(settingsDir, objectName+ObjectSettingsSuffix)); err != nil {
+			return errors.Wrap(err, "writing object settings for "+objectName)
+		}
+	}
+	return nil
+}
+
+// ObjectSettingsFiles returns the object settings files in a decomposed
+// permission set or profile directory
+func ObjectSettingsFiles(dir string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, ObjectSettingsDir, "*"+ObjectSettingsSuffix))
+	if err != nil {
+		return nil, errors.Wrap(err, "listing object settings")
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// Compose reads a permission set decomposed by Decompose from dir, which must
+// be named for the permission set, and combines the object settings into a
+// single, tidied permission set.
+func Compose(dir string) (*PermissionSet, error) {
+	dir = filepath.Clean(dir)
+	name := filepath.Base(dir)
+	p, err := Open(filepath.Join(dir, name+fileSuffix))
+	if err != nil {
+		return nil, errors.Wrap(err, "opening permission set")
+	}
+	settings, err := ReadObjectSettings(dir, OpenObjectSettings)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range settings {
+		p.FieldPermissions = append(p.FieldPermissions, s.FieldPermissions...)
+		p.ObjectPermissions = append(p.ObjectPermissions, s.ObjectPermissions...)
+		p.RecordTypeVisibilities = append(p.RecordTypeVisibilities, s.RecordTypeVisibilities...)
+	}
+	p.Tidy()
+	return p, nil
+}
+
+// ReadObjectSettings opens the object settings in a decomposed permission set
+// or profile directory
+func ReadObjectSettings[S any](dir string, open func(string) (S, error)) ([]S, error) {
+	files, err := ObjectSettingsFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	var settings []S
+	for _, file := range files {
+		s, err := open(file)
+		if err != nil {
+			return nil, errors.Wrap(err, "opening object settings "+filepath.Base(file))
+		}
+		settings = append(settings, s)
+	}
+	return settings, nil
+}
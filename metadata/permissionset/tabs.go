@@ -28,3 +28,15 @@ func (p *PermissionSet) AddTab(tabName string) error {
 func (t *TabSettings) IsVisible() bool {
 	return strings.ToLower(t.Visibility) != "hidden"
 }
+
+// GetVisibleTabs returns the tabs that are Visible or Available
+func (p *PermissionSet) GetVisibleTabs() []TabSettings {
+	var tabs []TabSettings
+	for _, t := range p.TabSettings {
+		switch strings.ToLower(t.Visibility) {
+		case "visible", "available":
+			tabs = append(tabs, t)
+		}
+	}
+	return tabs
+}
@@ -32,8 +32,9 @@ type PermissionSetGroup struct {
 	Label struct {
 		Text string `xml:",chardata"`
 	} `xml:"label"`
-	PermissionSets PermissionSetList `xml:"permissionSets"`
-	Status         struct {
+	MutingPermissionSets PermissionSetList `xml:"mutingPermissionSets"`
+	PermissionSets       PermissionSetList `xml:"permissionSets"`
+	Status               struct {
 		Text string `xml:",chardata"`
 	} `xml:"status"`
 }
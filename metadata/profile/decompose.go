@@ -0,0 +1,114 @@
+package profile
+
+import (
+	"encoding/xml"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/ForceCLI/force-md/metadata"
+	"github.com/ForceCLI/force-md/metadata/permissionset"
+)
+
+const fileSuffix = ".profile-meta.xml"
+
+// ObjectSettings contains the permissions and layout assignments for a single
+// object when a profile is decomposed
+type ObjectSettings struct {
+	metadata.MetadataInfo
+	XMLName                xml.Name                            `xml:"ObjectSettings"`
+	Xmlns                  string                              `xml:"xmlns,attr"`
+	FieldPermissions       permissionset.FieldPermissionsList  `xml:"fieldPermissions"`
+	LayoutAssignments      LayoutAssignmentList                `xml:"layoutAssignments"`
+	ObjectPermissions      permissionset.ObjectPermissionsList `xml:"objectPermissions"`
+	RecordTypeVisibilities RecordTypeVisibilityList            `xml:"recordTypeVisibilities"`
+}
+
+func (c *ObjectSettings) SetMetadata(m metadata.MetadataInfo) {
+	c.MetadataInfo = m
+}
+
+func (c *ObjectSettings) Tidy() {
+	c.FieldPermissions.Tidy()
+	c.LayoutAssignments.Tidy()
+	c.ObjectPermissions.Tidy()
+	c.RecordTypeVisibilities.Tidy()
+}
+
+func OpenObjectSettings(path string) (*ObjectSettings, error) {
+	p := &ObjectSettings{}
+	return p, metadata.ParseMetadataXml(p, path)
+}
+
+// LayoutObjectName returns the object a layout belongs to.  Layout names
+// are prefixed with the object name, e.g. Account-Account Layout.
+func LayoutObjectName(layout string) string {
+	objectName, _, _ := strings.Cut(layout, "-")
+	return objectName
+}
+
+// Decompose writes the profile to dir/Name/Name.profile-meta.xml, with the
+// field permissions, layout assignments, object permissions, and record type
+// visibilities for each object written to
+// dir/Name/objectSettings/Object.objectSettings-meta.xml.
+func (p *Profile) Decompose(dir string) error {
+	name := string(p.GetMetadataInfo().Name())
+	if name == "" {
+		return errors.New("profile name unknown")
+	}
+	settings := make(map[string]*ObjectSettings)
+	objectSettings := func(objectName string) *ObjectSettings {
+		if _, ok := settings[objectName]; !ok {
+			settings[objectName] = &ObjectSettings{Xmlns: p.Xmlns}
+		}
+		return settings[objectName]
+	}
+	for _, f := range p.FieldPermissions {
+		s := objectSettings(permissionset.ObjectName(f.Field))
+		s.FieldPermissions = append(s.FieldPermissions, f)
+	}
+	for _, l := range p.LayoutAssignments {
+		s := objectSettings(LayoutObjectName(l.Layout))
+		s.LayoutAssignments = append(s.LayoutAssignments, l)
+	}
+	for _, o := range p.ObjectPermissions {
+		s := objectSettings(o.Object)
+		s.ObjectPermissions = append(s.ObjectPermissions, o)
+	}
+	for _, r := range p.RecordTypeVisibilities {
+		s := objectSettings(permissionset.ObjectName(r.RecordType))
+		s.RecordTypeVisibilities = append(s.RecordTypeVisibilities, r)
+	}
+
+	parent := *p
+	parent.FieldPermissions = nil
+	parent.LayoutAssignments = nil
+	parent.ObjectPermissions = nil
+	parent.RecordTypeVisibilities = nil
+	return permissionset.WriteDecomposed(dir, name, fileSuffix, &parent, settings)
+}
+
+// Compose reads a profile decomposed by Decompose from dir, which must be
+// named for the profile, and combines the object settings into a single,
+// tidied profile.
+func Compose(dir string) (*Profile, error) {
+	dir = filepath.Clean(dir)
+	name := filepath.Base(dir)
+	p, err := Open(filepath.Join(dir, name+fileSuffix))
+	if err != nil {
+		return nil, errors.Wrap(err, "opening profile")
+	}
+	settings, err := permissionset.ReadObjectSettings(dir, OpenObjectSettings)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range settings {
+		p.FieldPermissions = append(p.FieldPermissions, s.FieldPermissions...)
+		p.LayoutAssignments = append(p.LayoutAssignments, s.LayoutAssignments...)
+		p.ObjectPermissions = append(p.ObjectPermissions, s.ObjectPermissions...)
+		p.RecordTypeVisibilities = append(p.RecordTypeVisibilities, s.RecordTypeVisibilities...)
+	}
+	p.Tidy()
+	return p, nil
+}
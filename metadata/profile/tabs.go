@@ -3,8 +3,11 @@ package profile
 import (
 	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/pkg/errors"
+
+	"github.com/ForceCLI/force-md/metadata/permissionset"
 )
 
 var TabExistsError = errors.New("tab already exists")
@@ -71,3 +74,19 @@ TABS:
 	}
 	return tabVisibilities
 }
+
+// GetVisibleTabs returns the tabs that aren't hidden, with visibilities
+// mapped to their permission set equivalents: DefaultOn is Visible, and
+// DefaultOff is Available.
+func (p *Profile) GetVisibleTabs() []permissionset.TabSettings {
+	var tabs []permissionset.TabSettings
+	for _, t := range p.TabVisibilities {
+		switch strings.ToLower(t.Visibility) {
+		case "defaulton":
+			tabs = append(tabs, permissionset.TabSettings{Tab: t.Tab, Visibility: "Visible"})
+		case "defaultoff":
+			tabs = append(tabs, permissionset.TabSettings{Tab: t.Tab, Visibility: "Available"})
+		}
+	}
+	return tabs
+}
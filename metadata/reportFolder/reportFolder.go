@@ -31,6 +31,10 @@ func (c *ReportFolder) SetMetadata(m metadata.MetadataInfo) {
 	c.MetadataInfo = m
 }
 
+func (c *ReportFolder) NameFromPath(path string) metadata.MetadataObjectName {
+	return metadata.FolderNameFromPath(path, "reports")
+}
+
 func (c *ReportFolder) Type() metadata.MetadataType {
 	return NAME
 }
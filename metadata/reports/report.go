@@ -338,6 +338,10 @@ func (c *Report) SetMetadata(m metadata.MetadataInfo) {
 	c.MetadataInfo = m
 }
 
+func (c *Report) NameFromPath(path string) metadata.MetadataObjectName {
+	return metadata.FolderNameFromPath(path, "reports")
+}
+
 func (c *Report) Type() metadata.MetadataType {
 	return NAME
 }
@@ -0,0 +1,362 @@
+package metadata
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/nbio/xml"
+	log "github.com/sirupsen/logrus"
+)
+
+// UnknownElement is an element that isn't modeled by the struct the metadata
+// was decoded into, e.g. an element added in a newer API version.  The
+// element is kept verbatim so it can be written back out when the metadata is
+// serialized.
+type UnknownElement struct {
+	// Name is the element's local name
+	Name string
+	// Parent is the path from the root element to the element that contained
+	// it, e.g. [fieldPermissions], or empty if it was a child of the root
+	Parent []string
+	// ParentKey identifies the element that contained a nested unknown
+	// element by its content, excluding any unknown elements
+	ParentKey string
+	// ParentIndex is the position of the containing element among the
+	// elements with the same Parent path and ParentKey
+	ParentIndex int
+	// After is the name of the known element that preceded it, or empty if
+	// it was the first child of its parent
+	After string
+	// Raw is the element as it appeared in the source file
+	Raw []byte
+}
+
+type UnknownElements []UnknownElement
+
+// Path returns the location of the element below the root element, e.g.
+// fieldPermissions/newThing
+func (u UnknownElement) Path() string {
+	return strings.Join(append(append([]string(nil), u.Parent...), u.Name), "/")
+}
+
+// Element is an element of an xml document found by ScanElements
+type Element struct {
+	// Path is the path from the root element to the element, or empty for
+	// the root element
+	Path []string
+	// Key is the element's content with the whitespace between elements
+	// removed
+	Key string
+	// Start is the offset of the element's start tag
+	Start int64
+	// ContentStart is the offset just past the element's start tag
+	ContentStart int64
+	// End is the offset just past the element's end tag
+	End int64
+	// Children are the element's child elements
+	Children []ChildElement
+}
+
+type ChildElement struct {
+	Name string
+	End  int64
+}
+
+type elementNames struct {
+	// names maps the names of the child elements to the types they're
+	// decoded into
+	names    map[string]reflect.Type
+	catchAll bool
+}
+
+var knownElementsCache sync.Map
+
+// knownElements returns the names of the child elements that typ will decode.
+// If typ has an ",any" or ",innerxml" field, all elements are consumed.
+func knownElements(typ reflect.Type) elementNames {
+	for typ.Kind() == reflect.Pointer || typ.Kind() == reflect.Slice {
+		typ = typ.Elem()
+	}
+	if cached, ok := knownElementsCache.Load(typ); ok {
+		return cached.(elementNames)
+	}
+	known := elementNames{names: make(map[string]reflect.Type)}
+	if typ.Implements(unmarshalerType) || reflect.PointerTo(typ).Implements(unmarshalerType) {
+		known.catchAll = true
+	}
+	addKnownElements(typ, &known)
+	knownElementsCache.Store(typ, known)
+	return known
+}
+
+var unmarshalerType = reflect.TypeOf((*xml.Unmarshaler)(nil)).Elem()
+
+func addKnownElements(typ reflect.Type, known *elementNames) {
+	if typ.Kind() != reflect.Struct {
+		return
+	}
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		tag, hasTag := f.Tag.Lookup("xml")
+		if tag == "-" || f.Name == "XMLName" {
+			continue
+		}
+		if f.Anonymous && !hasTag {
+			t := f.Type
+			if t.Kind() == reflect.Pointer {
+				t = t.Elem()
+			}
+			addKnownElements(t, known)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		name, flags, _ := strings.Cut(tag, ",")
+		if _, local, ok := strings.Cut(name, " "); ok {
+			name = local
+		}
+		if flags != "" {
+			switch {
+			case strings.Contains(flags, "attr"), strings.Contains(flags, "chardata"),
+				strings.Contains(flags, "cdata"), strings.Contains(flags, "comment"):
+				continue
+			case strings.Contains(flags, "any"), strings.Contains(flags, "innerxml"):
+				known.catchAll = true
+				continue
+			}
+		}
+		typ := f.Type
+		if parent, _, ok := strings.Cut(name, ">"); ok {
+			// Elements nested by the tag aren't checked
+			name = parent
+			typ = nil
+		}
+		if name == "" {
+			name = f.Name
+		}
+		known.names[name] = typ
+	}
+}
+
+// unknownLevel tracks an open element while scanning for unknown elements
+type unknownLevel struct {
+	known elementNames
+	// unchecked is set for elements whose children aren't checked
+	unchecked bool
+	after     string
+}
+
+// findUnknownElements returns the elements in contents that wouldn't be
+// decoded into i, at any depth.
+func findUnknownElements(i MetadataPointer, path string, contents []byte) UnknownElements {
+	var unknown UnknownElements
+	var starts []int64
+	dec := xml.NewDecoder(bytes.NewReader(contents))
+	var stack []unknownLevel
+	var names []string
+	var start int64
+	var current *UnknownElement
+	currentDepth := 0
+	for {
+		offset := dec.InputOffset()
+		t, err := dec.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			log.Debugf("could not scan %s for unknown elements: %s", path, err.Error())
+			return nil
+		}
+		switch e := t.(type) {
+		case xml.StartElement:
+			if current != nil {
+				currentDepth++
+				continue
+			}
+			if len(stack) == 0 {
+				stack = append(stack, unknownLevel{known: knownElements(reflect.TypeOf(i))})
+				continue
+			}
+			parent := &stack[len(stack)-1]
+			name := e.Name.Local
+			if parent.unchecked || parent.known.catchAll {
+				stack = append(stack, unknownLevel{unchecked: true})
+				names = append(names, name)
+				continue
+			}
+			typ, ok := parent.known.names[name]
+			if !ok {
+				start = offset
+				current = &UnknownElement{Name: name, Parent: append([]string(nil), names...), After: parent.after}
+				currentDepth = 1
+				continue
+			}
+			parent.after = name
+			if typ == nil {
+				stack = append(stack, unknownLevel{unchecked: true})
+			} else {
+				stack = append(stack, unknownLevel{known: knownElements(typ)})
+			}
+			names = append(names, name)
+		case xml.EndElement:
+			if current != nil {
+				currentDepth--
+				if currentDepth == 0 {
+					current.Raw = append([]byte(nil), contents[start:dec.InputOffset()]...)
+					unknown = append(unknown, *current)
+					starts = append(starts, start)
+					current = nil
+				}
+				continue
+			}
+			stack = stack[:len(stack)-1]
+			if len(names) > 0 {
+				names = names[:len(names)-1]
+			}
+		}
+	}
+	if err := identifyParents(unknown, starts, contents); err != nil {
+		log.Debugf("could not scan %s for unknown elements: %s", path, err.Error())
+		return nil
+	}
+	warned := make(map[string]bool)
+	for _, u := range unknown {
+		if !warned[u.Path()] {
+			log.Warnf("%s: preserving unrecognized element %s", path, u.Path())
+			warned[u.Path()] = true
+		}
+	}
+	return unknown
+}
+
+// identifyParents sets the ParentKey and ParentIndex of nested unknown
+// elements so the elements that contained them can be found after the
+// metadata is serialized.  starts are the offsets of the unknown elements in
+// contents.
+func identifyParents(unknown UnknownElements, starts []int64, contents []byte) error {
+	nested := false
+	isUnknown := make(map[int64]bool)
+	for i, u := range unknown {
+		isUnknown[starts[i]] = true
+		nested = nested || len(u.Parent) > 0
+	}
+	if !nested {
+		return nil
+	}
+	elements, err := ScanElements(contents, func(start int64) bool {
+		return isUnknown[start]
+	})
+	if err != nil {
+		return err
+	}
+	for i := range unknown {
+		u := &unknown[i]
+		if len(u.Parent) == 0 {
+			continue
+		}
+		seen := make(map[string]int)
+		for _, e := range elements {
+			if !slices.Equal(e.Path, u.Parent) {
+				continue
+			}
+			if e.Start < starts[i] && starts[i] < e.End {
+				u.ParentKey = e.Key
+				u.ParentIndex = seen[e.Key]
+				break
+			}
+			seen[e.Key]++
+		}
+	}
+	return nil
+}
+
+// ScanElements returns the elements of an xml document in the order their
+// end tags appear.  Elements whose start tag is at an offset for which skip
+// returns true are left out, along with their content.
+func ScanElements(contents []byte, skip func(start int64) bool) ([]Element, error) {
+	type openElement struct {
+		Element
+		key strings.Builder
+	}
+	var stack []*openElement
+	var elements []Element
+	dec := xml.NewDecoder(bytes.NewReader(contents))
+	skipping := 0
+	for {
+		offset := dec.InputOffset()
+		t, err := dec.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if skipping > 0 {
+			switch t.(type) {
+			case xml.StartElement:
+				skipping++
+			case xml.EndElement:
+				skipping--
+			}
+			continue
+		}
+		var text string
+		switch e := t.(type) {
+		case xml.StartElement:
+			if skip != nil && len(stack) > 0 && skip(offset) {
+				skipping = 1
+				continue
+			}
+			el := &openElement{Element: Element{Start: offset, ContentStart: dec.InputOffset()}}
+			if len(stack) > 0 {
+				el.Path = append(append([]string(nil), stack[len(stack)-1].Path...), e.Name.Local)
+			}
+			stack = append(stack, el)
+			text = canonicalStart(e)
+		case xml.EndElement:
+			text = "</" + e.Name.Local + ">"
+		case xml.CharData:
+			if trimmed := strings.TrimSpace(string(e)); trimmed != "" {
+				var b bytes.Buffer
+				xml.EscapeText(&b, []byte(trimmed))
+				text = b.String()
+			}
+		}
+		for _, el := range stack {
+			el.key.WriteString(text)
+		}
+		if _, ok := t.(xml.EndElement); ok && len(stack) > 0 {
+			el := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			el.End = dec.InputOffset()
+			el.Key = el.key.String()
+			elements = append(elements, el.Element)
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.Children = append(parent.Children, ChildElement{Name: el.Path[len(el.Path)-1], End: el.End})
+			}
+		}
+	}
+	return elements, nil
+}
+
+func canonicalStart(e xml.StartElement) string {
+	attrs := make([]string, 0, len(e.Attr))
+	for _, a := range e.Attr {
+		var b bytes.Buffer
+		xml.EscapeText(&b, []byte(a.Value))
+		name := a.Name.Local
+		if a.Name.Space != "" {
+			name = a.Name.Space + ":" + name
+		}
+		attrs = append(attrs, " "+name+`="`+b.String()+`"`)
+	}
+	sort.Strings(attrs)
+	return "<" + e.Name.Local + strings.Join(attrs, "") + ">"
+}
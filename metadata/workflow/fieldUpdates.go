@@ -0,0 +1,100 @@
+package workflow
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	. "github.com/ForceCLI/force-md/general"
+)
+
+var FieldUpdateExistsError = errors.New("field update already exists")
+
+type FieldUpdateFilter func(FieldUpdate) bool
+
+func (w *Workflow) GetFieldUpdates(filters ...FieldUpdateFilter) []FieldUpdate {
+	var updates []FieldUpdate
+UPDATES:
+	for _, u := range w.FieldUpdates {
+		for _, filter := range filters {
+			if !filter(u) {
+				continue UPDATES
+			}
+		}
+		updates = append(updates, u)
+	}
+	return updates
+}
+
+func (w *Workflow) AddFieldUpdate(update FieldUpdate) error {
+	for _, u := range w.FieldUpdates {
+		if strings.EqualFold(u.FullName.Text, update.FullName.Text) {
+			return FieldUpdateExistsError
+		}
+	}
+	w.FieldUpdates = append(w.FieldUpdates, update)
+	sort.SliceStable(w.FieldUpdates, func(i, j int) bool {
+		return w.FieldUpdates[i].FullName.Text < w.FieldUpdates[j].FullName.Text
+	})
+	return nil
+}
+
+// GetFieldUpdate returns a copy of the field update with the name
+func (w *Workflow) GetFieldUpdate(name string) (FieldUpdate, error) {
+	for _, u := range w.FieldUpdates {
+		if strings.EqualFold(u.FullName.Text, name) {
+			return u, nil
+		}
+	}
+	return FieldUpdate{}, errors.New("field update not found")
+}
+
+// UpdateFieldUpdate replaces the field update with the name
+func (w *Workflow) UpdateFieldUpdate(name string, update FieldUpdate) error {
+	for i, u := range w.FieldUpdates {
+		if strings.EqualFold(u.FullName.Text, name) {
+			w.FieldUpdates[i] = update
+			return nil
+		}
+	}
+	return errors.New("field update not found")
+}
+
+// SetOperation sets the operation of a field update and removes the values
+// that the operation doesn't use
+func (u *FieldUpdate) SetOperation(operation string) {
+	u.Operation = &TextLiteral{Text: operation}
+	if operation != "Formula" {
+		u.Formula = nil
+	}
+	if operation != "Literal" {
+		u.LiteralValue = nil
+	}
+	if operation != "LookupValue" {
+		u.LookupValue = nil
+		u.LookupValueType = nil
+	}
+}
+
+// DeleteFieldUpdate deletes a field update.  Field updates used by rules can't
+// be deleted.
+func (w *Workflow) DeleteFieldUpdate(name string) error {
+	if rule, ok := w.actionUsedBy(name, "FieldUpdate"); ok {
+		return errors.Errorf("field update used by rule %s", rule)
+	}
+	found := false
+	newUpdates := w.FieldUpdates[:0]
+	for _, u := range w.FieldUpdates {
+		if strings.EqualFold(u.FullName.Text, name) {
+			found = true
+		} else {
+			newUpdates = append(newUpdates, u)
+		}
+	}
+	if !found {
+		return errors.New("field update not found")
+	}
+	w.FieldUpdates = newUpdates
+	return nil
+}
@@ -1,9 +1,14 @@
 package workflow
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	. "github.com/ForceCLI/force-md/general"
+	"github.com/ForceCLI/force-md/internal/formula"
 )
 
 type RuleFilter func(Rule) bool
@@ -37,10 +42,6 @@ ALERTS:
 	return alerts
 }
 
-func (w *Workflow) GetFieldUpdates() []FieldUpdate {
-	return w.FieldUpdates
-}
-
 func (o *Workflow) DeleteRule(ruleName string) error {
 	found := false
 	newRules := o.Rules[:0]
@@ -57,3 +58,225 @@ func (o *Workflow) DeleteRule(ruleName string) error {
 	o.Rules = newRules
 	return nil
 }
+
+var ActionExistsError = errors.New("action already exists")
+var CriteriaItemExistsError = errors.New("criteria item already exists")
+
+func (w *Workflow) rule(ruleName string) (*Rule, error) {
+	for i, r := range w.Rules {
+		if strings.EqualFold(r.FullName, ruleName) {
+			return &w.Rules[i], nil
+		}
+	}
+	return nil, errors.New("rule not found")
+}
+
+func (w *Workflow) SetRuleActive(ruleName string, active bool) error {
+	r, err := w.rule(ruleName)
+	if err != nil {
+		return err
+	}
+	r.Active = FalseText
+	if active {
+		r.Active = TrueText
+	}
+	return nil
+}
+
+// AddRuleAction adds an immediate action to a rule.  Field updates, alerts,
+// and tasks must exist in the workflow.
+func (w *Workflow) AddRuleAction(ruleName, actionName, actionType string) error {
+	r, err := w.rule(ruleName)
+	if err != nil {
+		return err
+	}
+	for _, a := range r.Actions {
+		if strings.EqualFold(a.Name.Text, actionName) && strings.EqualFold(a.Type.Text, actionType) {
+			return ActionExistsError
+		}
+	}
+	if !w.hasAction(actionName, actionType) {
+		return errors.Errorf("%s not found: %s", actionType, actionName)
+	}
+	var action Action
+	action.Name.Text = actionName
+	action.Type.Text = actionType
+	r.Actions = append(r.Actions, action)
+	return nil
+}
+
+// DeleteRuleAction removes an immediate action from a rule.  If actionType is
+// empty, actions of any type with the name are removed.
+func (w *Workflow) DeleteRuleAction(ruleName, actionName, actionType string) error {
+	r, err := w.rule(ruleName)
+	if err != nil {
+		return err
+	}
+	found := false
+	newActions := r.Actions[:0]
+	for _, a := range r.Actions {
+		if strings.EqualFold(a.Name.Text, actionName) && (actionType == "" || strings.EqualFold(a.Type.Text, actionType)) {
+			found = true
+		} else {
+			newActions = append(newActions, a)
+		}
+	}
+	if !found {
+		return errors.New("action not found")
+	}
+	r.Actions = newActions
+	return nil
+}
+
+func (w *Workflow) hasAction(name, actionType string) bool {
+	switch actionType {
+	case "FieldUpdate":
+		return len(w.GetFieldUpdates(func(u FieldUpdate) bool { return strings.EqualFold(u.FullName.Text, name) })) > 0
+	case "Alert":
+		return len(w.GetAlerts(func(a Alert) bool { return strings.EqualFold(a.FullName, name) })) > 0
+	case "Task":
+		return len(w.GetTasks(func(t Task) bool { return strings.EqualFold(t.FullName.Text, name) })) > 0
+	}
+	// Other actions, such as outbound messages, aren't stored in the workflow
+	return true
+}
+
+// actionUsedBy returns the name of a rule that uses an action, if any
+func (w *Workflow) actionUsedBy(name, actionType string) (string, bool) {
+	for _, r := range w.Rules {
+		for _, a := range ruleActions(r) {
+			if strings.EqualFold(a.Name.Text, name) && a.Type.Text == actionType {
+				return r.FullName, true
+			}
+		}
+	}
+	return "", false
+}
+
+// ruleActions returns the immediate and time-dependent actions of a rule
+func ruleActions(r Rule) []Action {
+	actions := r.Actions[:len(r.Actions):len(r.Actions)]
+	for _, t := range r.WorkflowTimeTriggers {
+		actions = append(actions, t.Actions...)
+	}
+	return actions
+}
+
+// AddCriteriaItem adds a criteria item to a rule.  The field is qualified
+// with the object, e.g. Account.Type.  If the rule has a boolean filter, the
+// new item is ANDed with it.
+func (w *Workflow) AddCriteriaItem(ruleName string, item CriteriaItem) error {
+	r, err := w.rule(ruleName)
+	if err != nil {
+		return err
+	}
+	if r.Formula != nil && r.Formula.String() != "" {
+		return errors.New("rule uses a formula")
+	}
+	for _, c := range r.CriteriaItems {
+		if strings.EqualFold(c.Field.Text, item.Field.Text) && c.Operation.Text == item.Operation.Text {
+			return CriteriaItemExistsError
+		}
+	}
+	r.CriteriaItems = append(r.CriteriaItems, item)
+	if r.BooleanFilter != nil && r.BooleanFilter.String() != "" {
+		r.BooleanFilter.Text = fmt.Sprintf("(%s) AND %d", r.BooleanFilter.Text, len(r.CriteriaItems))
+	}
+	return nil
+}
+
+// UpdateCriteriaItems changes the operation and value of the criteria items
+// on a field
+func (w *Workflow) UpdateCriteriaItems(ruleName string, item CriteriaItem) error {
+	r, err := w.rule(ruleName)
+	if err != nil {
+		return err
+	}
+	found := false
+	for i, c := range r.CriteriaItems {
+		if strings.EqualFold(c.Field.Text, item.Field.Text) {
+			found = true
+			if item.Operation.Text != "" {
+				r.CriteriaItems[i].Operation = item.Operation
+			}
+			if item.Value != nil {
+				r.CriteriaItems[i].Value = item.Value
+			}
+		}
+	}
+	if !found {
+		return errors.New("criteria item not found")
+	}
+	return nil
+}
+
+// DeleteCriteriaItems removes the criteria items on a field.  Because boolean
+// filters refer to criteria items by number, a new boolean filter must be
+// given if the rule has one.
+func (w *Workflow) DeleteCriteriaItems(ruleName string, field string, booleanFilter string) error {
+	r, err := w.rule(ruleName)
+	if err != nil {
+		return err
+	}
+	if r.BooleanFilter != nil && r.BooleanFilter.String() != "" && booleanFilter == "" {
+		return errors.New("rule has a boolean filter; a new boolean filter is required")
+	}
+	found := false
+	newItems := r.CriteriaItems[:0]
+	for _, c := range r.CriteriaItems {
+		if strings.EqualFold(c.Field.Text, field) {
+			found = true
+		} else {
+			newItems = append(newItems, c)
+		}
+	}
+	if !found {
+		return errors.New("criteria item not found")
+	}
+	r.CriteriaItems = newItems
+	if booleanFilter != "" {
+		r.BooleanFilter = &TextLiteral{Text: booleanFilter}
+	}
+	return nil
+}
+
+// RuleReferencesField returns a filter for rules whose criteria items,
+// formula, field updates, or time triggers reference a field of the
+// workflow's object
+func (w *Workflow) RuleReferencesField(object, field string) RuleFilter {
+	qualified := object + "." + field
+	return func(r Rule) bool {
+		for _, c := range r.CriteriaItems {
+			if strings.EqualFold(c.Field.Text, qualified) {
+				return true
+			}
+		}
+		if r.Formula != nil {
+			references, err := formula.FieldReferences(r.Formula.String())
+			if err != nil {
+				log.Warnf("parsing formula for %s failed: %s", r.FullName, err.Error())
+			}
+			for _, ref := range references {
+				if strings.EqualFold(ref, field) {
+					return true
+				}
+			}
+		}
+		for _, a := range ruleActions(r) {
+			if a.Type.Text != "FieldUpdate" {
+				continue
+			}
+			for _, u := range w.FieldUpdates {
+				if strings.EqualFold(u.FullName.Text, a.Name.Text) && (u.TargetObject == nil || u.TargetObject.String() == "") && strings.EqualFold(u.Field.Text, field) {
+					return true
+				}
+			}
+		}
+		for _, t := range r.WorkflowTimeTriggers {
+			if strings.EqualFold(t.OffsetFromField.String(), qualified) {
+				return true
+			}
+		}
+		return false
+	}
+}
@@ -0,0 +1,81 @@
+package workflow
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var TaskExistsError = errors.New("task already exists")
+
+type TaskFilter func(Task) bool
+
+func (w *Workflow) GetTasks(filters ...TaskFilter) []Task {
+	var tasks []Task
+TASKS:
+	for _, t := range w.Tasks {
+		for _, filter := range filters {
+			if !filter(t) {
+				continue TASKS
+			}
+		}
+		tasks = append(tasks, t)
+	}
+	return tasks
+}
+
+func (w *Workflow) AddTask(task Task) error {
+	for _, t := range w.Tasks {
+		if strings.EqualFold(t.FullName.Text, task.FullName.Text) {
+			return TaskExistsError
+		}
+	}
+	w.Tasks = append(w.Tasks, task)
+	sort.SliceStable(w.Tasks, func(i, j int) bool {
+		return w.Tasks[i].FullName.Text < w.Tasks[j].FullName.Text
+	})
+	return nil
+}
+
+// GetTask returns a copy of the task with the name
+func (w *Workflow) GetTask(name string) (Task, error) {
+	for _, t := range w.Tasks {
+		if strings.EqualFold(t.FullName.Text, name) {
+			return t, nil
+		}
+	}
+	return Task{}, errors.New("task not found")
+}
+
+// UpdateTask replaces the task with the name
+func (w *Workflow) UpdateTask(name string, task Task) error {
+	for i, t := range w.Tasks {
+		if strings.EqualFold(t.FullName.Text, name) {
+			w.Tasks[i] = task
+			return nil
+		}
+	}
+	return errors.New("task not found")
+}
+
+// DeleteTask deletes a task.  Tasks used by rules can't be deleted.
+func (w *Workflow) DeleteTask(name string) error {
+	if rule, ok := w.actionUsedBy(name, "Task"); ok {
+		return errors.Errorf("task used by rule %s", rule)
+	}
+	found := false
+	newTasks := w.Tasks[:0]
+	for _, t := range w.Tasks {
+		if strings.EqualFold(t.FullName.Text, name) {
+			found = true
+		} else {
+			newTasks = append(newTasks, t)
+		}
+	}
+	if !found {
+		return errors.New("task not found")
+	}
+	w.Tasks = newTasks
+	return nil
+}
@@ -51,43 +51,38 @@ type FieldUpdate struct {
 	TargetObject       *TextLiteral `xml:"targetObject"`
 }
 
+type Action struct {
+	Name struct {
+		Text string `xml:",chardata"`
+	} `xml:"name"`
+	Type struct {
+		Text string `xml:",chardata"`
+	} `xml:"type"`
+}
+
+type CriteriaItem struct {
+	Field struct {
+		Text string `xml:",chardata"`
+	} `xml:"field"`
+	Operation struct {
+		Text string `xml:",chardata"`
+	} `xml:"operation"`
+	Value *TextLiteral `xml:"value"`
+}
+
 type Rule struct {
-	FullName string `xml:"fullName"`
-	Actions  []struct {
-		Name struct {
-			Text string `xml:",chardata"`
-		} `xml:"name"`
-		Type struct {
-			Text string `xml:",chardata"`
-		} `xml:"type"`
-	} `xml:"actions"`
-	Active        BooleanText  `xml:"active"`
-	BooleanFilter *TextLiteral `xml:"booleanFilter"`
-	CriteriaItems []struct {
-		Field struct {
-			Text string `xml:",chardata"`
-		} `xml:"field"`
-		Operation struct {
-			Text string `xml:",chardata"`
-		} `xml:"operation"`
-		Value *TextLiteral `xml:"value"`
-	} `xml:"criteriaItems"`
-	Description          *TextLiteral `xml:"description"`
-	Formula              *TextLiteral `xml:"formula"`
-	TriggerType          *TextLiteral `xml:"triggerType"`
-	WorkflowTimeTriggers *struct {
-		Actions struct {
-			Name struct {
-				Text string `xml:",chardata"`
-			} `xml:"name"`
-			Type struct {
-				Text string `xml:",chardata"`
-			} `xml:"type"`
-		} `xml:"actions"`
-		OffsetFromField struct {
-			Text string `xml:",chardata"`
-		} `xml:"offsetFromField"`
-		TimeLength struct {
+	FullName             string         `xml:"fullName"`
+	Actions              []Action       `xml:"actions"`
+	Active               BooleanText    `xml:"active"`
+	BooleanFilter        *TextLiteral   `xml:"booleanFilter"`
+	CriteriaItems        []CriteriaItem `xml:"criteriaItems"`
+	Description          *TextLiteral   `xml:"description"`
+	Formula              *TextLiteral   `xml:"formula"`
+	TriggerType          *TextLiteral   `xml:"triggerType"`
+	WorkflowTimeTriggers []struct {
+		Actions         []Action     `xml:"actions"`
+		OffsetFromField *TextLiteral `xml:"offsetFromField"`
+		TimeLength      struct {
 			Text string `xml:",chardata"`
 		} `xml:"timeLength"`
 		WorkflowTimeTriggerUnit struct {
@@ -100,19 +95,20 @@ type Task struct {
 	FullName struct {
 		Text string `xml:",chardata"`
 	} `xml:"fullName"`
-	AssignedTo struct {
+	AssignedTo *struct {
 		Text string `xml:",chardata"`
 	} `xml:"assignedTo"`
 	AssignedToType struct {
 		Text string `xml:",chardata"`
 	} `xml:"assignedToType"`
+	Description   *TextLiteral `xml:"description"`
 	DueDateOffset struct {
 		Text string `xml:",chardata"`
 	} `xml:"dueDateOffset"`
 	NotifyAssignee struct {
 		Text string `xml:",chardata"`
 	} `xml:"notifyAssignee"`
-	OffsetFromField struct {
+	OffsetFromField *struct {
 		Text string `xml:",chardata"`
 	} `xml:"offsetFromField"`
 	Priority struct {
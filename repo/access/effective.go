@@ -0,0 +1,324 @@
+package access
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/ForceCLI/force-md/metadata"
+	"github.com/ForceCLI/force-md/metadata/mutingpermissionset"
+	"github.com/ForceCLI/force-md/metadata/permissionGranter"
+	"github.com/ForceCLI/force-md/metadata/permissionset"
+	"github.com/ForceCLI/force-md/metadata/permissionsetgroup"
+	"github.com/ForceCLI/force-md/metadata/profile"
+	"github.com/ForceCLI/force-md/repo"
+)
+
+type ObjectAccess struct {
+	Object    string `json:"object"`
+	Create    bool   `json:"create"`
+	Read      bool   `json:"read"`
+	Edit      bool   `json:"edit"`
+	Delete    bool   `json:"delete"`
+	ViewAll   bool   `json:"viewAll"`
+	ModifyAll bool   `json:"modifyAll"`
+}
+
+type FieldAccess struct {
+	Field string `json:"field"`
+	Read  bool   `json:"read"`
+	Edit  bool   `json:"edit"`
+}
+
+type TabAccess struct {
+	Tab        string `json:"tab"`
+	Visibility string `json:"visibility"`
+}
+
+// Access is the combined access granted by a profile, permission sets, and
+// permission set groups
+type Access struct {
+	Objects           []ObjectAccess `json:"objects"`
+	Fields            []FieldAccess  `json:"fields"`
+	UserPermissions   []string       `json:"userPermissions"`
+	ApexClasses       []string       `json:"apexClasses"`
+	Pages             []string       `json:"pages"`
+	Tabs              []TabAccess    `json:"tabs"`
+	CustomPermissions []string       `json:"customPermissions"`
+}
+
+type grants struct {
+	objects           map[string]*ObjectAccess
+	fields            map[string]*FieldAccess
+	userPermissions   map[string]bool
+	apexClasses       map[string]bool
+	pages             map[string]bool
+	tabs              map[string]string
+	customPermissions map[string]bool
+}
+
+func newGrants() *grants {
+	return &grants{
+		objects:           make(map[string]*ObjectAccess),
+		fields:            make(map[string]*FieldAccess),
+		userPermissions:   make(map[string]bool),
+		apexClasses:       make(map[string]bool),
+		pages:             make(map[string]bool),
+		tabs:              make(map[string]string),
+		customPermissions: make(map[string]bool),
+	}
+}
+
+func setAll(set map[string]bool, names []string) {
+	for _, n := range names {
+		set[n] = true
+	}
+}
+
+// add adds the permissions granted by g
+func (a *grants) add(g permissionGranter.PermissionGranter) {
+	for _, o := range g.GetGrantedObjectPermissions() {
+		a.addObject(ObjectAccess{
+			Object:    o.Object,
+			Create:    o.AllowCreate.ToBool(),
+			Read:      o.AllowRead.ToBool(),
+			Edit:      o.AllowEdit.ToBool(),
+			Delete:    o.AllowDelete.ToBool(),
+			ViewAll:   o.ViewAllRecords.ToBool(),
+			ModifyAll: o.ModifyAllRecords.ToBool(),
+		})
+	}
+	for _, f := range g.GetGrantedFieldPermissions() {
+		a.addField(FieldAccess{Field: f.Field, Read: f.Readable.ToBool(), Edit: f.Editable.ToBool()})
+	}
+	setAll(a.userPermissions, g.GetEnabledUserPermissions())
+	setAll(a.apexClasses, g.GetEnabledClasses())
+	setAll(a.pages, g.GetEnabledPageAccesses())
+	setAll(a.customPermissions, g.GetEnabledCustomPermissions())
+	for _, t := range g.GetVisibleTabs() {
+		a.addTab(t.Tab, t.Visibility)
+	}
+}
+
+func (a *grants) addObject(o ObjectAccess) {
+	existing, ok := a.objects[o.Object]
+	if !ok {
+		a.objects[o.Object] = &o
+		return
+	}
+	existing.Create = existing.Create || o.Create
+	existing.Read = existing.Read || o.Read
+	existing.Edit = existing.Edit || o.Edit
+	existing.Delete = existing.Delete || o.Delete
+	existing.ViewAll = existing.ViewAll || o.ViewAll
+	existing.ModifyAll = existing.ModifyAll || o.ModifyAll
+}
+
+func (a *grants) addField(f FieldAccess) {
+	existing, ok := a.fields[f.Field]
+	if !ok {
+		a.fields[f.Field] = &f
+		return
+	}
+	existing.Read = existing.Read || f.Read
+	existing.Edit = existing.Edit || f.Edit
+}
+
+// addTab keeps the most visible setting for the tab.  Visible tabs are shown
+// by default, and Available tabs can be added by the user.
+func (a *grants) addTab(tab, visibility string) {
+	if strings.EqualFold(visibility, "Visible") {
+		a.tabs[tab] = "Visible"
+	} else if _, ok := a.tabs[tab]; !ok {
+		a.tabs[tab] = "Available"
+	}
+}
+
+func (a *grants) merge(b *grants) {
+	for _, o := range b.objects {
+		a.addObject(*o)
+	}
+	for _, f := range b.fields {
+		a.addField(*f)
+	}
+	for n := range b.userPermissions {
+		a.userPermissions[n] = true
+	}
+	for n := range b.apexClasses {
+		a.apexClasses[n] = true
+	}
+	for n := range b.pages {
+		a.pages[n] = true
+	}
+	for n := range b.customPermissions {
+		a.customPermissions[n] = true
+	}
+	for t, v := range b.tabs {
+		a.addTab(t, v)
+	}
+}
+
+// mute removes the permissions enabled in the muting permission set.  Muting
+// a permission also mutes the permissions that depend on it, e.g. muting
+// Read on an object mutes all access to the object.
+func (a *grants) mute(m *mutingpermissionset.MutingPermissionSet) {
+	for _, o := range m.ObjectPermissions {
+		g, ok := a.objects[o.Object]
+		if !ok {
+			continue
+		}
+		if o.AllowRead.ToBool() {
+			delete(a.objects, o.Object)
+			continue
+		}
+		if o.AllowCreate.ToBool() {
+			g.Create = false
+		}
+		if o.AllowEdit.ToBool() {
+			g.Edit = false
+			g.Delete = false
+			g.ModifyAll = false
+		}
+		if o.AllowDelete.ToBool() || o.ViewAllRecords.ToBool() || o.ModifyAllRecords.ToBool() {
+			g.ModifyAll = false
+		}
+		if o.AllowDelete.ToBool() {
+			g.Delete = false
+		}
+		if o.ViewAllRecords.ToBool() {
+			g.ViewAll = false
+		}
+		if !g.Create && !g.Read && !g.Edit && !g.Delete && !g.ViewAll && !g.ModifyAll {
+			delete(a.objects, o.Object)
+		}
+	}
+	for _, f := range m.FieldPermissions {
+		g, ok := a.fields[f.Field]
+		if !ok {
+			continue
+		}
+		if f.Readable.ToBool() {
+			delete(a.fields, f.Field)
+			continue
+		}
+		if f.Editable.ToBool() {
+			g.Edit = false
+		}
+	}
+	for _, u := range m.UserPermissions {
+		if u.Enabled.ToBool() {
+			delete(a.userPermissions, u.Name)
+		}
+	}
+	for _, c := range m.ClassAccesses {
+		if c.Enabled.ToBool() {
+			delete(a.apexClasses, c.ApexClass)
+		}
+	}
+	for _, p := range m.PageAccesses {
+		if p.Enabled.ToBool() {
+			delete(a.pages, p.ApexPage)
+		}
+	}
+	for _, c := range m.CustomPermissions {
+		if c.Enabled.ToBool() {
+			delete(a.customPermissions, c.Name)
+		}
+	}
+	for _, t := range m.TabSettings {
+		if !strings.EqualFold(t.Visibility, "None") {
+			delete(a.tabs, t.Tab)
+		}
+	}
+}
+
+func sortedNames(set map[string]bool) []string {
+	names := make([]string, 0, len(set))
+	for n := range set {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (a *grants) access() Access {
+	var result Access
+	for _, o := range a.objects {
+		result.Objects = append(result.Objects, *o)
+	}
+	sort.Slice(result.Objects, func(i, j int) bool {
+		return result.Objects[i].Object < result.Objects[j].Object
+	})
+	for _, f := range a.fields {
+		result.Fields = append(result.Fields, *f)
+	}
+	sort.Slice(result.Fields, func(i, j int) bool {
+		return result.Fields[i].Field < result.Fields[j].Field
+	})
+	for t, v := range a.tabs {
+		result.Tabs = append(result.Tabs, TabAccess{Tab: t, Visibility: v})
+	}
+	sort.Slice(result.Tabs, func(i, j int) bool {
+		return result.Tabs[i].Tab < result.Tabs[j].Tab
+	})
+	result.UserPermissions = sortedNames(a.userPermissions)
+	result.ApexClasses = sortedNames(a.apexClasses)
+	result.Pages = sortedNames(a.pages)
+	result.CustomPermissions = sortedNames(a.customPermissions)
+	return result
+}
+
+// Effective returns the access granted by the profile, permission sets, and
+// permission set groups, which must be open in r.  The profile is optional.
+// The permission sets in each group are expanded, and the group's muting
+// permission sets are applied to them.
+func Effective(r *repo.Repo, profileName string, permissionSets []string, groups []string) (Access, error) {
+	result := newGrants()
+	if profileName != "" {
+		p, ok := r.Items(profile.NAME)[metadata.MetadataObjectName(profileName)]
+		if !ok {
+			return Access{}, errors.New("profile not found: " + profileName)
+		}
+		result.add(p.(*profile.Profile))
+	}
+	for _, name := range permissionSets {
+		p, err := openPermissionSet(r, name)
+		if err != nil {
+			return Access{}, err
+		}
+		result.add(p)
+	}
+	for _, name := range groups {
+		m, ok := r.Items(permissionsetgroup.NAME)[metadata.MetadataObjectName(name)]
+		if !ok {
+			return Access{}, errors.New("permission set group not found: " + name)
+		}
+		g := m.(*permissionsetgroup.PermissionSetGroup)
+		group := newGrants()
+		for _, ps := range g.PermissionSets {
+			p, err := openPermissionSet(r, ps.Text)
+			if err != nil {
+				return Access{}, errors.Wrap(err, "expanding permission set group "+name)
+			}
+			group.add(p)
+		}
+		for _, ms := range g.MutingPermissionSets {
+			m, ok := r.Items(mutingpermissionset.NAME)[metadata.MetadataObjectName(ms.Text)]
+			if !ok {
+				return Access{}, errors.New("muting permission set not found: " + ms.Text)
+			}
+			group.mute(m.(*mutingpermissionset.MutingPermissionSet))
+		}
+		result.merge(group)
+	}
+	return result.access(), nil
+}
+
+func openPermissionSet(r *repo.Repo, name string) (*permissionset.PermissionSet, error) {
+	p, ok := r.Items(permissionset.NAME)[metadata.MetadataObjectName(name)]
+	if !ok {
+		return nil, errors.New("permission set not found: " + name)
+	}
+	return p.(*permissionset.PermissionSet), nil
+}
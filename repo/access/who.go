@@ -0,0 +1,144 @@
+package access
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/ForceCLI/force-md/metadata"
+	"github.com/ForceCLI/force-md/metadata/mutingpermissionset"
+	"github.com/ForceCLI/force-md/metadata/permissionGranter"
+	"github.com/ForceCLI/force-md/metadata/permissionset"
+	"github.com/ForceCLI/force-md/metadata/permissionsetgroup"
+	"github.com/ForceCLI/force-md/metadata/profile"
+	"github.com/ForceCLI/force-md/repo"
+)
+
+// GroupGrant is a permission set group that includes a granting permission
+// set, with the permissions that remain after muting
+type GroupGrant struct {
+	Name        string
+	Permissions []string
+}
+
+// Grant is a profile or permission set that grants access to a component
+type Grant struct {
+	Type        metadata.MetadataType
+	Name        string
+	Path        string
+	Permissions []string
+	Groups      []GroupGrant
+}
+
+// WhoCanAccess returns the profiles and permission sets open in r that grant
+// access to a field (Object.Field), an object, or a user permission.  Names
+// are matched case-insensitively.  The permission set groups that include
+// each granting permission set are included.
+func WhoCanAccess(r *repo.Repo, component string) []Grant {
+	var result []Grant
+	for name, m := range r.Items(profile.NAME) {
+		if g, ok := grantFor(m.(*profile.Profile), component); ok {
+			g.Type = profile.NAME
+			g.Name = string(name)
+			result = append(result, g)
+		}
+	}
+	groups := groupsByPermissionSet(r)
+	for name, m := range r.Items(permissionset.NAME) {
+		p := m.(*permissionset.PermissionSet)
+		g, ok := grantFor(p, component)
+		if !ok {
+			continue
+		}
+		g.Type = permissionset.NAME
+		g.Name = string(name)
+		for _, group := range groups[strings.ToLower(string(name))] {
+			granted := newGrants()
+			granted.add(p)
+			for _, muting := range group.MutingPermissionSets {
+				if ms, ok := r.Items(mutingpermissionset.NAME)[metadata.MetadataObjectName(muting.Text)]; ok {
+					granted.mute(ms.(*mutingpermissionset.MutingPermissionSet))
+				}
+			}
+			g.Groups = append(g.Groups, GroupGrant{
+				Name:        string(group.GetMetadataInfo().Name()),
+				Permissions: granted.permissionsFor(component),
+			})
+		}
+		sort.Slice(g.Groups, func(i, j int) bool {
+			return g.Groups[i].Name < g.Groups[j].Name
+		})
+		result = append(result, g)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Path < result[j].Path
+	})
+	return result
+}
+
+func grantFor(p permissionGranter.PermissionGranter, component string) (Grant, bool) {
+	granted := newGrants()
+	granted.add(p)
+	permissions := granted.permissionsFor(component)
+	if len(permissions) == 0 {
+		return Grant{}, false
+	}
+	return Grant{
+		Path:        string(p.(metadata.RegisterableMetadata).GetMetadataInfo().Path()),
+		Permissions: permissions,
+	}, true
+}
+
+func groupsByPermissionSet(r *repo.Repo) map[string][]*permissionsetgroup.PermissionSetGroup {
+	groups := make(map[string][]*permissionsetgroup.PermissionSetGroup)
+	for _, m := range r.Items(permissionsetgroup.NAME) {
+		g := m.(*permissionsetgroup.PermissionSetGroup)
+		for _, p := range g.PermissionSets {
+			name := strings.ToLower(p.Text)
+			groups[name] = append(groups[name], g)
+		}
+	}
+	return groups
+}
+
+// permissionsFor returns the permissions granted on a field, object, or user
+// permission
+func (a *grants) permissionsFor(component string) []string {
+	var permissions []string
+	for _, f := range a.fields {
+		if !strings.EqualFold(f.Field, component) {
+			continue
+		}
+		if f.Read {
+			permissions = append(permissions, "read")
+		}
+		if f.Edit {
+			permissions = append(permissions, "edit")
+		}
+	}
+	for _, o := range a.objects {
+		if !strings.EqualFold(o.Object, component) {
+			continue
+		}
+		for _, p := range []struct {
+			name    string
+			granted bool
+		}{
+			{"create", o.Create},
+			{"read", o.Read},
+			{"edit", o.Edit},
+			{"delete", o.Delete},
+			{"view all", o.ViewAll},
+			{"modify all", o.ModifyAll},
+		} {
+			if p.granted {
+				permissions = append(permissions, p.name)
+			}
+		}
+	}
+	for u := range a.userPermissions {
+		if strings.EqualFold(u, component) {
+			permissions = append(permissions, "enabled")
+		}
+	}
+	return permissions
+}
@@ -0,0 +1,381 @@
+// Package automation builds an inventory of the automation that runs when
+// records of an object are saved.
+package automation
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ForceCLI/force-md/metadata"
+	assignmentRules "github.com/ForceCLI/force-md/metadata/assignmentRules"
+	autoResponseRules "github.com/ForceCLI/force-md/metadata/autoResponseRules"
+	duplicateRule "github.com/ForceCLI/force-md/metadata/duplicateRules"
+	"github.com/ForceCLI/force-md/metadata/flow"
+	"github.com/ForceCLI/force-md/metadata/objects"
+	"github.com/ForceCLI/force-md/metadata/objects/validationrule"
+	trigger "github.com/ForceCLI/force-md/metadata/triggers"
+	"github.com/ForceCLI/force-md/metadata/workflow"
+	"github.com/ForceCLI/force-md/repo"
+)
+
+// Phase is a stage of the save order of execution
+type Phase string
+
+const (
+	BeforeSave Phase = "before-save"
+	Validation Phase = "validation"
+	AfterSave  Phase = "after-save"
+	Workflow   Phase = "workflow"
+	Async      Phase = "async"
+)
+
+// Phases are in the order they run
+var Phases = []Phase{BeforeSave, Validation, AfterSave, Workflow, Async}
+
+// Events that cause automation to run
+const (
+	Create   = "create"
+	Update   = "update"
+	Delete   = "delete"
+	Undelete = "undelete"
+)
+
+// Automation is a component that runs when records are saved
+type Automation struct {
+	Phase Phase
+	// The type of automation, e.g. Flow or ApexTrigger
+	Type   string
+	Name   string
+	Events []string
+	Active bool
+	// The trigger order of a record-triggered flow, if defined
+	Order string
+	Path  string
+	// The step in the order of execution, used to sort automation within
+	// a phase
+	step int
+}
+
+// Steps of the order of execution
+const (
+	stepBeforeSaveFlow = iota
+	stepBeforeTrigger
+	stepValidationRule
+	stepDuplicateRule
+	stepAfterTrigger
+	stepAssignmentRule
+	stepAutoResponseRule
+	stepWorkflowRule
+	stepAfterSaveFlow
+	stepTimeTrigger
+	stepScheduledPath
+)
+
+// Map returns the automation that runs when records of an object are
+// created, updated, deleted, or undeleted, in order of execution
+func Map(r *repo.Repo, object string) []Automation {
+	m := &mapper{object: object}
+	for _, i := range r.Items(flow.NAME) {
+		m.addFlow(i.(*flow.Flow))
+	}
+	for _, i := range r.Items(trigger.NAME) {
+		m.addTrigger(i.(*trigger.ApexTrigger))
+	}
+	for name, i := range r.Items(objects.NAME) {
+		if m.matches(string(name)) {
+			for _, v := range i.(*objects.CustomObject).ValidationRules {
+				m.addValidationRule(v, path(i))
+			}
+		}
+	}
+	for name, i := range r.Items(validationrule.NAME) {
+		if m.matches(objectName(string(name))) {
+			m.addValidationRule(i.(*validationrule.ValidationRule).Rule, path(i))
+		}
+	}
+	for name, i := range r.Items(duplicateRule.NAME) {
+		if m.matches(objectName(string(name))) {
+			m.addDuplicateRule(string(name), i.(*duplicateRule.DuplicateRule))
+		}
+	}
+	for name, i := range r.Items(assignmentRules.NAME) {
+		if m.matches(string(name)) {
+			for _, a := range i.(*assignmentRules.AssignmentRules).AssignmentRule {
+				m.add(Automation{Phase: AfterSave, Type: "AssignmentRule", Name: a.FullName.Text, Events: []string{Create, Update}, Active: a.Active.Text == "true", Path: path(i), step: stepAssignmentRule})
+			}
+		}
+	}
+	for name, i := range r.Items(autoResponseRules.NAME) {
+		if m.matches(string(name)) {
+			for _, a := range i.(*autoResponseRules.AutoResponseRules).AutoResponseRule {
+				m.add(Automation{Phase: AfterSave, Type: "AutoResponseRule", Name: a.FullName.Text, Events: []string{Create}, Active: a.Active.Text == "true", Path: path(i), step: stepAutoResponseRule})
+			}
+		}
+	}
+	// Workflows are named for their object
+	for name, i := range r.Items(workflow.NAME) {
+		if m.matches(string(name)) {
+			for _, rule := range i.(*workflow.Workflow).Rules {
+				m.addWorkflowRule(rule, path(i))
+			}
+		}
+	}
+	sort.SliceStable(m.automation, func(i, j int) bool {
+		a, b := m.automation[i], m.automation[j]
+		if a.Phase != b.Phase {
+			return phaseIndex(a.Phase) < phaseIndex(b.Phase)
+		}
+		if a.step != b.step {
+			return a.step < b.step
+		}
+		if a.Order != b.Order {
+			return orderLess(a.Order, b.Order)
+		}
+		return a.Name < b.Name
+	})
+	return m.automation
+}
+
+type mapper struct {
+	object     string
+	automation []Automation
+}
+
+func (m *mapper) matches(object string) bool {
+	return strings.EqualFold(object, m.object)
+}
+
+func (m *mapper) add(a Automation) {
+	m.automation = append(m.automation, a)
+}
+
+func (m *mapper) addFlow(f *flow.Flow) {
+	s := f.Start
+	if s == nil || s.Object == nil || s.TriggerType == nil || !m.matches(*s.Object) {
+		return
+	}
+	a := Automation{
+		Type:   "Flow",
+		Name:   string(f.GetMetadataInfo().Name()),
+		Active: f.Status != nil && f.Status.Text == "Active",
+		Path:   path(f),
+	}
+	if f.TriggerOrder != nil {
+		a.Order = f.TriggerOrder.Text
+	}
+	switch *s.TriggerType {
+	case "RecordBeforeSave":
+		a.Phase, a.step = BeforeSave, stepBeforeSaveFlow
+	case "RecordBeforeDelete":
+		a.Phase, a.step = BeforeSave, stepBeforeSaveFlow
+		a.Events = []string{Delete}
+	case "RecordAfterSave":
+		a.Phase, a.step = AfterSave, stepAfterSaveFlow
+	default:
+		return
+	}
+	if a.Events == nil && s.RecordTriggerType != nil {
+		switch s.RecordTriggerType.Text {
+		case "Create":
+			a.Events = []string{Create}
+		case "Update":
+			a.Events = []string{Update}
+		case "CreateAndUpdate":
+			a.Events = []string{Create, Update}
+		case "Delete":
+			a.Events = []string{Delete}
+		}
+	}
+	m.add(a)
+	for _, p := range s.ScheduledPaths {
+		name := a.Name
+		if p.Name != nil {
+			name += "." + p.Name.Text
+		}
+		m.add(Automation{Phase: Async, Type: "ScheduledPath", Name: name, Events: a.Events, Active: a.Active, Path: a.Path, step: stepScheduledPath})
+	}
+}
+
+var triggerHeader = regexp.MustCompile(`(?is)\btrigger\s+(\w+)\s+on\s+(\w+)\s*\(([^)]*)\)`)
+
+// addTrigger adds the before and after parts of an Apex trigger, whose object
+// and events are read from its source file
+func (m *mapper) addTrigger(t *trigger.ApexTrigger) {
+	source := strings.TrimSuffix(path(t), "-meta.xml")
+	contents, err := os.ReadFile(source)
+	if err != nil {
+		return
+	}
+	match := triggerHeader.FindStringSubmatch(stripComments(string(contents)))
+	if match == nil || !m.matches(match[2]) {
+		return
+	}
+	var before, after []string
+	for _, e := range strings.Split(match[3], ",") {
+		timing, event, _ := strings.Cut(strings.Join(strings.Fields(strings.ToLower(e)), " "), " ")
+		switch event {
+		case "insert":
+			event = Create
+		case "update":
+			event = Update
+		case "delete":
+			event = Delete
+		case "undelete":
+			event = Undelete
+		default:
+			continue
+		}
+		if timing == "before" {
+			before = append(before, event)
+		} else {
+			after = append(after, event)
+		}
+	}
+	a := Automation{Type: "ApexTrigger", Name: match[1], Active: t.Status.Text == "Active", Path: source}
+	if len(before) > 0 {
+		a.Phase, a.step, a.Events = BeforeSave, stepBeforeTrigger, before
+		m.add(a)
+	}
+	if len(after) > 0 {
+		a.Phase, a.step, a.Events = AfterSave, stepAfterTrigger, after
+		m.add(a)
+	}
+}
+
+var comments = regexp.MustCompile(`(?s)/\*.*?\*/|//[^\n]*`)
+
+func stripComments(s string) string {
+	return comments.ReplaceAllString(s, "")
+}
+
+func (m *mapper) addValidationRule(v validationrule.Rule, path string) {
+	m.add(Automation{Phase: Validation, Type: "ValidationRule", Name: v.FullName, Events: []string{Create, Update}, Active: v.Active.Text == "true", Path: path, step: stepValidationRule})
+}
+
+func (m *mapper) addDuplicateRule(name string, d *duplicateRule.DuplicateRule) {
+	var events []string
+	if d.ActionOnInsert.Text != "" {
+		events = append(events, Create)
+	}
+	if d.ActionOnUpdate.Text != "" {
+		events = append(events, Update)
+	}
+	_, ruleName, _ := strings.Cut(name, ".")
+	m.add(Automation{Phase: Validation, Type: "DuplicateRule", Name: ruleName, Events: events, Active: d.IsActive.Text == "true", Path: path(d), step: stepDuplicateRule})
+}
+
+func (m *mapper) addWorkflowRule(rule workflow.Rule, path string) {
+	events := []string{Create, Update}
+	if rule.TriggerType != nil && rule.TriggerType.String() == "onCreateOnly" {
+		events = []string{Create}
+	}
+	a := Automation{Phase: Workflow, Type: "WorkflowRule", Name: rule.FullName, Events: events, Active: rule.Active.ToBool(), Path: path, step: stepWorkflowRule}
+	m.add(a)
+	for _, t := range rule.WorkflowTimeTriggers {
+		a.Phase, a.Type, a.step = Async, "WorkflowTimeTrigger", stepTimeTrigger
+		a.Name = fmt.Sprintf("%s (%s %s)", rule.FullName, t.TimeLength.Text, t.WorkflowTimeTriggerUnit.Text)
+		m.add(a)
+	}
+}
+
+// Warnings returns problems with the order of execution of the active
+// automation: multiple record-triggered flows that run in the same phase for
+// the same event without distinct trigger orders, and multiple Apex triggers
+// for the same event, whose order is never defined.
+func Warnings(automation []Automation) []string {
+	type key struct {
+		phase Phase
+		event string
+	}
+	flows := make(map[key][]Automation)
+	triggers := make(map[key][]Automation)
+	var keys []key
+	for _, a := range automation {
+		if !a.Active {
+			continue
+		}
+		for _, e := range a.Events {
+			k := key{a.Phase, e}
+			switch a.Type {
+			case "Flow":
+				if len(flows[k]) == 0 && len(triggers[k]) == 0 {
+					keys = append(keys, k)
+				}
+				flows[k] = append(flows[k], a)
+			case "ApexTrigger":
+				if len(flows[k]) == 0 && len(triggers[k]) == 0 {
+					keys = append(keys, k)
+				}
+				triggers[k] = append(triggers[k], a)
+			}
+		}
+	}
+	var warnings []string
+	for _, k := range keys {
+		if f := flows[k]; len(f) > 1 {
+			var unordered []string
+			orders := make(map[string][]string)
+			for _, a := range f {
+				if a.Order == "" {
+					unordered = append(unordered, a.Name)
+				} else {
+					orders[a.Order] = append(orders[a.Order], a.Name)
+				}
+			}
+			if len(unordered) > 0 {
+				warnings = append(warnings, fmt.Sprintf("%d %s flows run on %s without a trigger order: %s", len(f), k.phase, k.event, strings.Join(unordered, ", ")))
+			}
+			var duplicates []string
+			for order, names := range orders {
+				if len(names) > 1 {
+					duplicates = append(duplicates, fmt.Sprintf("%s (%s)", order, strings.Join(names, ", ")))
+				}
+			}
+			sort.Strings(duplicates)
+			for _, d := range duplicates {
+				warnings = append(warnings, fmt.Sprintf("%s flows on %s share trigger order %s", k.phase, k.event, d))
+			}
+		}
+		if t := triggers[k]; len(t) > 1 {
+			var names []string
+			for _, a := range t {
+				names = append(names, a.Name)
+			}
+			warnings = append(warnings, fmt.Sprintf("%d %s Apex triggers run on %s in an undefined order: %s", len(t), k.phase, k.event, strings.Join(names, ", ")))
+		}
+	}
+	return warnings
+}
+
+func path(m metadata.RegisterableMetadata) string {
+	return string(m.GetMetadataInfo().Path())
+}
+
+// Child components in sfdx source format are named Object.Name
+func objectName(name string) string {
+	object, _, _ := strings.Cut(name, ".")
+	return object
+}
+
+func phaseIndex(p Phase) int {
+	for i, phase := range Phases {
+		if p == phase {
+			return i
+		}
+	}
+	return len(Phases)
+}
+
+// orderLess sorts flows by trigger order, with unordered flows last
+func orderLess(a, b string) bool {
+	if a == "" || b == "" {
+		return b == ""
+	}
+	var x, y int
+	fmt.Sscan(a, &x)
+	fmt.Sscan(b, &y)
+	return x < y
+}
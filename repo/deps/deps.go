@@ -0,0 +1,128 @@
+// Package deps indexes the references between metadata components so the
+// impact of changing a component can be assessed.
+package deps
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/ForceCLI/force-md/metadata"
+	"github.com/ForceCLI/force-md/repo"
+)
+
+// The types of components that can be referenced
+const (
+	CustomField  = "CustomField"
+	CustomObject = "CustomObject"
+	Report       = "Report"
+)
+
+// Component identifies a metadata component
+type Component struct {
+	Type string
+	Name string
+}
+
+func (c Component) String() string {
+	return c.Type + ":" + c.Name
+}
+
+// Reference is a reference from a metadata file to a component
+type Reference struct {
+	Source     metadata.MetadataType
+	SourceName string
+	Path       string
+	Target     Component
+	// Where the reference was found within the source, e.g. fieldPermissions
+	Context string
+}
+
+// Index contains the references between the metadata in a repository
+type Index struct {
+	references []Reference
+	resolver   *Resolver
+}
+
+// NewIndex builds an index of the references between all of the metadata
+// open in r.
+func NewIndex(r *repo.Repo) *Index {
+	idx := &Index{resolver: NewResolver(r)}
+	for _, t := range r.Types() {
+		for _, m := range r.Items(t) {
+			idx.references = append(idx.references, idx.References(m)...)
+		}
+	}
+	sortReferences(idx.references)
+	return idx
+}
+
+// References returns the references from m to other components.  Fields
+// referenced through relationships in formulas are resolved using the objects
+// in the index's repository.
+func (idx *Index) References(m metadata.RegisterableMetadata) []Reference {
+	refs := extract(m, idx.resolver)
+	info := m.GetMetadataInfo()
+	for i := range refs {
+		refs[i].Source = m.Type()
+		refs[i].SourceName = string(info.Name())
+		refs[i].Path = string(info.Path())
+	}
+	return uniqueReferences(refs)
+}
+
+// WhatUses returns the references to the named component.  The name can be a
+// field, e.g. Account.Industry, an object, e.g. Account, which also matches
+// references to the object's fields, or a report, e.g. Folder/Report_Name.
+// Names are matched case-insensitively.
+func (idx *Index) WhatUses(name string) []Reference {
+	var refs []Reference
+	for _, r := range idx.references {
+		if matches(r.Target, name) {
+			refs = append(refs, r)
+		}
+	}
+	return refs
+}
+
+func matches(c Component, name string) bool {
+	if strings.EqualFold(c.Name, name) {
+		return true
+	}
+	if c.Type == CustomField && !strings.Contains(name, ".") {
+		return strings.EqualFold(objectName(c.Name), name)
+	}
+	return false
+}
+
+func objectName(field string) string {
+	objectName, _, _ := strings.Cut(field, ".")
+	return objectName
+}
+
+func sortReferences(refs []Reference) {
+	sort.SliceStable(refs, func(i, j int) bool {
+		a, b := refs[i], refs[j]
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		if a.Target.Type != b.Target.Type {
+			return a.Target.Type < b.Target.Type
+		}
+		if a.Target.Name != b.Target.Name {
+			return a.Target.Name < b.Target.Name
+		}
+		return a.Context < b.Context
+	})
+}
+
+func uniqueReferences(refs []Reference) []Reference {
+	sortReferences(refs)
+	unique := refs[:0]
+	for _, r := range refs {
+		if len(unique) > 0 && r == unique[len(unique)-1] {
+			continue
+		}
+		unique = append(unique, r)
+	}
+	return unique
+}
@@ -0,0 +1,240 @@
+package deps
+
+import (
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/ForceCLI/force-md/metadata"
+	"github.com/ForceCLI/force-md/metadata/dashboard"
+	flexipage "github.com/ForceCLI/force-md/metadata/flexipages"
+	"github.com/ForceCLI/force-md/metadata/flow"
+	layout "github.com/ForceCLI/force-md/metadata/layouts"
+	"github.com/ForceCLI/force-md/metadata/objects"
+	"github.com/ForceCLI/force-md/metadata/objects/field"
+	"github.com/ForceCLI/force-md/metadata/permissionset"
+	"github.com/ForceCLI/force-md/metadata/profile"
+	"github.com/ForceCLI/force-md/metadata/reportType"
+	"github.com/ForceCLI/force-md/metadata/workflow"
+)
+
+func fieldReference(name, context string) Reference {
+	return Reference{Target: Component{Type: CustomField, Name: name}, Context: context}
+}
+
+func objectReference(name, context string) Reference {
+	return Reference{Target: Component{Type: CustomObject, Name: name}, Context: context}
+}
+
+func extract(m metadata.RegisterableMetadata, r *Resolver) []Reference {
+	switch m := m.(type) {
+	case *profile.Profile:
+		return permissionReferences(m.FieldPermissions, m.ObjectPermissions)
+	case *permissionset.PermissionSet:
+		return permissionReferences(m.FieldPermissions, m.ObjectPermissions)
+	case *layout.Layout:
+		return layoutReferences(m)
+	case *flexipage.FlexiPage:
+		return flexiPageReferences(m)
+	case *flow.Flow:
+		return flowReferences(m)
+	case *workflow.Workflow:
+		return workflowReferences(m, r)
+	case *reportType.ReportType:
+		return reportTypeReferences(m, r)
+	case *dashboard.Dashboard:
+		return dashboardReferences(m)
+	case *objects.CustomObject:
+		var refs []Reference
+		for _, f := range m.Fields {
+			refs = append(refs, formulaReferences(string(m.GetMetadataInfo().Name()), f, "fields/"+f.FullName, r)...)
+		}
+		return refs
+	case *field.CustomField:
+		return formulaReferences(objectName(string(m.GetMetadataInfo().Name())), m.Field, "formula", r)
+	}
+	return nil
+}
+
+func permissionReferences(fields permissionset.FieldPermissionsList, objects permissionset.ObjectPermissionsList) []Reference {
+	var refs []Reference
+	for _, f := range fields {
+		refs = append(refs, fieldReference(f.Field, "fieldPermissions"))
+	}
+	for _, o := range objects {
+		refs = append(refs, objectReference(o.Object, "objectPermissions"))
+	}
+	return refs
+}
+
+// Layout names are prefixed with the object name, e.g. Account-Account Layout
+func layoutReferences(l *layout.Layout) []Reference {
+	object, _, _ := strings.Cut(string(l.GetMetadataInfo().Name()), "-")
+	var refs []Reference
+	for _, s := range l.LayoutSections {
+		for _, c := range s.LayoutColumns {
+			for _, i := range c.LayoutItems {
+				if i.Field.String() != "" {
+					refs = append(refs, fieldReference(object+"."+i.Field.String(), "layoutItems"))
+				}
+			}
+		}
+	}
+	if l.RelatedContent != nil {
+		for _, i := range l.RelatedContent.RelatedContentItems {
+			if i.LayoutItem.Field.String() != "" {
+				refs = append(refs, fieldReference(object+"."+i.LayoutItem.Field.String(), "layoutItems"))
+			}
+		}
+	}
+	return refs
+}
+
+// Field items on record pages are prefixed with Record, e.g. Record.Name
+func flexiPageReferences(p *flexipage.FlexiPage) []Reference {
+	object := p.SobjectType.String()
+	if object == "" {
+		return nil
+	}
+	var refs []Reference
+	for _, region := range p.FlexiPageRegions {
+		for _, i := range region.ItemInstances {
+			if i.FieldInstance == nil || i.FieldInstance.FieldItem.Text == "" {
+				continue
+			}
+			item := i.FieldInstance.FieldItem.String()
+			_, fieldName, found := strings.Cut(item, ".")
+			if !found {
+				fieldName = item
+			}
+			refs = append(refs, fieldReference(object+"."+fieldName, "fieldItem"))
+		}
+	}
+	return refs
+}
+
+func flowReferences(f *flow.Flow) []Reference {
+	var refs []Reference
+	var triggerObject string
+	if f.Start != nil && f.Start.Object != nil {
+		triggerObject = *f.Start.Object
+		refs = append(refs, objectReference(triggerObject, "start"))
+		for _, filter := range f.Start.Filters {
+			refs = append(refs, fieldReference(triggerObject+"."+filter.Field.Text, "start"))
+		}
+	}
+	for _, l := range f.RecordLookups {
+		if l.Object == "" {
+			continue
+		}
+		refs = append(refs, objectReference(l.Object, "recordLookups"))
+		var fields []string
+		for _, filter := range l.Filters {
+			fields = append(fields, filter.Field)
+		}
+		for _, q := range l.QueriedFields {
+			fields = append(fields, q.Text)
+		}
+		for _, a := range l.OutputAssignments {
+			fields = append(fields, a.Field.Text)
+		}
+		if l.SortField != nil {
+			fields = append(fields, l.SortField.Text)
+		}
+		for _, name := range fields {
+			refs = append(refs, fieldReference(l.Object+"."+name, "recordLookups"))
+		}
+	}
+	for _, c := range f.RecordCreates {
+		if c.Object == nil {
+			continue
+		}
+		refs = append(refs, objectReference(c.Object.Text, "recordCreates"))
+		for _, a := range c.InputAssignments {
+			refs = append(refs, fieldReference(c.Object.Text+"."+a.Field.Text, "recordCreates"))
+		}
+	}
+	for _, u := range f.RecordUpdates {
+		var object string
+		switch {
+		case u.Object != nil:
+			object = u.Object.Text
+		case u.InputReference != nil && u.InputReference.Text == "$Record":
+			object = triggerObject
+		}
+		if object == "" {
+			continue
+		}
+		refs = append(refs, objectReference(object, "recordUpdates"))
+		for _, filter := range u.Filters {
+			refs = append(refs, fieldReference(object+"."+filter.Field.Text, "recordUpdates"))
+		}
+		for _, a := range u.InputAssignments {
+			refs = append(refs, fieldReference(object+"."+a.Field.Text, "recordUpdates"))
+		}
+	}
+	return refs
+}
+
+// Workflows are named for their object
+func workflowReferences(w *workflow.Workflow, r *Resolver) []Reference {
+	object := string(w.GetMetadataInfo().Name())
+	var refs []Reference
+	for _, u := range w.FieldUpdates {
+		target := object
+		if u.TargetObject != nil && u.TargetObject.Text != "" {
+			target = u.TargetObject.Text
+		}
+		refs = append(refs, fieldReference(target+"."+u.Field.Text, "fieldUpdates"))
+		if u.Formula == nil {
+			continue
+		}
+		fields, err := r.formulaFields(object, u.Formula.String())
+		if err != nil {
+			log.Warnf("parsing formula for field update %s in %s failed: %s", u.FullName.Text, w.GetMetadataInfo().Path(), err.Error())
+			continue
+		}
+		for _, name := range fields {
+			refs = append(refs, fieldReference(name, "fieldUpdates"))
+		}
+	}
+	return refs
+}
+
+func reportTypeReferences(t *reportType.ReportType, r *Resolver) []Reference {
+	var refs []Reference
+	if t.BaseObject.Text != "" {
+		refs = append(refs, objectReference(t.BaseObject.Text, "baseObject"))
+	}
+	for _, f := range t.GetFields() {
+		refs = append(refs, fieldReference(r.TableObject(f.Table)+"."+f.Field, "sections"))
+	}
+	return refs
+}
+
+func dashboardReferences(d *dashboard.Dashboard) []Reference {
+	var refs []Reference
+	for _, report := range d.GetReports() {
+		if report == "" {
+			continue
+		}
+		refs = append(refs, Reference{Target: Component{Type: Report, Name: report}, Context: "dashboardComponents"})
+	}
+	return refs
+}
+
+func formulaReferences(object string, f field.Field, context string, r *Resolver) []Reference {
+	if f.Formula == nil {
+		return nil
+	}
+	fields, err := r.formulaFields(object, f.Formula.String())
+	if err != nil {
+		log.Warnf("parsing formula for %s.%s failed: %s", object, f.FullName, err.Error())
+		return nil
+	}
+	var refs []Reference
+	for _, name := range fields {
+		refs = append(refs, fieldReference(name, context))
+	}
+	return refs
+}
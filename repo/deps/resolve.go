@@ -0,0 +1,160 @@
+package deps
+
+import (
+	"strings"
+
+	"github.com/ForceCLI/force-md/internal/formula"
+	"github.com/ForceCLI/force-md/metadata/objects"
+	"github.com/ForceCLI/force-md/metadata/objects/field"
+	"github.com/ForceCLI/force-md/repo"
+)
+
+// Standard lookup relationships whose target can't be inferred from the
+// relationship name
+var standardRelationships = map[string]string{
+	"createdby":      "User",
+	"lastmodifiedby": "User",
+	"owner":          "User",
+}
+
+type lookup struct {
+	object           string
+	referenceTo      string
+	relationshipName string
+}
+
+// Resolver follows relationships using the fields of the objects in a
+// repository
+type Resolver struct {
+	// lookups by lowercase object name and field name
+	lookups map[string]map[string]lookup
+}
+
+// NewResolver returns a Resolver for the objects and fields open in r
+func NewResolver(r *repo.Repo) *Resolver {
+	res := &Resolver{lookups: make(map[string]map[string]lookup)}
+	for _, m := range r.Items(objects.NAME) {
+		o := m.(*objects.CustomObject)
+		for _, f := range o.Fields {
+			res.add(string(o.GetMetadataInfo().Name()), f)
+		}
+	}
+	for _, m := range r.Items(field.NAME) {
+		f := m.(*field.CustomField)
+		res.add(objectName(string(f.GetMetadataInfo().Name())), f.Field)
+	}
+	return res
+}
+
+func (r *Resolver) add(object string, f field.Field) {
+	if f.ReferenceTo == nil {
+		return
+	}
+	key := strings.ToLower(object)
+	if _, ok := r.lookups[key]; !ok {
+		r.lookups[key] = make(map[string]lookup)
+	}
+	l := lookup{object: object, referenceTo: f.ReferenceTo.Text}
+	if f.RelationshipName != nil {
+		l.relationshipName = f.RelationshipName.Text
+	}
+	r.lookups[key][strings.ToLower(f.FullName)] = l
+}
+
+// parentObject returns the lookup field on object for a relationship, e.g.
+// AccountId for Account or Parent__c for Parent__r, and the object it
+// references, if known.
+func (r *Resolver) parentObject(object, relationship string) (string, string) {
+	var lookupField string
+	lower := strings.ToLower(relationship)
+	if strings.HasSuffix(lower, "__r") {
+		lookupField = relationship[:len(relationship)-3] + "__c"
+	} else {
+		lookupField = relationship + "Id"
+	}
+	if l, ok := r.lookups[strings.ToLower(object)][strings.ToLower(lookupField)]; ok {
+		return lookupField, l.referenceTo
+	}
+	if target, ok := standardRelationships[lower]; ok {
+		return lookupField, target
+	}
+	if strings.HasSuffix(lower, "__r") {
+		return lookupField, ""
+	}
+	return lookupField, relationship
+}
+
+// childObject returns the object related to parent through a child
+// relationship, e.g. Contact for Account's Contacts relationship, if known.
+func (r *Resolver) childObject(parent, relationship string) string {
+	for _, fields := range r.lookups {
+		for _, l := range fields {
+			if !strings.EqualFold(l.referenceTo, parent) || l.relationshipName == "" {
+				continue
+			}
+			if strings.EqualFold(l.relationshipName, relationship) || strings.EqualFold(l.relationshipName+"__r", relationship) {
+				return l.object
+			}
+		}
+	}
+	return ""
+}
+
+// Fields returns the fields referenced by a path of relationships starting
+// at object, e.g. Account.Owner.Email from Contact references
+// Contact.AccountId, Account.OwnerId, and User.Email.
+func (r *Resolver) Fields(object, path string) []string {
+	parts := strings.Split(path, ".")
+	var fields []string
+	for i, part := range parts {
+		if object == "" {
+			break
+		}
+		if i == len(parts)-1 {
+			fields = append(fields, object+"."+part)
+			break
+		}
+		lookupField, parent := r.parentObject(object, part)
+		fields = append(fields, object+"."+lookupField)
+		object = parent
+	}
+	return fields
+}
+
+// formulaFields returns the fields referenced by a formula evaluated in the
+// context of object.  Global variables other than custom settings are
+// ignored.
+func (r *Resolver) formulaFields(object, f string) ([]string, error) {
+	refs, err := formula.FieldReferences(f)
+	if err != nil {
+		return nil, err
+	}
+	var fields []string
+	for _, ref := range refs {
+		if strings.HasPrefix(ref, "$Setup.") {
+			fields = append(fields, strings.TrimPrefix(ref, "$Setup."))
+			continue
+		}
+		if strings.HasPrefix(ref, "$") {
+			continue
+		}
+		fields = append(fields, r.Fields(object, ref)...)
+	}
+	return fields, nil
+}
+
+// TableObject returns the object for a report type table, e.g. Contact for
+// Account.Contacts.  If a relationship can't be resolved, the table is
+// returned unchanged.
+func (r *Resolver) TableObject(table string) string {
+	parts := strings.Split(table, ".")
+	object := parts[0]
+	for _, relationship := range parts[1:] {
+		child := r.childObject(object, relationship)
+		if child == "" {
+			return table
+		}
+		object = child
+	}
+	return object
+}
@@ -0,0 +1,191 @@
+package formulas
+
+import (
+	"strings"
+
+	"github.com/ForceCLI/force-md/internal/formula"
+	"github.com/ForceCLI/force-md/metadata/flow"
+	"github.com/ForceCLI/force-md/metadata/objects"
+	"github.com/ForceCLI/force-md/metadata/objects/field"
+	"github.com/ForceCLI/force-md/repo"
+	"github.com/ForceCLI/force-md/repo/deps"
+)
+
+const (
+	// SourceSizeLimit is the maximum length of a formula's text, including
+	// whitespace and comments
+	SourceSizeLimit = 3900
+	// CompiledSizeLimit is the maximum compiled size of a formula
+	CompiledSizeLimit = 5000
+)
+
+// Analysis describes the fields referenced by a formula
+type Analysis struct {
+	Formula
+	// The references in the formula as written, e.g. Account.Owner.Email
+	References []string `json:"references"`
+	// The fields referenced, including the lookup fields of relationships,
+	// e.g. Contact.AccountId, Account.OwnerId, and User.Email
+	Fields []string `json:"fields"`
+	// Referenced custom fields that aren't in the objects loaded
+	UnknownFields []string `json:"unknownFields,omitempty"`
+	// The length of the formula as written
+	SourceSize int `json:"sourceSize"`
+	// The length of the formula without whitespace and comments, with the
+	// formulas of referenced formula fields included in place of the
+	// references to them.  This is an estimate of Salesforce's compiled
+	// size, which can't be computed exactly from the source.
+	ExpandedSize int `json:"expandedSize"`
+	// Whether SourceSize exceeds SourceSizeLimit
+	OverSourceSizeLimit bool `json:"overSourceSizeLimit,omitempty"`
+	// Whether ExpandedSize exceeds CompiledSizeLimit
+	OverCompiledSizeLimit bool   `json:"overCompiledSizeLimit,omitempty"`
+	Error                 string `json:"error,omitempty"`
+}
+
+// Analyzer resolves the fields referenced by formulas using the objects in a
+// repository
+type Analyzer struct {
+	resolver *deps.Resolver
+	// objects and their fields by lowercase name
+	objects map[string]map[string]bool
+	// formula fields by lowercase Object.Field
+	formulaFields map[string]string
+	sizes         map[string]int
+}
+
+func NewAnalyzer(r *repo.Repo) *Analyzer {
+	a := &Analyzer{
+		resolver:      deps.NewResolver(r),
+		objects:       make(map[string]map[string]bool),
+		formulaFields: make(map[string]string),
+		sizes:         make(map[string]int),
+	}
+	for _, m := range r.Items(objects.NAME) {
+		o := m.(*objects.CustomObject)
+		object := string(o.GetMetadataInfo().Name())
+		a.addObject(object)
+		for _, f := range o.Fields {
+			a.addField(object, f)
+		}
+	}
+	for _, m := range r.Items(field.NAME) {
+		f := m.(*field.CustomField)
+		object := objectName(string(f.GetMetadataInfo().Name()))
+		a.addObject(object)
+		a.addField(object, f.Field)
+	}
+	return a
+}
+
+func (a *Analyzer) addObject(object string) {
+	if _, ok := a.objects[strings.ToLower(object)]; !ok {
+		a.objects[strings.ToLower(object)] = make(map[string]bool)
+	}
+}
+
+func (a *Analyzer) addField(object string, f field.Field) {
+	a.objects[strings.ToLower(object)][strings.ToLower(f.FullName)] = true
+	if f.Formula != nil {
+		a.formulaFields[strings.ToLower(object+"."+f.FullName)] = f.Formula.String()
+	}
+}
+
+// Analyze returns the references, unknown fields, and sizes of a formula
+func (a *Analyzer) Analyze(f Formula) Analysis {
+	result := Analysis{Formula: f, References: []string{}, Fields: []string{}, SourceSize: len([]rune(f.Formula))}
+	refs, err := formula.FieldReferences(f.Formula)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.References = append(result.References, refs...)
+	seen := make(map[string]bool)
+	for _, ref := range refs {
+		for _, name := range a.fields(f, ref) {
+			if seen[strings.ToLower(name)] {
+				continue
+			}
+			seen[strings.ToLower(name)] = true
+			result.Fields = append(result.Fields, name)
+			if !a.exists(name) {
+				result.UnknownFields = append(result.UnknownFields, name)
+			}
+		}
+	}
+	result.ExpandedSize = a.expandedSize(f, nil)
+	result.OverSourceSizeLimit = result.SourceSize > SourceSizeLimit
+	result.OverCompiledSizeLimit = result.ExpandedSize > CompiledSizeLimit
+	return result
+}
+
+// fields returns the fields referenced by a reference in a formula
+func (a *Analyzer) fields(f Formula, ref string) []string {
+	if f.Type == flow.NAME {
+		// Flow formulas reference the triggering record through $Record.
+		// Other references are to flow resources.
+		for _, prefix := range []string{"$Record.", "$Record__Prior."} {
+			if strings.HasPrefix(ref, prefix) && f.Object != "" {
+				return a.resolver.Fields(f.Object, strings.TrimPrefix(ref, prefix))
+			}
+		}
+		return nil
+	}
+	switch {
+	case strings.HasPrefix(ref, "$Setup."):
+		return []string{strings.TrimPrefix(ref, "$Setup.")}
+	case strings.HasPrefix(ref, "$"), f.Object == "":
+		return nil
+	}
+	return a.resolver.Fields(f.Object, ref)
+}
+
+// exists returns false for custom fields on loaded objects that aren't
+// found.  Standard fields and fields on objects that weren't loaded are
+// assumed to exist, as are fields from managed packages.
+func (a *Analyzer) exists(name string) bool {
+	object, fieldName, found := strings.Cut(name, ".")
+	if !found || !strings.HasSuffix(strings.ToLower(fieldName), "__c") || strings.Count(fieldName, "__") > 1 {
+		return true
+	}
+	fields, ok := a.objects[strings.ToLower(object)]
+	if !ok {
+		return true
+	}
+	return fields[strings.ToLower(fieldName)]
+}
+
+// expandedSize returns the size of a formula without whitespace and comments,
+// with the formulas of referenced formula fields included in place of the
+// references to them
+func (a *Analyzer) expandedSize(f Formula, visiting map[string]bool) int {
+	size, err := formula.Size(f.Formula)
+	if err != nil {
+		return 0
+	}
+	refs, _ := formula.FieldReferences(f.Formula)
+	for _, ref := range refs {
+		fields := a.fields(f, ref)
+		if len(fields) == 0 {
+			continue
+		}
+		target := strings.ToLower(fields[len(fields)-1])
+		text, isFormula := a.formulaFields[target]
+		if !isFormula || visiting[target] {
+			continue
+		}
+		referenced, ok := a.sizes[target]
+		if !ok {
+			if visiting == nil {
+				visiting = make(map[string]bool)
+			}
+			visiting[target] = true
+			object, _, _ := strings.Cut(fields[len(fields)-1], ".")
+			referenced = a.expandedSize(Formula{Object: object, Formula: text}, visiting)
+			delete(visiting, target)
+			a.sizes[target] = referenced
+		}
+		size += referenced - len([]rune(ref))
+	}
+	return size
+}
@@ -0,0 +1,99 @@
+// Package formulas finds the formulas in metadata and analyzes the fields
+// they reference.
+package formulas
+
+import (
+	"strings"
+
+	"github.com/ForceCLI/force-md/metadata"
+	"github.com/ForceCLI/force-md/metadata/flow"
+	"github.com/ForceCLI/force-md/metadata/objects"
+	"github.com/ForceCLI/force-md/metadata/objects/field"
+	"github.com/ForceCLI/force-md/metadata/objects/validationrule"
+	"github.com/ForceCLI/force-md/metadata/workflow"
+)
+
+// Formula is a formula in a metadata file
+type Formula struct {
+	Path string                `json:"path"`
+	Type metadata.MetadataType `json:"type"`
+	// The component containing the formula, e.g. Account.Total__c
+	Name string `json:"name"`
+	// The element containing the formula, e.g. formula or defaultValue
+	Element string `json:"element"`
+	// The object in whose context the formula is evaluated, if known
+	Object  string `json:"object,omitempty"`
+	Formula string `json:"formula"`
+	// Whether blank fields are treated as zero rather than blank
+	BlanksAsZero bool `json:"blanksAsZero,omitempty"`
+}
+
+// Formulas returns the formulas in formula fields, field default values,
+// validation rules, workflow rules and field updates, and flows
+func Formulas(m metadata.RegisterableMetadata) []Formula {
+	info := m.GetMetadataInfo()
+	var formulas []Formula
+	add := func(name, element, object, text string) {
+		if strings.TrimSpace(text) == "" {
+			return
+		}
+		formulas = append(formulas, Formula{
+			Path:    string(info.Path()),
+			Type:    m.Type(),
+			Name:    name,
+			Element: element,
+			Object:  object,
+			Formula: text,
+		})
+	}
+	addField := func(object string, f field.Field) {
+		n := len(formulas)
+		add(object+"."+f.FullName, "formula", object, f.Formula.String())
+		if len(formulas) > n && f.FormulaTreatBlanksAs != nil && f.FormulaTreatBlanksAs.Text == "BlankAsZero" {
+			formulas[n].BlanksAsZero = true
+		}
+		add(object+"."+f.FullName, "defaultValue", object, f.DefaultValue.String())
+	}
+	addRule := func(object string, r validationrule.Rule) {
+		add(object+"."+r.FullName, "errorConditionFormula", object, r.ErrorConditionFormula.String())
+	}
+
+	switch m := m.(type) {
+	case *objects.CustomObject:
+		object := string(info.Name())
+		for _, f := range m.Fields {
+			addField(object, f)
+		}
+		for _, r := range m.ValidationRules {
+			addRule(object, r)
+		}
+	case *field.CustomField:
+		addField(objectName(string(info.Name())), m.Field)
+	case *validationrule.ValidationRule:
+		addRule(objectName(string(info.Name())), m.Rule)
+	case *workflow.Workflow:
+		// Workflows are named for their object
+		object := string(info.Name())
+		for _, r := range m.Rules {
+			add(object+"."+r.FullName, "rules", object, r.Formula.String())
+		}
+		for _, u := range m.FieldUpdates {
+			add(object+"."+u.FullName.Text, "fieldUpdates", object, u.Formula.String())
+		}
+	case *flow.Flow:
+		var object string
+		if m.Start != nil && m.Start.Object != nil {
+			object = *m.Start.Object
+		}
+		for _, f := range m.Formulas {
+			add(string(info.Name())+"."+f.Name.Text, "formulas", object, f.Expression.String())
+		}
+	}
+	return formulas
+}
+
+// Child components in sfdx source format are named Object.Name.type
+func objectName(name string) string {
+	object, _, _ := strings.Cut(name, ".")
+	return object
+}
@@ -0,0 +1,252 @@
+package formulas
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/ForceCLI/force-md/internal/formula"
+	"github.com/ForceCLI/force-md/repo"
+)
+
+// Suite is a set of formula test cases read from a YAML file
+type Suite struct {
+	// The fields of $User and $Profile for all tests
+	User    map[string]any `yaml:"user"`
+	Profile map[string]any `yaml:"profile"`
+	// Other global merge fields, e.g. $Setup or $Label, by name
+	Globals map[string]any `yaml:"globals"`
+	// The current date/time for TODAY() and NOW()
+	Now   string     `yaml:"now"`
+	Tests []TestCase `yaml:"tests"`
+
+	dir string
+}
+
+// TestCase evaluates a formula against a record.  The formula is either
+// given inline or read from a metadata file.
+type TestCase struct {
+	Name string `yaml:"name"`
+	// The metadata file containing the formula, relative to the suite
+	File string `yaml:"file"`
+	// The component containing the formula, e.g. Account.Total__c or
+	// Total__c, if the file contains more than one formula
+	Component string `yaml:"component"`
+	// The element containing the formula, e.g. formula or defaultValue
+	Element string `yaml:"element"`
+	Formula string `yaml:"formula"`
+	// Treat blank fields as zero.  Defaults to the field's setting.
+	BlanksAsZero *bool `yaml:"blanksAsZero"`
+	// The record, or the path to a JSON file containing it
+	Record any `yaml:"record"`
+	// The record before it was changed.  Omit for new records.
+	Prior   any            `yaml:"prior"`
+	User    map[string]any `yaml:"user"`
+	Profile map[string]any `yaml:"profile"`
+	Now     string         `yaml:"now"`
+	Expect  any            `yaml:"expect"`
+}
+
+// Result is the outcome of a test case
+type Result struct {
+	Name     string
+	Expected string
+	Actual   string
+	Error    error
+}
+
+func (r Result) Passed() bool {
+	return r.Error == nil && r.Expected == r.Actual
+}
+
+func LoadSuite(path string) (*Suite, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var s Suite
+	if err := yaml.Unmarshal(data, &s); err != nil {
+		return nil, errors.Wrap(err, "parsing "+path)
+	}
+	s.dir = filepath.Dir(path)
+	return &s, nil
+}
+
+// Run evaluates each test case in the suite
+func (s *Suite) Run() []Result {
+	var results []Result
+	for i, t := range s.Tests {
+		name := t.Name
+		if name == "" {
+			name = fmt.Sprintf("test %d", i+1)
+		}
+		result := Result{Name: name, Expected: formula.Format(normalize(t.Expect))}
+		actual, err := s.Evaluate(t)
+		if err != nil {
+			result.Error = err
+		} else {
+			result.Actual = formula.Format(actual)
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// Evaluate returns the result of the formula in a test case
+func (s *Suite) Evaluate(t TestCase) (any, error) {
+	f, err := s.formula(t)
+	if err != nil {
+		return nil, err
+	}
+	env := formula.Env{BlanksAsZero: f.BlanksAsZero}
+	if t.BlanksAsZero != nil {
+		env.BlanksAsZero = *t.BlanksAsZero
+	}
+	if env.Record, err = s.record(t.Record); err != nil {
+		return nil, errors.Wrap(err, "record")
+	}
+	if env.Record == nil {
+		env.Record = make(map[string]any)
+	}
+	if env.Prior, err = s.record(t.Prior); err != nil {
+		return nil, errors.Wrap(err, "prior")
+	}
+	env.Globals = map[string]any{
+		"$User":    merge(s.User, t.User),
+		"$Profile": merge(s.Profile, t.Profile),
+	}
+	for k, v := range s.Globals {
+		if !strings.HasPrefix(k, "$") {
+			k = "$" + k
+		}
+		env.Globals[k] = normalize(v)
+	}
+	now := t.Now
+	if now == "" {
+		now = s.Now
+	}
+	if now != "" {
+		if env.Now, err = parseTime(now); err != nil {
+			return nil, err
+		}
+	}
+	return formula.Evaluate(f.Formula, env)
+}
+
+// formula returns the formula to test, either inline or from a metadata
+// file.  Default values are only used if requested or if there's no other
+// formula.
+func (s *Suite) formula(t TestCase) (Formula, error) {
+	if t.Formula != "" {
+		return Formula{Formula: t.Formula}, nil
+	}
+	if t.File == "" {
+		return Formula{}, errors.New("formula or file required")
+	}
+	m, err := repo.MetadataFromPath(s.path(t.File))
+	if err != nil {
+		return Formula{}, errors.Wrap(err, "loading "+t.File)
+	}
+	var matches []Formula
+	for _, f := range Formulas(m) {
+		if t.Component != "" && !strings.EqualFold(f.Name, t.Component) && !strings.HasSuffix(strings.ToLower(f.Name), "."+strings.ToLower(t.Component)) {
+			continue
+		}
+		if t.Element != "" && f.Element != t.Element || t.Element == "" && f.Element == "defaultValue" {
+			continue
+		}
+		matches = append(matches, f)
+	}
+	switch len(matches) {
+	case 0:
+		return Formula{}, fmt.Errorf("no matching formula found in %s", t.File)
+	case 1:
+		return matches[0], nil
+	}
+	var names []string
+	for _, f := range matches {
+		names = append(names, f.Name)
+	}
+	return Formula{}, fmt.Errorf("multiple formulas found in %s; specify component: %s", t.File, strings.Join(names, ", "))
+}
+
+// record returns a record given inline or in a JSON file
+func (s *Suite) record(r any) (map[string]any, error) {
+	switch r := r.(type) {
+	case nil:
+		return nil, nil
+	case map[string]any:
+		return normalize(r).(map[string]any), nil
+	case string:
+		data, err := os.ReadFile(s.path(r))
+		if err != nil {
+			return nil, err
+		}
+		var record map[string]any
+		if err := json.Unmarshal(data, &record); err != nil {
+			return nil, errors.Wrap(err, "parsing "+r)
+		}
+		return record, nil
+	}
+	return nil, fmt.Errorf("expected map or JSON file, got %v", r)
+}
+
+func (s *Suite) path(p string) string {
+	if filepath.IsAbs(p) {
+		return p
+	}
+	return filepath.Join(s.dir, p)
+}
+
+func merge(base, overrides map[string]any) map[string]any {
+	merged := make(map[string]any)
+	for k, v := range base {
+		merged[k] = normalize(v)
+	}
+	for k, v := range overrides {
+		merged[k] = normalize(v)
+	}
+	return merged
+}
+
+// normalize converts YAML values to the types used by formulas.  YAML
+// timestamps without a time are dates.
+func normalize(v any) any {
+	switch v := v.(type) {
+	case int:
+		return float64(v)
+	case time.Time:
+		if v.Equal(v.Truncate(24 * time.Hour)) {
+			return formula.NewDate(v.Year(), v.Month(), v.Day())
+		}
+		return v.UTC()
+	case map[string]any:
+		m := make(map[string]any)
+		for k, value := range v {
+			m[k] = normalize(value)
+		}
+		return m
+	case []any:
+		var l []any
+		for _, value := range v {
+			l = append(l, normalize(value))
+		}
+		return l
+	}
+	return v
+}
+
+func parseTime(s string) (time.Time, error) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time: %s", s)
+}
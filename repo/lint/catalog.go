@@ -0,0 +1,121 @@
+// Package lint checks metadata for problems that would cause deployments to
+// fail and for flows that don't follow best practices.
+package lint
+
+import (
+	"strings"
+
+	"github.com/ForceCLI/force-md/metadata"
+	"github.com/ForceCLI/force-md/metadata/objects"
+	"github.com/ForceCLI/force-md/metadata/objects/field"
+	"github.com/ForceCLI/force-md/metadata/objects/recordtype"
+	"github.com/ForceCLI/force-md/repo"
+)
+
+// catalog contains the lowercase names of the components in a repository
+type catalog struct {
+	components  map[metadata.MetadataType]map[string]bool
+	objects     map[string]bool
+	fields      map[string]bool
+	recordTypes map[string]bool
+}
+
+func newCatalog(r *repo.Repo) *catalog {
+	c := &catalog{
+		components:  make(map[metadata.MetadataType]map[string]bool),
+		objects:     make(map[string]bool),
+		fields:      make(map[string]bool),
+		recordTypes: make(map[string]bool),
+	}
+	for _, t := range r.Types() {
+		names := make(map[string]bool)
+		for name := range r.Items(t) {
+			names[strings.ToLower(string(name))] = true
+		}
+		c.components[t] = names
+	}
+	for name, m := range r.Items(objects.NAME) {
+		o := m.(*objects.CustomObject)
+		c.objects[strings.ToLower(string(name))] = true
+		for _, f := range o.Fields {
+			c.fields[strings.ToLower(string(name)+"."+f.FullName)] = true
+		}
+		for _, rt := range o.RecordTypes {
+			c.recordTypes[strings.ToLower(string(name)+"."+rt.FullName)] = true
+		}
+	}
+	// Child components in sfdx source format are named Object.Name.type
+	for name, m := range r.Items(field.NAME) {
+		object := objectName(string(name))
+		c.objects[strings.ToLower(object)] = true
+		c.fields[strings.ToLower(object+"."+m.(*field.CustomField).FullName)] = true
+	}
+	for name, m := range r.Items(recordtype.NAME) {
+		object := objectName(string(name))
+		c.objects[strings.ToLower(object)] = true
+		c.recordTypes[strings.ToLower(object+"."+m.(*recordtype.RecordTypeMetadata).FullName)] = true
+	}
+	return c
+}
+
+func objectName(name string) string {
+	objectName, _, _ := strings.Cut(name, ".")
+	return objectName
+}
+
+func isCustom(name string) bool {
+	return strings.Contains(name, "__")
+}
+
+var customSuffixes = []string{"__c", "__mdt", "__e", "__b", "__x", "__kav"}
+
+// Components from managed packages are prefixed with a namespace, e.g.
+// ns__Object__c or ns__Class, and can't be checked
+func isManaged(name string) bool {
+	lower := strings.ToLower(name)
+	for _, suffix := range customSuffixes {
+		if strings.HasSuffix(lower, suffix) {
+			lower = strings.TrimSuffix(lower, suffix)
+			break
+		}
+	}
+	return strings.Contains(lower, "__")
+}
+
+// exists returns false if the component is known to be missing.  Components
+// that may be defined outside of the repository, such as standard objects
+// and fields, or components of types that aren't loaded, are assumed to
+// exist.
+func (c *catalog) exists(t metadata.MetadataType, name string) bool {
+	switch t {
+	case objects.NAME:
+		return c.objectExists(name)
+	case field.NAME:
+		object, fieldName, _ := strings.Cut(name, ".")
+		if !c.objects[strings.ToLower(object)] {
+			return c.objectExists(object)
+		}
+		if !isCustom(fieldName) || isManaged(fieldName) {
+			return true
+		}
+		return c.fields[strings.ToLower(name)]
+	case recordtype.NAME:
+		object := objectName(name)
+		if !c.objects[strings.ToLower(object)] {
+			return c.objectExists(object)
+		}
+		return c.recordTypes[strings.ToLower(name)]
+	}
+	names, loaded := c.components[t]
+	if !loaded || isManaged(name) {
+		return true
+	}
+	return names[strings.ToLower(name)]
+}
+
+func (c *catalog) objectExists(name string) bool {
+	if len(c.objects) == 0 || !isCustom(name) || isManaged(name) {
+		return true
+	}
+	return c.objects[strings.ToLower(name)]
+}
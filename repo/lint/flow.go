@@ -0,0 +1,417 @@
+package lint
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ForceCLI/force-md/metadata/flow"
+)
+
+type Level string
+
+const (
+	Error   Level = "error"
+	Warning Level = "warning"
+	Note    Level = "note"
+	// Off disables a rule
+	Off Level = "off"
+)
+
+// DefaultMinApiVersion is the lowest API version accepted by the api-version
+// rule unless configured otherwise
+const DefaultMinApiVersion = 58.0
+
+// FlowRule is a best practice for flows
+type FlowRule struct {
+	Id          string
+	Description string
+	Level       Level
+	check       func(f *flow.Flow, config FlowConfig) []FlowIssue
+}
+
+// FlowIssue is a violation of a flow rule
+type FlowIssue struct {
+	Rule  string
+	Level Level
+	// The element with the issue, if any
+	Element flow.ElementName
+	Message string
+}
+
+func (i FlowIssue) String() string {
+	return fmt.Sprintf("%s: %s [%s]", i.Level, i.Message, i.Rule)
+}
+
+// FlowConfig configures the flow rules
+type FlowConfig struct {
+	// Levels by rule id, overriding the rules' defaults.  Rules set to Off
+	// aren't checked.
+	Rules         map[string]Level `yaml:"rules"`
+	MinApiVersion float64          `yaml:"minApiVersion"`
+}
+
+func (c FlowConfig) level(r FlowRule) Level {
+	if l, ok := c.Rules[r.Id]; ok {
+		return l
+	}
+	return r.Level
+}
+
+var FlowRules = []FlowRule{
+	{
+		Id:          "dml-in-loop",
+		Description: "Record lookups, creates, updates, and deletes should not be inside loops",
+		Level:       Error,
+		check:       dmlInLoop,
+	},
+	{
+		Id:          "missing-fault-path",
+		Description: "Record creates, updates, and deletes, and actions should have fault paths",
+		Level:       Warning,
+		check:       missingFaultPath,
+	},
+	{
+		Id:          "unreachable-element",
+		Description: "Every element should be reachable from the start of the flow",
+		Level:       Warning,
+		check:       unreachableElement,
+	},
+	{
+		Id:          "unused-variable",
+		Description: "Variables should be used or available for input or output",
+		Level:       Warning,
+		check:       unusedVariable,
+	},
+	{
+		Id:          "hardcoded-id",
+		Description: "Record ids should not be hard-coded",
+		Level:       Error,
+		check:       hardcodedId,
+	},
+	{
+		Id:          "missing-entry-criteria",
+		Description: "Record-triggered flows should have entry criteria",
+		Level:       Warning,
+		check:       missingEntryCriteria,
+	},
+	{
+		Id:          "api-version",
+		Description: "Flows should use a recent API version",
+		Level:       Warning,
+		check:       apiVersion,
+	},
+}
+
+// ValidateFlowConfig returns an error if the config refers to unknown rules or
+// levels
+func ValidateFlowConfig(config FlowConfig) error {
+	for id, level := range config.Rules {
+		found := false
+		for _, r := range FlowRules {
+			found = found || r.Id == id
+		}
+		if !found {
+			return fmt.Errorf("unknown rule: %s", id)
+		}
+		switch level {
+		case Error, Warning, Note, Off:
+		default:
+			return fmt.Errorf("invalid level for %s: %s", id, level)
+		}
+	}
+	return nil
+}
+
+// LintFlow checks a flow against the enabled rules
+func LintFlow(f *flow.Flow, config FlowConfig) []FlowIssue {
+	if config.MinApiVersion == 0 {
+		config.MinApiVersion = DefaultMinApiVersion
+	}
+	var issues []FlowIssue
+	for _, r := range FlowRules {
+		level := config.level(r)
+		if level == Off {
+			continue
+		}
+		for _, i := range r.check(f, config) {
+			i.Rule = r.Id
+			i.Level = level
+			issues = append(issues, i)
+		}
+	}
+	return issues
+}
+
+var dmlTypes = map[string]bool{
+	"RecordCreate": true,
+	"RecordDelete": true,
+	"RecordLookup": true,
+	"RecordUpdate": true,
+}
+
+func dmlInLoop(f *flow.Flow, _ FlowConfig) []FlowIssue {
+	elements := elementsByName(f)
+	var issues []FlowIssue
+	reported := make(map[flow.ElementName]bool)
+	for _, loop := range f.Elements() {
+		if loop.Type != "Loop" {
+			continue
+		}
+		// Follow the path for each item until it returns to the loop
+		visited := map[flow.ElementName]bool{loop.Name: true}
+		var queue []flow.ElementName
+		for _, c := range loop.Connectors {
+			if c.Label == "For Each" {
+				queue = append(queue, c.Target)
+			}
+		}
+		for len(queue) > 0 {
+			name := queue[0]
+			queue = queue[1:]
+			if visited[name] {
+				continue
+			}
+			visited[name] = true
+			e, ok := elements[name]
+			if !ok {
+				continue
+			}
+			if dmlTypes[e.Type] && !reported[name] {
+				reported[name] = true
+				issues = append(issues, FlowIssue{Element: name, Message: fmt.Sprintf("%s %s is inside loop %s", e.Type, name, loop.Name)})
+			}
+			for _, c := range e.Connectors {
+				queue = append(queue, c.Target)
+			}
+		}
+	}
+	return issues
+}
+
+func missingFaultPath(f *flow.Flow, _ FlowConfig) []FlowIssue {
+	var issues []FlowIssue
+	for _, e := range f.Elements() {
+		switch e.Type {
+		case "RecordCreate", "RecordUpdate", "RecordDelete", "ActionCall":
+		default:
+			continue
+		}
+		hasFault := false
+		for _, c := range e.Connectors {
+			hasFault = hasFault || c.Fault
+		}
+		if !hasFault {
+			issues = append(issues, FlowIssue{Element: e.Name, Message: fmt.Sprintf("%s %s has no fault path", e.Type, e.Name)})
+		}
+	}
+	return issues
+}
+
+func unreachableElement(f *flow.Flow, _ FlowConfig) []FlowIssue {
+	start := f.StartConnectors()
+	if len(start) == 0 {
+		return nil
+	}
+	elements := elementsByName(f)
+	visited := make(map[flow.ElementName]bool)
+	var queue []flow.ElementName
+	for _, c := range start {
+		queue = append(queue, c.Target)
+	}
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		if visited[name] {
+			continue
+		}
+		visited[name] = true
+		for _, c := range elements[name].Connectors {
+			queue = append(queue, c.Target)
+		}
+	}
+	var issues []FlowIssue
+	for _, e := range f.Elements() {
+		if !visited[e.Name] {
+			issues = append(issues, FlowIssue{Element: e.Name, Message: fmt.Sprintf("%s %s is not reachable", e.Type, e.Name)})
+		}
+	}
+	return issues
+}
+
+func unusedVariable(f *flow.Flow, _ FlowConfig) []FlowIssue {
+	var values []string
+	collectStrings(reflect.ValueOf(f).Elem(), &values)
+	used := func(name string) bool {
+		name = strings.ToLower(name)
+		for _, v := range values {
+			v = strings.ToLower(v)
+			if v == name || strings.HasPrefix(v, name+".") || strings.Contains(v, "{!"+name+"}") || strings.Contains(v, "{!"+name+".") {
+				return true
+			}
+		}
+		return false
+	}
+	var issues []FlowIssue
+	for _, v := range f.Variables {
+		if v.IsInput.ToBool() || v.IsOutput.ToBool() || used(v.Name) {
+			continue
+		}
+		issues = append(issues, FlowIssue{Element: flow.ElementName(v.Name), Message: fmt.Sprintf("variable %s is not used", v.Name)})
+	}
+	return issues
+}
+
+// collectStrings appends the text of the flow's elements, except for names
+// and descriptions, to values
+func collectStrings(v reflect.Value, values *[]string) {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			collectStrings(v.Elem(), values)
+		}
+	case reflect.String:
+		*values = append(*values, v.String())
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			collectStrings(v.Index(i), values)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() || field.Name == "Name" || field.Name == "Description" || field.Anonymous {
+				continue
+			}
+			collectStrings(v.Field(i), values)
+		}
+	}
+}
+
+// Record ids are 15 or 18 characters: a 3-character key prefix, a
+// 2-character instance id, a reserved character that is currently always 0,
+// and a 9-character record number, optionally followed by a 3-character
+// checksum.
+var idPattern = regexp.MustCompile(`^[a-zA-Z0-9]{5}0[a-zA-Z0-9]{9}([a-zA-Z0-9]{3})?$`)
+
+func looksLikeId(s string) bool {
+	return idPattern.MatchString(s) && strings.ContainsAny(s, "0123456789") && strings.ContainsAny(s, "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz")
+}
+
+func hardcodedId(f *flow.Flow, _ FlowConfig) []FlowIssue {
+	var issues []FlowIssue
+	check := func(name flow.ElementName, item reflect.Value) {
+		var values []*flow.Value
+		collectValues(item, &values)
+		for _, value := range values {
+			if value.StringValue != nil && looksLikeId(value.StringValue.Text) {
+				issues = append(issues, FlowIssue{Element: name, Message: fmt.Sprintf("%s contains hard-coded id %s", name, value.StringValue.Text)})
+			}
+		}
+	}
+	v := reflect.ValueOf(f).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		if !v.Type().Field(i).IsExported() {
+			continue
+		}
+		field := v.Field(i)
+		if field.Kind() != reflect.Slice {
+			// Elements that only appear once, such as start, are named by
+			// their tag
+			tag, _, _ := strings.Cut(v.Type().Field(i).Tag.Get("xml"), ",")
+			check(flow.ElementName(tag), field)
+			continue
+		}
+		for j := 0; j < field.Len(); j++ {
+			item := field.Index(j)
+			check(elementName(item), item)
+		}
+	}
+	return issues
+}
+
+var valueType = reflect.TypeOf(flow.Value{})
+
+// collectValues appends the flow.Values within v to values
+func collectValues(v reflect.Value, values *[]*flow.Value) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if !v.IsNil() {
+			collectValues(v.Elem(), values)
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			collectValues(v.Index(i), values)
+		}
+	case reflect.Struct:
+		if v.Type() == valueType {
+			if v.CanAddr() {
+				*values = append(*values, v.Addr().Interface().(*flow.Value))
+			}
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				collectValues(v.Field(i), values)
+			}
+		}
+	}
+}
+
+// elementName returns the name of a flow element or resource.  Names are
+// stored as strings, ElementNames, or text elements.
+func elementName(v reflect.Value) flow.ElementName {
+	name := v.FieldByName("Name")
+	if !name.IsValid() {
+		return ""
+	}
+	if name.Kind() == reflect.Pointer {
+		if name.IsNil() {
+			return ""
+		}
+		name = name.Elem()
+	}
+	switch name.Kind() {
+	case reflect.String:
+		return flow.ElementName(name.String())
+	case reflect.Struct:
+		if text := name.FieldByName("Text"); text.IsValid() {
+			return flow.ElementName(text.String())
+		}
+	}
+	return ""
+}
+
+func missingEntryCriteria(f *flow.Flow, _ FlowConfig) []FlowIssue {
+	s := f.Start
+	if s == nil || s.Object == nil || s.TriggerType == nil || !strings.HasPrefix(*s.TriggerType, "Record") {
+		return nil
+	}
+	if len(s.Filters) > 0 || s.FilterFormula != nil && strings.TrimSpace(s.FilterFormula.String()) != "" {
+		return nil
+	}
+	return []FlowIssue{{Message: fmt.Sprintf("record-triggered flow on %s has no entry criteria", *s.Object)}}
+}
+
+func apiVersion(f *flow.Flow, config FlowConfig) []FlowIssue {
+	if f.ApiVersion == nil {
+		return []FlowIssue{{Message: "flow has no API version"}}
+	}
+	version, err := strconv.ParseFloat(f.ApiVersion.Text, 64)
+	if err != nil {
+		return []FlowIssue{{Message: "invalid API version " + f.ApiVersion.Text}}
+	}
+	if version < config.MinApiVersion {
+		return []FlowIssue{{Message: fmt.Sprintf("API version %s is below %.1f", f.ApiVersion.Text, config.MinApiVersion)}}
+	}
+	return nil
+}
+
+func elementsByName(f *flow.Flow) map[flow.ElementName]flow.Element {
+	elements := make(map[flow.ElementName]flow.Element)
+	for _, e := range f.Elements() {
+		elements[e.Name] = e
+	}
+	return elements
+}
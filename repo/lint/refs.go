@@ -0,0 +1,253 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ForceCLI/force-md/metadata"
+	"github.com/ForceCLI/force-md/metadata/application"
+	apexClass "github.com/ForceCLI/force-md/metadata/classes"
+	"github.com/ForceCLI/force-md/metadata/customPermissions"
+	"github.com/ForceCLI/force-md/metadata/flow"
+	layout "github.com/ForceCLI/force-md/metadata/layouts"
+	"github.com/ForceCLI/force-md/metadata/objects"
+	"github.com/ForceCLI/force-md/metadata/objects/field"
+	"github.com/ForceCLI/force-md/metadata/objects/recordtype"
+	apexPage "github.com/ForceCLI/force-md/metadata/pages"
+	"github.com/ForceCLI/force-md/metadata/permissionset"
+	"github.com/ForceCLI/force-md/metadata/profile"
+	tab "github.com/ForceCLI/force-md/metadata/tabs"
+	"github.com/ForceCLI/force-md/repo"
+)
+
+// Reference is a reference from metadata to another component
+type Reference struct {
+	// The element containing the reference, e.g. fieldPermissions
+	Element string
+	Type    metadata.MetadataType
+	Name    string
+}
+
+func (r Reference) String() string {
+	return fmt.Sprintf("%s: %s %s not found", r.Element, r.Type, r.Name)
+}
+
+// RefChecker finds references from profiles and permission sets to
+// components that don't exist in a repository
+type RefChecker struct {
+	catalog *catalog
+}
+
+func NewRefChecker(r *repo.Repo) *RefChecker {
+	return &RefChecker{catalog: newCatalog(r)}
+}
+
+// MissingReferences returns the references in m to components that don't
+// exist
+func (c *RefChecker) MissingReferences(m metadata.RegisterableMetadata) []Reference {
+	return c.check(m, false)
+}
+
+// RemoveMissingReferences removes the references in m to components that
+// don't exist, and returns the references removed
+func (c *RefChecker) RemoveMissingReferences(m metadata.RegisterableMetadata) []Reference {
+	return c.check(m, true)
+}
+
+func (c *RefChecker) check(m metadata.RegisterableMetadata, fix bool) []Reference {
+	ch := &checker{catalog: c.catalog, fix: fix}
+	switch m := m.(type) {
+	case *permissionset.PermissionSet:
+		ch.permissionSet(m)
+	case *profile.Profile:
+		ch.profile(m)
+	}
+	return ch.missing
+}
+
+type checker struct {
+	catalog *catalog
+	fix     bool
+	missing []Reference
+}
+
+// keep records the reference if the component is missing, and returns
+// whether the element containing the reference should be kept
+func (c *checker) keep(element string, t metadata.MetadataType, name string) bool {
+	if name == "" || c.catalog.exists(t, name) {
+		return true
+	}
+	c.missing = append(c.missing, Reference{Element: element, Type: t, Name: name})
+	return !c.fix
+}
+
+func (c *checker) permissionSet(p *permissionset.PermissionSet) {
+	applications := p.ApplicationVisibilities[:0]
+	for _, a := range p.ApplicationVisibilities {
+		if c.keep("applicationVisibilities", application.NAME, a.Application) {
+			applications = append(applications, a)
+		}
+	}
+	p.ApplicationVisibilities = applications
+
+	p.ClassAccesses = c.classAccesses(p.ClassAccesses)
+	p.CustomMetadataTypeAccesses = c.customMetadataTypeAccesses(p.CustomMetadataTypeAccesses)
+	p.CustomPermissions = c.customPermissions(p.CustomPermissions)
+	p.CustomSettingAccesses = c.customSettingAccesses(p.CustomSettingAccesses)
+	p.FieldPermissions = c.fieldPermissions(p.FieldPermissions)
+	p.FlowAccesses = c.flowAccesses(p.FlowAccesses)
+	p.ObjectPermissions = c.objectPermissions(p.ObjectPermissions)
+	p.PageAccesses = c.pageAccesses(p.PageAccesses)
+
+	recordTypes := p.RecordTypeVisibilities[:0]
+	for _, r := range p.RecordTypeVisibilities {
+		if c.keep("recordTypeVisibilities", recordtype.NAME, r.RecordType) {
+			recordTypes = append(recordTypes, r)
+		}
+	}
+	p.RecordTypeVisibilities = recordTypes
+
+	tabs := p.TabSettings[:0]
+	for _, t := range p.TabSettings {
+		if c.keepTab("tabSettings", t.Tab) {
+			tabs = append(tabs, t)
+		}
+	}
+	p.TabSettings = tabs
+}
+
+func (c *checker) profile(p *profile.Profile) {
+	applications := p.ApplicationVisibilities[:0]
+	for _, a := range p.ApplicationVisibilities {
+		if c.keep("applicationVisibilities", application.NAME, a.Application) {
+			applications = append(applications, a)
+		}
+	}
+	p.ApplicationVisibilities = applications
+
+	p.ClassAccesses = c.classAccesses(p.ClassAccesses)
+	p.CustomMetadataTypeAccesses = c.customMetadataTypeAccesses(p.CustomMetadataTypeAccesses)
+	p.CustomPermissions = c.customPermissions(p.CustomPermissions)
+	p.CustomSettingAccesses = c.customSettingAccesses(p.CustomSettingAccesses)
+	p.FieldPermissions = c.fieldPermissions(p.FieldPermissions)
+	p.FlowAccesses = c.flowAccesses(p.FlowAccesses)
+
+	layouts := p.LayoutAssignments[:0]
+	for _, l := range p.LayoutAssignments {
+		keep := c.keep("layoutAssignments", layout.NAME, l.Layout)
+		if l.RecordType != nil {
+			keep = c.keep("layoutAssignments", recordtype.NAME, l.RecordType.Text) && keep
+		}
+		if keep {
+			layouts = append(layouts, l)
+		}
+	}
+	p.LayoutAssignments = layouts
+
+	p.ObjectPermissions = c.objectPermissions(p.ObjectPermissions)
+	p.PageAccesses = c.pageAccesses(p.PageAccesses)
+
+	recordTypes := p.RecordTypeVisibilities[:0]
+	for _, r := range p.RecordTypeVisibilities {
+		if c.keep("recordTypeVisibilities", recordtype.NAME, r.RecordType) {
+			recordTypes = append(recordTypes, r)
+		}
+	}
+	p.RecordTypeVisibilities = recordTypes
+
+	tabs := p.TabVisibilities[:0]
+	for _, t := range p.TabVisibilities {
+		if c.keepTab("tabVisibilities", t.Tab) {
+			tabs = append(tabs, t)
+		}
+	}
+	p.TabVisibilities = tabs
+}
+
+// Standard tabs are prefixed with standard-
+func (c *checker) keepTab(element, name string) bool {
+	if strings.HasPrefix(name, "standard-") {
+		return true
+	}
+	return c.keep(element, tab.NAME, name)
+}
+
+func (c *checker) classAccesses(classes permissionset.ApexClassList) permissionset.ApexClassList {
+	kept := classes[:0]
+	for _, a := range classes {
+		if c.keep("classAccesses", apexClass.NAME, a.ApexClass) {
+			kept = append(kept, a)
+		}
+	}
+	return kept
+}
+
+func (c *checker) customMetadataTypeAccesses(types permissionset.CustomMetadataTypeList) permissionset.CustomMetadataTypeList {
+	kept := types[:0]
+	for _, t := range types {
+		if c.keep("customMetadataTypeAccesses", objects.NAME, t.Name) {
+			kept = append(kept, t)
+		}
+	}
+	return kept
+}
+
+func (c *checker) customPermissions(permissions permissionset.CustomPermissionList) permissionset.CustomPermissionList {
+	kept := permissions[:0]
+	for _, p := range permissions {
+		if c.keep("customPermissions", customPermissions.NAME, p.Name) {
+			kept = append(kept, p)
+		}
+	}
+	return kept
+}
+
+func (c *checker) customSettingAccesses(settings permissionset.CustomSettingList) permissionset.CustomSettingList {
+	kept := settings[:0]
+	for _, s := range settings {
+		if c.keep("customSettingAccesses", objects.NAME, s.Name) {
+			kept = append(kept, s)
+		}
+	}
+	return kept
+}
+
+func (c *checker) fieldPermissions(fields permissionset.FieldPermissionsList) permissionset.FieldPermissionsList {
+	kept := fields[:0]
+	for _, f := range fields {
+		if c.keep("fieldPermissions", field.NAME, f.Field) {
+			kept = append(kept, f)
+		}
+	}
+	return kept
+}
+
+func (c *checker) flowAccesses(flows permissionset.FlowAccessList) permissionset.FlowAccessList {
+	kept := flows[:0]
+	for _, f := range flows {
+		if c.keep("flowAccesses", flow.NAME, f.Flow) {
+			kept = append(kept, f)
+		}
+	}
+	return kept
+}
+
+func (c *checker) objectPermissions(permissions permissionset.ObjectPermissionsList) permissionset.ObjectPermissionsList {
+	kept := permissions[:0]
+	for _, o := range permissions {
+		if c.keep("objectPermissions", objects.NAME, o.Object) {
+			kept = append(kept, o)
+		}
+	}
+	return kept
+}
+
+func (c *checker) pageAccesses(pages permissionset.PageAccessList) permissionset.PageAccessList {
+	kept := pages[:0]
+	for _, p := range pages {
+		if c.keep("pageAccesses", apexPage.NAME, p.ApexPage) {
+			kept = append(kept, p)
+		}
+	}
+	return kept
+}
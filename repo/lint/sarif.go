@@ -0,0 +1,113 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+)
+
+// FlowResults are the issues found in a flow file
+type FlowResults struct {
+	Path   string
+	Issues []FlowIssue
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationUri string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	Id                   string       `json:"id"`
+	ShortDescription     sarifMessage `json:"shortDescription"`
+	DefaultConfiguration struct {
+		Level Level `json:"level"`
+	} `json:"defaultConfiguration"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleId    string          `json:"ruleId"`
+	Level     Level           `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation struct {
+		ArtifactLocation struct {
+			Uri string `json:"uri"`
+		} `json:"artifactLocation"`
+		Region *struct {
+			StartLine int `json:"startLine"`
+		} `json:"region,omitempty"`
+	} `json:"physicalLocation"`
+}
+
+// FlowSARIF returns flow lint results in SARIF format.  Issues are located
+// on the line where their element is named.
+func FlowSARIF(results []FlowResults) ([]byte, error) {
+	driver := sarifDriver{Name: "force-md", InformationUri: "https://github.com/ForceCLI/force-md"}
+	for _, r := range FlowRules {
+		rule := sarifRule{Id: r.Id, ShortDescription: sarifMessage{Text: r.Description}}
+		rule.DefaultConfiguration.Level = r.Level
+		driver.Rules = append(driver.Rules, rule)
+	}
+	run := sarifRun{Tool: sarifTool{Driver: driver}, Results: []sarifResult{}}
+	for _, file := range results {
+		contents, _ := os.ReadFile(file.Path)
+		for _, i := range file.Issues {
+			var location sarifLocation
+			location.PhysicalLocation.ArtifactLocation.Uri = file.Path
+			if line := elementLine(contents, string(i.Element)); line > 0 {
+				location.PhysicalLocation.Region = &struct {
+					StartLine int `json:"startLine"`
+				}{StartLine: line}
+			}
+			run.Results = append(run.Results, sarifResult{
+				RuleId:    i.Rule,
+				Level:     i.Level,
+				Message:   sarifMessage{Text: i.Message},
+				Locations: []sarifLocation{location},
+			})
+		}
+	}
+	log := sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	}
+	return json.MarshalIndent(log, "", "  ")
+}
+
+// elementLine returns the line number on which an element is named, or 0 if
+// it isn't found
+func elementLine(contents []byte, name string) int {
+	if name == "" {
+		return 0
+	}
+	i := bytes.Index(contents, []byte("<name>"+name+"</name>"))
+	if i < 0 {
+		return 0
+	}
+	return bytes.Count(contents[:i], []byte("\n")) + 1
+}
@@ -0,0 +1,65 @@
+package manifest
+
+import (
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/ForceCLI/force-md/metadata/pkg"
+	"github.com/ForceCLI/force-md/repo"
+)
+
+// Generate returns a package.xml listing all of the metadata in r.  Members
+// matching any of the exclude patterns are left out.  See Excluded.
+func Generate(r *repo.Repo, version string, exclude []string) (pkg.Package, error) {
+	for _, pattern := range exclude {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return pkg.Package{}, errors.Wrap(err, "invalid exclude pattern "+pattern)
+		}
+	}
+	members := make(map[string]map[string]bool)
+	for _, t := range r.Types() {
+		for _, m := range r.Items(t) {
+			c, ok := ComponentOf(m)
+			if !ok || Excluded(c.Member, exclude) {
+				continue
+			}
+			if members[c.Type] == nil {
+				members[c.Type] = make(map[string]bool)
+			}
+			members[c.Type][c.Member] = true
+		}
+	}
+	p := pkg.NewPackage(version)
+	for t, names := range members {
+		items := pkg.MetadataItems{Name: t}
+		for name := range names {
+			items.Members = append(items.Members, pkg.Member(name))
+		}
+		p.Types = append(p.Types, items)
+	}
+	p.Tidy()
+	return p, nil
+}
+
+// Excluded returns whether a member matches any of the patterns.  A pattern
+// can match the whole member name, or any part of it separated by "." or "/",
+// so npsp__* matches npsp__Trigger_Handler__c, Account.npsp__Batch__c, and
+// npsp__Reports/Donations.
+func Excluded(member string, patterns []string) bool {
+	parts := strings.FieldsFunc(member, func(r rune) bool {
+		return r == '.' || r == '/'
+	})
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, member); ok {
+			return true
+		}
+		for _, part := range parts {
+			if ok, _ := path.Match(pattern, part); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
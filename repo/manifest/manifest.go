@@ -0,0 +1,155 @@
+// Package manifest maps metadata to the types and members used in
+// package.xml.
+package manifest
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/ForceCLI/force-md/metadata"
+	"github.com/ForceCLI/force-md/metadata/dashboard"
+	"github.com/ForceCLI/force-md/metadata/dashboardFolder"
+	"github.com/ForceCLI/force-md/metadata/documentFolder"
+	document "github.com/ForceCLI/force-md/metadata/documents"
+	"github.com/ForceCLI/force-md/metadata/emailFolder"
+	"github.com/ForceCLI/force-md/metadata/emailTemplate"
+	"github.com/ForceCLI/force-md/metadata/objectTranslations"
+	"github.com/ForceCLI/force-md/metadata/objectTranslations/field"
+	"github.com/ForceCLI/force-md/metadata/objects/businessprocess"
+	"github.com/ForceCLI/force-md/metadata/objects/compactlayout"
+	objectfield "github.com/ForceCLI/force-md/metadata/objects/field"
+	"github.com/ForceCLI/force-md/metadata/objects/fieldset"
+	"github.com/ForceCLI/force-md/metadata/objects/index"
+	"github.com/ForceCLI/force-md/metadata/objects/listview"
+	"github.com/ForceCLI/force-md/metadata/objects/recordtype"
+	"github.com/ForceCLI/force-md/metadata/objects/sharingreason"
+	"github.com/ForceCLI/force-md/metadata/objects/validationrule"
+	"github.com/ForceCLI/force-md/metadata/objects/weblink"
+	"github.com/ForceCLI/force-md/metadata/pkg"
+	"github.com/ForceCLI/force-md/metadata/reportFolder"
+	report "github.com/ForceCLI/force-md/metadata/reports"
+	"github.com/ForceCLI/force-md/metadata/settings"
+)
+
+// Component is a member of a metadata type in package.xml
+type Component struct {
+	Type   string
+	Member string
+}
+
+// Child components of objects, which are named Object.Child
+var childTypes = map[metadata.MetadataType]bool{
+	businessprocess.NAME: true,
+	compactlayout.NAME:   true,
+	objectfield.NAME:     true,
+	fieldset.NAME:        true,
+	index.NAME:           true,
+	listview.NAME:        true,
+	recordtype.NAME:      true,
+	sharingreason.NAME:   true,
+	validationrule.NAME:  true,
+	weblink.NAME:         true,
+}
+
+// The directory containing each folder-based type.  Members are named
+// Folder/Name.
+var folderDirectories = map[string]string{
+	dashboard.NAME:     "dashboards",
+	document.NAME:      "documents",
+	emailTemplate.NAME: "email",
+	report.NAME:        "reports",
+}
+
+// Folders are members of the type of metadata they contain
+var folderTypes = map[metadata.MetadataType]string{
+	dashboardFolder.NAME: dashboard.NAME,
+	documentFolder.NAME:  document.NAME,
+	emailFolder.NAME:     emailTemplate.NAME,
+	reportFolder.NAME:    report.NAME,
+}
+
+// ComponentOf returns the package.xml type and member for metadata.  False
+// is returned for metadata that isn't deployed, such as package.xml itself.
+func ComponentOf(m metadata.RegisterableMetadata) (Component, bool) {
+	file := filepath.ToSlash(string(m.GetMetadataInfo().Path()))
+	name := string(m.GetMetadataInfo().Name())
+	t := m.Type()
+	if _, ok := m.(*settings.Settings); ok {
+		t = "Settings"
+	}
+	switch {
+	case t == pkg.NAME:
+		return Component{}, false
+	case childTypes[t]:
+		return Component{Type: t, Member: childName(name)}, true
+	case t == field.FIELD_TRANSLATIONS_NAME:
+		// Field translations are part of the decomposed object translation
+		// in the parent directory
+		return Component{Type: objectTranslations.OBJECT_TRANSLATIONS_NAME, Member: path.Base(path.Dir(file))}, true
+	case folderTypes[t] != "":
+		t = folderTypes[t]
+		return Component{Type: t, Member: folderMember(file, folderDirectories[t])}, true
+	case t == document.NAME:
+		return Component{Type: t, Member: documentMember(file)}, true
+	case folderDirectories[t] != "":
+		return Component{Type: t, Member: folderMember(file, folderDirectories[t])}, true
+	}
+	return Component{Type: t, Member: name}, true
+}
+
+// childName removes the suffix from the name of an object's child component
+// in sfdx format, e.g. Account.Foo__c.field is Account.Foo__c
+func childName(name string) string {
+	if strings.Count(name, ".") < 2 {
+		return name
+	}
+	return name[:strings.LastIndex(name, ".")]
+}
+
+// folderMember returns the path of a file relative to the directory for its
+// type, without its suffix, e.g. reports/Sales/Pipeline.report-meta.xml is
+// Sales/Pipeline
+func folderMember(file string, dir string) string {
+	member := relativeMember(file, dir)
+	return strings.TrimSuffix(member, path.Ext(member))
+}
+
+func relativeMember(file string, dir string) string {
+	member := strings.TrimSuffix(file, "-meta.xml")
+	if i := strings.LastIndex(member, "/"+dir+"/"); i >= 0 {
+		return member[i+len(dir)+2:]
+	}
+	return strings.TrimPrefix(member, dir+"/")
+}
+
+// documentMember returns the member name for a document, which includes the
+// extension of the document's content file, e.g. Folder/Logo.png
+func documentMember(file string) string {
+	if !strings.HasSuffix(file, ".document-meta.xml") {
+		// Metadata API format, e.g. documents/Folder/Logo.png-meta.xml
+		return relativeMember(file, folderDirectories[document.NAME])
+	}
+	member := folderMember(file, folderDirectories[document.NAME])
+	if content, ok := documentContentFile(file); ok {
+		return path.Join(path.Dir(member), path.Base(content))
+	}
+	return member
+}
+
+// documentContentFile returns the content file for a document's
+// .document-meta.xml file in sfdx format, e.g. Folder/Logo.png for
+// Folder/Logo.document-meta.xml
+func documentContentFile(file string) (string, bool) {
+	matches, _ := filepath.Glob(filepath.FromSlash(strings.TrimSuffix(file, ".document-meta.xml")) + ".*")
+	for _, m := range matches {
+		if strings.HasSuffix(m, "-meta.xml") {
+			continue
+		}
+		if info, err := os.Stat(m); err == nil && !info.IsDir() {
+			return filepath.ToSlash(m), true
+		}
+	}
+	return "", false
+}
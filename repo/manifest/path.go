@@ -0,0 +1,175 @@
+package manifest
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+
+	document "github.com/ForceCLI/force-md/metadata/documents"
+	"github.com/ForceCLI/force-md/metadata/objectTranslations"
+	"github.com/ForceCLI/force-md/metadata/objects/split"
+)
+
+// Bundles are made up of all of the files in a directory within these
+// directories
+var bundleDirectories = map[string]string{
+	"aura":            "AuraDefinitionBundle",
+	"experiences":     "ExperienceBundle",
+	"lwc":             "LightningComponentBundle",
+	"staticresources": "StaticResource",
+}
+
+type suffixType struct {
+	Type      string
+	Directory string
+}
+
+// The metadata type for each file suffix, and the directory containing the
+// type in Metadata API format
+var suffixTypes = map[string]suffixType{
+	"app":                       {"CustomApplication", "applications"},
+	"appMenu":                   {"AppMenu", "appMenus"},
+	"asset":                     {"ContentAsset", "contentassets"},
+	"assignmentRules":           {"AssignmentRules", "assignmentRules"},
+	"autoResponseRules":         {"AutoResponseRules", "autoResponseRules"},
+	"cls":                       {"ApexClass", "classes"},
+	"community":                 {"Community", "communities"},
+	"component":                 {"ApexComponent", "components"},
+	"connectedApp":              {"ConnectedApp", "connectedApps"},
+	"customPermission":          {"CustomPermission", "customPermissions"},
+	"delegateGroup":             {"DelegateGroup", "delegateGroups"},
+	"duplicateRule":             {"DuplicateRule", "duplicateRules"},
+	"externalCredential":        {"ExternalCredential", "externalCredentials"},
+	"flexipage":                 {"FlexiPage", "flexipages"},
+	"flow":                      {"Flow", "flows"},
+	"flowDefinition":            {"FlowDefinition", "flowDefinitions"},
+	"globalValueSet":            {"GlobalValueSet", "globalValueSets"},
+	"globalValueSetTranslation": {"GlobalValueSetTranslation", "globalValueSetTranslations"},
+	"group":                     {"Group", "groups"},
+	"homePageComponent":         {"HomePageComponent", "homePageComponents"},
+	"homePageLayout":            {"HomePageLayout", "homePageLayouts"},
+	"installedPackage":          {"InstalledPackage", "installedPackages"},
+	"labels":                    {"CustomLabels", "labels"},
+	"layout":                    {"Layout", "layouts"},
+	"letter":                    {"Letterhead", "letterhead"},
+	"matchingRule":              {"MatchingRules", "matchingRules"},
+	"md":                        {"CustomMetadata", "customMetadata"},
+	"messageChannel":            {"LightningMessageChannel", "messageChannels"},
+	"mutingpermissionset":       {"MutingPermissionSet", "mutingpermissionsets"},
+	"namedCredential":           {"NamedCredential", "namedCredentials"},
+	"network":                   {"Network", "networks"},
+	"notiftype":                 {"CustomNotificationType", "notificationtypes"},
+	"object":                    {"CustomObject", "objects"},
+	"objectTranslation":         {objectTranslations.OBJECT_TRANSLATIONS_NAME, "objectTranslations"},
+	"page":                      {"ApexPage", "pages"},
+	"permissionset":             {"PermissionSet", "permissionsets"},
+	"permissionsetgroup":        {"PermissionSetGroup", "permissionsetgroups"},
+	"profile":                   {"Profile", "profiles"},
+	"queue":                     {"Queue", "queues"},
+	"quickAction":               {"QuickAction", "quickActions"},
+	"remoteSite":                {"RemoteSiteSetting", "remoteSiteSettings"},
+	"reportType":                {"ReportType", "reportTypes"},
+	"role":                      {"Role", "roles"},
+	"settings":                  {"Settings", "settings"},
+	"sharingRules":              {"SharingRules", "sharingRules"},
+	"sharingSet":                {"SharingSet", "sharingSets"},
+	"site":                      {"CustomSite", "sites"},
+	"snapshot":                  {"AnalyticSnapshot", "analyticSnapshots"},
+	"standardValueSet":          {"StandardValueSet", "standardValueSets"},
+	"tab":                       {"CustomTab", "tabs"},
+	"translation":               {"Translations", "translations"},
+	"trigger":                   {"ApexTrigger", "triggers"},
+	"weblink":                   {"CustomPageWebLink", "weblinks"},
+	"workflow":                  {"Workflow", "workflows"},
+}
+
+// The suffixes of object child components in sfdx format
+var childSuffixes = map[string]string{
+	"businessProcess": "BusinessProcess",
+	"compactLayout":   "CompactLayout",
+	"field":           "CustomField",
+	"fieldSet":        "FieldSet",
+	"index":           "Index",
+	"listView":        "ListView",
+	"recordType":      "RecordType",
+	"sharingReason":   "SharingReason",
+	"validationRule":  "ValidationRule",
+	"webLink":         "WebLink",
+}
+
+// The suffixes of folders in sfdx format
+var folderSuffixes = map[string]string{
+	"dashboardFolder": "Dashboard",
+	"documentFolder":  "Document",
+	"emailFolder":     "EmailTemplate",
+	"reportFolder":    "Report",
+}
+
+// ComponentFromPath infers the package.xml type and member for a file from
+// its path alone, so it can be used for files that no longer exist.  False is
+// returned if the file doesn't look like metadata.
+func ComponentFromPath(file string) (Component, bool) {
+	file = filepath.ToSlash(filepath.Clean(file))
+	parts := strings.Split(file, "/")
+	for i, dir := range parts[:len(parts)-1] {
+		if t, ok := bundleDirectories[dir]; ok {
+			name := strings.TrimSuffix(parts[i+1], "-meta.xml")
+			name, _, _ = strings.Cut(name, ".")
+			return Component{Type: t, Member: name}, true
+		}
+	}
+
+	base := parts[len(parts)-1]
+	isMeta := strings.HasSuffix(base, "-meta.xml")
+	name := strings.TrimSuffix(base, "-meta.xml")
+	suffix := strings.TrimPrefix(path.Ext(name), ".")
+	parent := ""
+	if len(parts) > 1 {
+		parent = parts[len(parts)-2]
+	}
+
+	switch {
+	case isMeta && childSuffixes[suffix] != "":
+		return Component{Type: childSuffixes[suffix], Member: childName(string(split.NameFromPath(file)))}, true
+	case isMeta && suffix == "fieldTranslation":
+		return Component{Type: objectTranslations.OBJECT_TRANSLATIONS_NAME, Member: parent}, true
+	case isMeta && folderSuffixes[suffix] != "":
+		t := folderSuffixes[suffix]
+		return Component{Type: t, Member: folderMember(file, folderDirectories[t])}, true
+	}
+
+	for t, dir := range folderDirectories {
+		if !containsDirectory(parts, dir) {
+			continue
+		}
+		switch {
+		case t == document.NAME && suffix == "document":
+			// The member is named by the content file, which has the
+			// document's extension.  If it no longer exists, its own change
+			// includes the document.
+			content, ok := documentContentFile(file)
+			if !ok {
+				return Component{}, false
+			}
+			return Component{Type: t, Member: relativeMember(content, dir)}, true
+		case t == document.NAME, isMeta && suffix == "":
+			// Documents and folders in Metadata API format
+			return Component{Type: t, Member: relativeMember(file, dir)}, true
+		}
+		return Component{Type: t, Member: folderMember(file, dir)}, true
+	}
+
+	if s, ok := suffixTypes[suffix]; ok && (isMeta || parent == s.Directory) {
+		return Component{Type: s.Type, Member: strings.TrimSuffix(name, "."+suffix)}, true
+	}
+	return Component{}, false
+}
+
+func containsDirectory(parts []string, dir string) bool {
+	for _, p := range parts[:len(parts)-1] {
+		if p == dir {
+			return true
+		}
+	}
+	return false
+}
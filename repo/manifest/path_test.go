@@ -0,0 +1,87 @@
+package manifest_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/ForceCLI/force-md/repo/manifest"
+)
+
+func TestComponentFromPath(t *testing.T) {
+	tests := []struct {
+		file     string
+		expected Component
+		ok       bool
+	}{
+		// sfdx format
+		{"force-app/main/default/classes/Deleted.cls", Component{Type: "ApexClass", Member: "Deleted"}, true},
+		{"force-app/main/default/classes/Deleted.cls-meta.xml", Component{Type: "ApexClass", Member: "Deleted"}, true},
+		{"force-app/main/default/lwc/myCard/myCard.js", Component{Type: "LightningComponentBundle", Member: "myCard"}, true},
+		{"force-app/main/default/lwc/myCard/templates/detail.html", Component{Type: "LightningComponentBundle", Member: "myCard"}, true},
+		{"force-app/main/default/aura/Banner/BannerController.js", Component{Type: "AuraDefinitionBundle", Member: "Banner"}, true},
+		{"force-app/main/default/staticresources/logo.resource-meta.xml", Component{Type: "StaticResource", Member: "logo"}, true},
+		{"force-app/main/default/objects/Account/Account.object-meta.xml", Component{Type: "CustomObject", Member: "Account"}, true},
+		{"force-app/main/default/objects/Account/fields/Foo__c.field-meta.xml", Component{Type: "CustomField", Member: "Account.Foo__c"}, true},
+		{"force-app/main/default/objects/Account/recordTypes/Business.recordType-meta.xml", Component{Type: "RecordType", Member: "Account.Business"}, true},
+		{"force-app/main/default/objects/Account/validationRules/Require_Name.validationRule-meta.xml", Component{Type: "ValidationRule", Member: "Account.Require_Name"}, true},
+		{"force-app/main/default/objectTranslations/Account-es/Foo__c.fieldTranslation-meta.xml", Component{Type: "CustomObjectTranslation", Member: "Account-es"}, true},
+		{"force-app/main/default/profiles/Admin.profile-meta.xml", Component{Type: "Profile", Member: "Admin"}, true},
+		{"force-app/main/default/flows/My_Flow.flow-meta.xml", Component{Type: "Flow", Member: "My_Flow"}, true},
+		{"force-app/main/default/reports/Sales/Pipeline.report-meta.xml", Component{Type: "Report", Member: "Sales/Pipeline"}, true},
+		{"force-app/main/default/reports/Sales.reportFolder-meta.xml", Component{Type: "Report", Member: "Sales"}, true},
+		{"force-app/main/default/email/Support/Welcome.email-meta.xml", Component{Type: "EmailTemplate", Member: "Support/Welcome"}, true},
+		{"force-app/main/default/email/Support/Welcome.email", Component{Type: "EmailTemplate", Member: "Support/Welcome"}, true},
+
+		// Metadata API format
+		{"src/classes/Deleted.cls", Component{Type: "ApexClass", Member: "Deleted"}, true},
+		{"src/objects/Account.object", Component{Type: "CustomObject", Member: "Account"}, true},
+		{"src/profiles/Admin.profile", Component{Type: "Profile", Member: "Admin"}, true},
+		{"src/reports/Sales/Pipeline.report", Component{Type: "Report", Member: "Sales/Pipeline"}, true},
+		{"src/reports/Sales-meta.xml", Component{Type: "Report", Member: "Sales"}, true},
+		{"src/documents/Images/Logo.png", Component{Type: "Document", Member: "Images/Logo.png"}, true},
+		{"src/documents/Images/Logo.png-meta.xml", Component{Type: "Document", Member: "Images/Logo.png"}, true},
+
+		// Not metadata
+		{"README.md", Component{}, false},
+		{"sfdx-project.json", Component{}, false},
+		{"force-app/main/default/classes/notes.txt", Component{}, false},
+		{"scripts/apex/hello.apex", Component{}, false},
+	}
+
+	for _, test := range tests {
+		c, ok := ComponentFromPath(test.file)
+		if ok != test.ok || c != test.expected {
+			t.Errorf("%s: expected %v %v, got %v %v", test.file, test.expected, test.ok, c, ok)
+		}
+	}
+}
+
+func TestComponentFromPathDocument(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "force-app", "main", "default", "documents", "Images")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	meta := filepath.Join(dir, "Logo.document-meta.xml")
+	content := filepath.Join(dir, "Logo.png")
+	for _, f := range []string{meta, content} {
+		if err := os.WriteFile(f, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expected := Component{Type: "Document", Member: "Images/Logo.png"}
+	for _, f := range []string{meta, content} {
+		if c, ok := ComponentFromPath(f); !ok || c != expected {
+			t.Errorf("%s: expected %v, got %v %v", f, expected, c, ok)
+		}
+	}
+
+	// Without the content file, the meta file can't be mapped, but the
+	// deleted content file's own change includes the document
+	if err := os.Remove(content); err != nil {
+		t.Fatal(err)
+	}
+	if c, ok := ComponentFromPath(meta); ok {
+		t.Errorf("%s: expected no component without content file, got %v", meta, c)
+	}
+}
@@ -0,0 +1,227 @@
+package manifest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ForceCLI/force-md/internal"
+	"github.com/ForceCLI/force-md/metadata/objects"
+	"github.com/ForceCLI/force-md/metadata/objects/businessprocess"
+	"github.com/ForceCLI/force-md/metadata/objects/compactlayout"
+	objectfield "github.com/ForceCLI/force-md/metadata/objects/field"
+	"github.com/ForceCLI/force-md/metadata/objects/fieldset"
+	"github.com/ForceCLI/force-md/metadata/objects/index"
+	"github.com/ForceCLI/force-md/metadata/objects/listview"
+	"github.com/ForceCLI/force-md/metadata/objects/recordtype"
+	"github.com/ForceCLI/force-md/metadata/objects/sharingreason"
+	"github.com/ForceCLI/force-md/metadata/objects/validationrule"
+	"github.com/ForceCLI/force-md/metadata/objects/weblink"
+	"github.com/ForceCLI/force-md/metadata/pkg"
+	"github.com/ForceCLI/force-md/repo"
+)
+
+type ProblemKind string
+
+const (
+	// A member is listed that has no source file
+	MissingSource ProblemKind = "missing-source"
+	// A source file isn't covered by any member or wildcard
+	NotInManifest ProblemKind = "not-in-manifest"
+	// A type name isn't a known metadata type
+	UnknownType ProblemKind = "unknown-type"
+	// A child member is listed whose parent doesn't exist
+	MissingParent ProblemKind = "missing-parent"
+)
+
+// Problem is a discrepancy between a package.xml and the metadata in a
+// repository
+type Problem struct {
+	Kind   ProblemKind
+	Type   string
+	Member string
+	// The source file, for components that aren't in the manifest
+	Path string
+	// The parent object or folder, for members whose parent is missing
+	Parent string
+}
+
+func (p Problem) String() string {
+	switch p.Kind {
+	case MissingSource:
+		return fmt.Sprintf("%s %s has no source file", p.Type, p.Member)
+	case NotInManifest:
+		return fmt.Sprintf("%s: %s %s is not in manifest", p.Path, p.Type, p.Member)
+	case UnknownType:
+		return fmt.Sprintf("unknown type %s", p.Type)
+	case MissingParent:
+		return fmt.Sprintf("%s %s: parent %s not found", p.Type, p.Member, p.Parent)
+	}
+	return fmt.Sprintf("%s %s: %s", p.Type, p.Member, p.Kind)
+}
+
+// source is a component in a repository and the file that contains it
+type source struct {
+	Component
+	path string
+}
+
+// Validate compares the members of a package.xml to the components in a
+// repository.  Standard objects are assumed to exist when checking the parents
+// of child members, e.g. Account.Status__c.
+func Validate(r *repo.Repo, p *pkg.Package) []Problem {
+	sources := sourceComponents(r)
+	exists := make(map[Component]bool)
+	for _, s := range sources {
+		exists[s.Component] = true
+	}
+	known := knownTypes()
+	for _, s := range sources {
+		known[s.Type] = true
+	}
+
+	var problems []Problem
+	listed := make(map[Component]bool)
+	wildcards := make(map[string]bool)
+	for _, t := range p.Types {
+		if !known[t.Name] {
+			problems = append(problems, Problem{Kind: UnknownType, Type: t.Name})
+			continue
+		}
+		for _, m := range t.Members {
+			member := string(m)
+			if member == "*" {
+				wildcards[t.Name] = true
+				continue
+			}
+			c := Component{Type: t.Name, Member: member}
+			listed[c] = true
+			if !exists[c] {
+				problems = append(problems, Problem{Kind: MissingSource, Type: t.Name, Member: member})
+			}
+			if parent, ok := parentOf(c); ok && !exists[parent] && !isStandard(parent) {
+				problems = append(problems, Problem{Kind: MissingParent, Type: t.Name, Member: member, Parent: parent.Member})
+			}
+		}
+	}
+
+	for _, s := range sources {
+		if wildcards[s.Type] || listed[s.Component] {
+			continue
+		}
+		// Listing an object includes all of its child components
+		if parent, ok := parentOf(s.Component); ok && parent.Type == objects.NAME && (listed[parent] || wildcards[parent.Type]) {
+			continue
+		}
+		problems = append(problems, Problem{Kind: NotInManifest, Type: s.Type, Member: s.Member, Path: s.path})
+	}
+	return problems
+}
+
+// sourceComponents returns the components in a repository, including the
+// child components of objects in Metadata API format
+func sourceComponents(r *repo.Repo) []source {
+	var sources []source
+	for _, t := range r.Types() {
+		for _, m := range r.Items(t) {
+			c, ok := ComponentOf(m)
+			if !ok {
+				continue
+			}
+			path := string(m.GetMetadataInfo().Path())
+			sources = append(sources, source{Component: c, path: path})
+			if o, ok := m.(*objects.CustomObject); ok {
+				for _, child := range objectChildren(c.Member, o) {
+					sources = append(sources, source{Component: child, path: path})
+				}
+			}
+		}
+	}
+	sort.Slice(sources, func(i, j int) bool {
+		if sources[i].path != sources[j].path {
+			return sources[i].path < sources[j].path
+		}
+		if sources[i].Type != sources[j].Type {
+			return sources[i].Type < sources[j].Type
+		}
+		return sources[i].Member < sources[j].Member
+	})
+	return sources
+}
+
+func objectChildren(object string, o *objects.CustomObject) []Component {
+	var children []Component
+	add := func(t string, name string) {
+		children = append(children, Component{Type: t, Member: object + "." + name})
+	}
+	for _, f := range o.BusinessProcesses {
+		add(businessprocess.NAME, f.FullName.Text)
+	}
+	for _, f := range o.CompactLayouts {
+		add(compactlayout.NAME, f.FullName.Text)
+	}
+	for _, f := range o.Fields {
+		add(objectfield.NAME, f.FullName)
+	}
+	for _, f := range o.FieldSets {
+		add(fieldset.NAME, f.FullName)
+	}
+	for _, f := range o.Indexes {
+		add(index.NAME, f.FullName)
+	}
+	for _, f := range o.ListViews {
+		add(listview.NAME, f.FullName.Text)
+	}
+	for _, f := range o.RecordTypes {
+		add(recordtype.NAME, f.FullName)
+	}
+	for _, f := range o.SharingReasons {
+		add(sharingreason.NAME, f.FullName)
+	}
+	for _, f := range o.ValidationRules {
+		add(validationrule.NAME, f.FullName)
+	}
+	for _, f := range o.WebLinks {
+		add(weblink.NAME, f.FullName)
+	}
+	return children
+}
+
+// parentOf returns the object containing a child component or the folder
+// containing a folder-based component
+func parentOf(c Component) (Component, bool) {
+	switch {
+	case childTypes[c.Type]:
+		if object, _, found := strings.Cut(c.Member, "."); found {
+			return Component{Type: objects.NAME, Member: object}, true
+		}
+	case folderDirectories[c.Type] != "":
+		if i := strings.LastIndex(c.Member, "/"); i >= 0 && c.Member[:i] != "unfiled$public" {
+			return Component{Type: c.Type, Member: c.Member[:i]}, true
+		}
+	}
+	return Component{}, false
+}
+
+// Standard objects exist without source files
+func isStandard(c Component) bool {
+	return c.Type == objects.NAME && !strings.Contains(c.Member, "__")
+}
+
+// knownTypes returns the metadata types that can be listed in package.xml
+func knownTypes() map[string]bool {
+	known := make(map[string]bool)
+	for t := range internal.TypeRegistry {
+		known[t] = true
+	}
+	for _, t := range bundleDirectories {
+		known[t] = true
+	}
+	for _, s := range suffixTypes {
+		known[s.Type] = true
+	}
+	for _, t := range childSuffixes {
+		known[t] = true
+	}
+	return known
+}
@@ -0,0 +1,575 @@
+// Package migrate converts retired automation to flows.
+package migrate
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	. "github.com/ForceCLI/force-md/general"
+	"github.com/ForceCLI/force-md/internal"
+	"github.com/ForceCLI/force-md/internal/formula"
+	"github.com/ForceCLI/force-md/metadata/flow"
+	"github.com/ForceCLI/force-md/metadata/objects"
+	"github.com/ForceCLI/force-md/metadata/objects/field"
+	"github.com/ForceCLI/force-md/metadata/workflow"
+	"github.com/ForceCLI/force-md/repo"
+)
+
+// DefaultApiVersion is the API version of generated flows unless configured
+// otherwise
+const DefaultApiVersion = "60.0"
+
+type Options struct {
+	ApiVersion string
+	// Field returns the definition of a field, if known.  Field types are
+	// used to choose the types of filter values and formulas.
+	Field func(object, name string) (field.Field, bool)
+}
+
+// Migration is a flow generated from a workflow rule
+type Migration struct {
+	Rule string
+	Flow *flow.Flow
+	// The API name of the flow
+	Name string
+	// The parts of the rule that couldn't be translated, or that should be
+	// reviewed
+	Issues []string
+}
+
+// WorkflowRule converts a workflow rule on object into a record-triggered
+// flow.  The rule's criteria become the flow's entry criteria, and its
+// immediate and time-dependent actions become elements on the flow's run
+// immediately and scheduled paths.  Field updates are made in a before-save
+// flow if the rule has no other actions.  The flow is created as a draft.
+func WorkflowRule(w *workflow.Workflow, object string, rule workflow.Rule, opts Options) Migration {
+	if opts.ApiVersion == "" {
+		opts.ApiVersion = DefaultApiVersion
+	}
+	m := &migrator{
+		Migration: Migration{Rule: object + "." + rule.FullName, Name: apiName(object + "_" + rule.FullName)},
+		workflow:  w,
+		object:    object,
+		opts:      opts,
+	}
+	m.migrate(rule)
+	return m.Migration
+}
+
+type migrator struct {
+	Migration
+	workflow *workflow.Workflow
+	object   string
+	opts     Options
+	names    map[string]bool
+}
+
+func (m *migrator) issue(format string, args ...any) {
+	m.Issues = append(m.Issues, fmt.Sprintf(format, args...))
+}
+
+type action struct {
+	Name string
+	Type string
+}
+
+func (m *migrator) migrate(rule workflow.Rule) {
+	var immediate []action
+	for _, a := range rule.Actions {
+		immediate = append(immediate, action{a.Name.Text, a.Type.Text})
+	}
+	beforeSave := len(rule.WorkflowTimeTriggers) == 0 && len(immediate) > 0
+	for _, a := range immediate {
+		beforeSave = beforeSave && a.Type == "FieldUpdate"
+	}
+
+	f := &flow.Flow{Xmlns: "http://soap.sforce.com/2006/04/metadata"}
+	m.Flow = f
+	set(&f.ApiVersion).Text = m.opts.ApiVersion
+	f.Description = &TextLiteral{Text: internal.FormulaEscaper.Replace(description(rule, m.Rule))}
+	set(&f.InterviewLabel).Text = m.Name + " {!$Flow.CurrentDateTime}"
+	f.Label.Text = rule.FullName
+	for _, pair := range [][2]string{{"BuilderType", "LightningFlowBuilder"}, {"CanvasMode", "AUTO_LAYOUT_CANVAS"}, {"OriginBuilderType", "LightningFlowBuilder"}} {
+		v := add(&f.ProcessMetadataValues)
+		v.Name.Text = pair[0]
+		v.Value = &flow.Value{StringValue: &TextLiteral{Text: pair[1]}}
+	}
+	f.ProcessType.Text = "AutoLaunchedFlow"
+	set(&f.Status).Text = "Draft"
+
+	s := &flow.Start{}
+	f.Start = s
+	s.LocationX.Text = "50"
+	s.LocationY.Text = "0"
+	object := m.object
+	s.Object = &object
+	triggerType := "RecordAfterSave"
+	if beforeSave {
+		triggerType = "RecordBeforeSave"
+	}
+	s.TriggerType = &triggerType
+
+	triggeringUpdate := false
+	switch rule.TriggerType.String() {
+	case "onCreateOnly":
+		set(&s.RecordTriggerType).Text = "Create"
+	case "onCreateOrTriggeringUpdate":
+		set(&s.RecordTriggerType).Text = "CreateAndUpdate"
+		triggeringUpdate = true
+	default:
+		set(&s.RecordTriggerType).Text = "CreateAndUpdate"
+	}
+	if m.criteria(rule) && triggeringUpdate {
+		set(&s.DoesRequireRecordChangedToMeetCriteria).Text = "true"
+	}
+
+	connect := func(target flow.ElementName) {
+		set(&s.Connector).TargetReference = target
+	}
+	m.actions(immediate, beforeSave, &connect)
+
+	// Each time trigger becomes a scheduled path
+	for _, t := range rule.WorkflowTimeTriggers {
+		offset := t.TimeLength.Text
+		if len(t.Actions) == 0 {
+			m.issue("time trigger %s %s has no actions", offset, t.WorkflowTimeTriggerUnit.Text)
+			continue
+		}
+		var timed []action
+		for _, a := range t.Actions {
+			timed = append(timed, action{a.Name.Text, a.Type.Text})
+		}
+		p := add(&s.ScheduledPaths)
+		after := "After"
+		if n, err := strconv.Atoi(offset); err == nil && n < 0 {
+			after = "Before"
+		}
+		source := "Rule Trigger Date"
+		_, recordField, ok := strings.Cut(t.OffsetFromField.String(), ".")
+		if ok && recordField != "" && !strings.EqualFold(recordField, "Rule Trigger Date") {
+			source = recordField
+			set(&p.TimeSource).Text = "RecordField"
+			p.RecordField = &recordField
+		} else {
+			set(&p.TimeSource).Text = "RecordTriggerEvent"
+		}
+		label := fmt.Sprintf("%s %s %s %s", strings.TrimPrefix(offset, "-"), t.WorkflowTimeTriggerUnit.Text, after, source)
+		set(&p.Name).Text = m.unique(apiName(label))
+		set(&p.Label).Text = label
+		set(&p.OffsetNumber).Text = offset
+		set(&p.OffsetUnit).Text = t.WorkflowTimeTriggerUnit.Text
+		connect := func(target flow.ElementName) {
+			p.Connector.TargetReference = target
+		}
+		m.actions(timed, false, &connect)
+	}
+}
+
+func description(rule workflow.Rule, name string) string {
+	d := "Migrated from workflow rule " + name + "."
+	if rule.Description != nil && rule.Description.String() != "" {
+		d = rule.Description.String() + "\n\n" + d
+	}
+	return d
+}
+
+// actions adds elements for workflow actions.  connect links the previous
+// element to the next one.
+func (m *migrator) actions(actions []action, beforeSave bool, connect *func(flow.ElementName)) {
+	var updates []workflow.FieldUpdate
+	var alerts []workflow.Alert
+	for _, a := range actions {
+		switch a.Type {
+		case "FieldUpdate":
+			u, ok := m.fieldUpdate(a.Name)
+			if !ok {
+				m.issue("field update %s was not found", a.Name)
+				continue
+			}
+			if u.TargetObject != nil && u.TargetObject.String() != "" && !strings.EqualFold(u.TargetObject.String(), m.object) {
+				m.issue("field update %s updates %s on the related %s record; cross-object updates are not translated", a.Name, u.Field.Text, u.TargetObject.String())
+				continue
+			}
+			updates = append(updates, u)
+		case "Alert":
+			alert, ok := m.alert(a.Name)
+			if !ok {
+				m.issue("email alert %s was not found", a.Name)
+				continue
+			}
+			alerts = append(alerts, alert)
+		default:
+			m.issue("%s action %s is not translated", a.Type, a.Name)
+		}
+	}
+	if len(updates) > 0 {
+		if beforeSave {
+			m.assignment(updates, connect)
+		} else {
+			m.recordUpdate(updates, connect)
+		}
+	}
+	for _, a := range alerts {
+		m.emailAlert(a, connect)
+	}
+}
+
+func (m *migrator) fieldUpdate(name string) (workflow.FieldUpdate, bool) {
+	for _, u := range m.workflow.FieldUpdates {
+		if strings.EqualFold(u.FullName.Text, name) {
+			return u, true
+		}
+	}
+	return workflow.FieldUpdate{}, false
+}
+
+func (m *migrator) alert(name string) (workflow.Alert, bool) {
+	for _, a := range m.workflow.Alerts {
+		if strings.EqualFold(a.FullName, name) {
+			return a, true
+		}
+	}
+	return workflow.Alert{}, false
+}
+
+// assignment sets fields of the triggering record in a before-save flow
+func (m *migrator) assignment(updates []workflow.FieldUpdate, connect *func(flow.ElementName)) {
+	f := m.Flow
+	a := add(&f.Assignments)
+	a.Name = flow.ElementName(m.unique("Set_Fields"))
+	a.Label.Text = "Set Fields"
+	a.LocationX.Text = "0"
+	a.LocationY.Text = "0"
+	for _, u := range updates {
+		value, ok := m.updateValue(u)
+		if !ok {
+			continue
+		}
+		item := add(&a.AssignmentItems)
+		item.AssignToReference = "$Record." + u.Field.Text
+		item.Operator = "Assign"
+		item.Value = value
+	}
+	// Appending to f.Assignments may move a, so link by index
+	i := len(f.Assignments) - 1
+	(*connect)(a.Name)
+	*connect = func(target flow.ElementName) {
+		set(&f.Assignments[i].Connector).TargetReference = target
+	}
+}
+
+// recordUpdate updates the triggering record in an after-save flow or
+// scheduled path
+func (m *migrator) recordUpdate(updates []workflow.FieldUpdate, connect *func(flow.ElementName)) {
+	f := m.Flow
+	u := add(&f.RecordUpdates)
+	u.Name.Text = m.unique("Update_Record")
+	u.Label.Text = "Update Record"
+	u.LocationX.Text = "0"
+	u.LocationY.Text = "0"
+	set(&u.InputReference).Text = "$Record"
+	for _, fu := range updates {
+		value, ok := m.updateValue(fu)
+		if !ok {
+			continue
+		}
+		a := add(&u.InputAssignments)
+		a.Field.Text = fu.Field.Text
+		a.Value = value
+	}
+	i := len(f.RecordUpdates) - 1
+	(*connect)(flow.ElementName(u.Name.Text))
+	*connect = func(target flow.ElementName) {
+		set(&f.RecordUpdates[i].Connector).TargetReference = target
+	}
+}
+
+// updateValue returns the value a field update sets a field to.  A nil value
+// clears the field.
+func (m *migrator) updateValue(u workflow.FieldUpdate) (*flow.Value, bool) {
+	name := u.FullName.Text
+	if u.NotifyAssignee.ToBool() {
+		m.issue("field update %s notifies the assignee; flows don't send owner change notifications", name)
+	}
+	if u.ReevaluateOnChange.ToBool() {
+		m.issue("field update %s re-evaluates workflow rules; flows don't re-evaluate other automation", name)
+	}
+	switch u.Operation.String() {
+	case "Literal":
+		return m.literal(u.Field.Text, u.LiteralValue.String()), true
+	case "Null":
+		return nil, true
+	case "Formula":
+		expression, err := m.formula(u.Formula.String())
+		if err != nil {
+			m.issue("field update %s has a formula that could not be translated: %s", name, err.Error())
+			return nil, false
+		}
+		f := add(&m.Flow.Formulas)
+		f.Name.Text = m.unique(apiName(name + "_Value"))
+		f.DataType.Text = "String"
+		if def, ok := m.field(u.Field.Text); ok {
+			f.DataType.Text = formulaType(def.Type.String())
+			if def.Scale != nil && (f.DataType.Text == "Number" || f.DataType.Text == "Currency") {
+				set(&f.Scale).Text = def.Scale.Text
+			}
+		} else {
+			m.issue("the type of %s is unknown; check the data type of formula %s", u.Field.Text, f.Name.Text)
+		}
+		f.Expression = &TextLiteral{Text: internal.FormulaEscaper.Replace(expression)}
+		return &flow.Value{ElementReference: &struct {
+			Text string `xml:",chardata"`
+		}{Text: f.Name.Text}}, true
+	default:
+		m.issue("field update %s uses the %s operation, which is not translated", name, u.Operation.String())
+		return nil, false
+	}
+}
+
+func (m *migrator) emailAlert(alert workflow.Alert, connect *func(flow.ElementName)) {
+	f := m.Flow
+	a := add(&f.ActionCalls)
+	a.Name = flow.ElementName(m.unique(apiName("Send_" + alert.FullName)))
+	a.Label.Text = "Send " + alert.FullName
+	a.LocationX.Text = "0"
+	a.LocationY.Text = "0"
+	a.ActionName.Text = m.object + "." + alert.FullName
+	a.ActionType = "emailAlert"
+	set(&a.FlowTransactionModel).Text = "CurrentTransaction"
+	p := add(&a.InputParameters)
+	p.Name.Text = "SObjectRowId"
+	p.Value.ElementReference = &struct {
+		Text string `xml:",chardata"`
+	}{Text: "$Record.Id"}
+	set(&a.NameSegment).Text = a.ActionName.Text
+	set(&a.VersionSegment).Text = "1"
+	i := len(f.ActionCalls) - 1
+	(*connect)(a.Name)
+	*connect = func(target flow.ElementName) {
+		set(&f.ActionCalls[i].Connector).TargetReference = target
+	}
+}
+
+var operators = map[string]string{
+	"equals":         "EqualTo",
+	"notEqual":       "NotEqualTo",
+	"lessThan":       "LessThan",
+	"greaterThan":    "GreaterThan",
+	"lessOrEqual":    "LessThanOrEqualTo",
+	"greaterOrEqual": "GreaterThanOrEqualTo",
+	"contains":       "Contains",
+	"startsWith":     "StartsWith",
+}
+
+// criteria sets the flow's entry criteria from the rule's criteria items or
+// formula, returning false if there are none
+func (m *migrator) criteria(rule workflow.Rule) bool {
+	s := m.Flow.Start
+	if rule.Formula != nil && strings.TrimSpace(rule.Formula.String()) != "" {
+		expression, err := m.formula(rule.Formula.String())
+		if err != nil {
+			m.issue("the rule formula could not be translated: %s", err.Error())
+			return false
+		}
+		if strings.EqualFold(strings.TrimSpace(expression), "true") {
+			return false
+		}
+		s.FilterFormula = &TextLiteral{Text: internal.FormulaEscaper.Replace(expression)}
+		return true
+	}
+	if len(rule.CriteriaItems) == 0 {
+		return false
+	}
+	type filter struct {
+		field, operator string
+		value           *flow.Value
+	}
+	var filters []filter
+	// The filter logic for each criteria item
+	var logic []string
+	for i, c := range rule.CriteriaItems {
+		object, name, _ := strings.Cut(c.Field.Text, ".")
+		if !strings.EqualFold(object, m.object) || strings.Contains(name, ".") {
+			m.issue("criteria item %d on %s can't be used as a filter; entry criteria were not translated", i+1, c.Field.Text)
+			return false
+		}
+		operator, ok := operators[c.Operation.Text]
+		if !ok {
+			m.issue("criteria item %d uses the %s operator; entry criteria were not translated", i+1, c.Operation.Text)
+			return false
+		}
+		if def, ok := m.field(name); ok && (def.Type.String() == "Date" || def.Type.String() == "DateTime") {
+			m.issue("criteria item %d compares date %s; entry criteria were not translated", i+1, name)
+			return false
+		}
+		raw := c.Value.String()
+		if raw == "" {
+			if operator != "EqualTo" && operator != "NotEqualTo" {
+				m.issue("criteria item %d compares %s to a blank value; entry criteria were not translated", i+1, name)
+				return false
+			}
+			filters = append(filters, filter{name, "IsNull", &flow.Value{BooleanValue: &BooleanText{Text: strconv.FormatBool(operator == "EqualTo")}}})
+			logic = append(logic, strconv.Itoa(len(filters)))
+			continue
+		}
+		// Comma-separated values match any of the values, or none of them
+		// for notEqual
+		var group []string
+		for _, v := range strings.Split(raw, ",") {
+			filters = append(filters, filter{name, operator, m.literal(name, strings.TrimSpace(v))})
+			group = append(group, strconv.Itoa(len(filters)))
+		}
+		join := " OR "
+		if operator == "NotEqualTo" {
+			join = " AND "
+		}
+		if len(group) == 1 {
+			logic = append(logic, group[0])
+		} else {
+			logic = append(logic, "("+strings.Join(group, join)+")")
+		}
+	}
+	filterLogic := "and"
+	expanded := len(filters) != len(rule.CriteriaItems)
+	if rule.BooleanFilter != nil && strings.TrimSpace(rule.BooleanFilter.String()) != "" {
+		filterLogic = itemNumber.ReplaceAllStringFunc(rule.BooleanFilter.String(), func(n string) string {
+			i, _ := strconv.Atoi(n)
+			if i < 1 || i > len(logic) {
+				return n
+			}
+			return logic[i-1]
+		})
+	} else if expanded {
+		filterLogic = strings.Join(logic, " AND ")
+	}
+	s.FilterLogic = &filterLogic
+	for _, f := range filters {
+		item := add(&s.Filters)
+		item.Field.Text = f.field
+		item.Operator.Text = f.operator
+		item.Value = f.value
+	}
+	return true
+}
+
+var itemNumber = regexp.MustCompile(`\d+`)
+
+// literal returns a value of the appropriate type for a field
+func (m *migrator) literal(fieldName, value string) *flow.Value {
+	fieldType := ""
+	if def, ok := m.field(fieldName); ok {
+		fieldType = def.Type.String()
+	}
+	switch {
+	case fieldType == "Checkbox", fieldType == "" && (strings.EqualFold(value, "true") || strings.EqualFold(value, "false")):
+		return &flow.Value{BooleanValue: &BooleanText{Text: strconv.FormatBool(strings.EqualFold(value, "true") || value == "1")}}
+	case fieldType == "Number", fieldType == "Currency", fieldType == "Percent":
+		if _, err := strconv.ParseFloat(value, 64); err == nil {
+			return &flow.Value{NumberValue: &struct {
+				Text string `xml:",chardata"`
+			}{Text: value}}
+		}
+	}
+	return &flow.Value{StringValue: &TextLiteral{Text: internal.FormulaEscaper.Replace(value)}}
+}
+
+func (m *migrator) field(name string) (field.Field, bool) {
+	if m.opts.Field == nil {
+		return field.Field{}, false
+	}
+	return m.opts.Field(m.object, name)
+}
+
+// formula converts a workflow formula to a flow formula by replacing field
+// references with merge fields, e.g. Name with {!$Record.Name} and $User.Id
+// with {!$User.Id}
+func (m *migrator) formula(f string) (string, error) {
+	converted, _, err := formula.ReplaceFieldReferences(f, func(ref string) (string, bool) {
+		if rest, ok := strings.CutPrefix(ref, "$RecordType."); ok {
+			return "{!$Record.RecordType." + rest + "}", true
+		}
+		if strings.HasPrefix(ref, "$") {
+			return "{!" + ref + "}", true
+		}
+		return "{!$Record." + ref + "}", true
+	})
+	return converted, err
+}
+
+// formulaType returns the flow formula data type for a field type
+func formulaType(fieldType string) string {
+	switch fieldType {
+	case "Checkbox":
+		return "Boolean"
+	case "Currency", "Date", "DateTime":
+		return fieldType
+	case "Number", "Percent":
+		return "Number"
+	default:
+		return "String"
+	}
+}
+
+// unique returns name, suffixed if needed to make it unique within the flow
+func (m *migrator) unique(name string) string {
+	if m.names == nil {
+		m.names = make(map[string]bool)
+	}
+	candidate := name
+	for i := 2; m.names[strings.ToLower(candidate)]; i++ {
+		candidate = fmt.Sprintf("%s_%d", name, i)
+	}
+	m.names[strings.ToLower(candidate)] = true
+	return candidate
+}
+
+var nonWord = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+// apiName converts a label to a valid API name: alphanumeric characters and
+// single underscores, starting with a letter, and at most 80 characters
+func apiName(label string) string {
+	name := strings.Trim(nonWord.ReplaceAllString(label, "_"), "_")
+	if name == "" || name[0] < 'A' || (name[0] > 'Z' && name[0] < 'a') || name[0] > 'z' {
+		name = "X" + name
+	}
+	if len(name) > 80 {
+		name = strings.TrimRight(name[:80], "_")
+	}
+	return name
+}
+
+// add appends a zero value to a slice and returns a pointer to it.  The
+// pointer is only valid until the slice is appended to again.
+func add[T any](s *[]T) *T {
+	var zero T
+	*s = append(*s, zero)
+	return &(*s)[len(*s)-1]
+}
+
+// set allocates the value of a pointer field and returns it
+func set[T any](p **T) *T {
+	*p = new(T)
+	return *p
+}
+
+// RepoFields returns a field lookup that uses the objects and fields loaded
+// in a repository
+func RepoFields(r *repo.Repo) func(object, name string) (field.Field, bool) {
+	fields := make(map[string]field.Field)
+	for name, m := range r.Items(objects.NAME) {
+		for _, f := range m.(*objects.CustomObject).Fields {
+			fields[strings.ToLower(string(name)+"."+f.FullName)] = f
+		}
+	}
+	for name, m := range r.Items(field.NAME) {
+		f := m.(*field.CustomField).Field
+		object, _, _ := strings.Cut(string(name), ".")
+		fields[strings.ToLower(object+"."+f.FullName)] = f
+	}
+	return func(object, name string) (field.Field, bool) {
+		f, ok := fields[strings.ToLower(object+"."+name)]
+		return f, ok
+	}
+}
@@ -0,0 +1,322 @@
+package migrate_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	. "github.com/ForceCLI/force-md/general"
+	"github.com/ForceCLI/force-md/metadata/flow"
+	"github.com/ForceCLI/force-md/metadata/objects/field"
+	"github.com/ForceCLI/force-md/metadata/workflow"
+	. "github.com/ForceCLI/force-md/repo/migrate"
+)
+
+const testWorkflow = `<?xml version="1.0" encoding="UTF-8"?>
+<Workflow xmlns="http://soap.sforce.com/2006/04/metadata">
+    <alerts>
+        <fullName>Renewal_Reminder</fullName>
+        <description>Renewal Reminder</description>
+        <protected>false</protected>
+        <recipients>
+            <type>owner</type>
+        </recipients>
+        <senderType>CurrentUser</senderType>
+        <template>Sales/Renewal_Reminder</template>
+    </alerts>
+    <fieldUpdates>
+        <fullName>Set_Rating</fullName>
+        <field>Rating</field>
+        <literalValue>Hot</literalValue>
+        <name>Set Rating</name>
+        <notifyAssignee>false</notifyAssignee>
+        <operation>Literal</operation>
+        <protected>false</protected>
+    </fieldUpdates>
+    <fieldUpdates>
+        <fullName>Set_Score</fullName>
+        <field>Score__c</field>
+        <formula>AnnualRevenue / 1000</formula>
+        <name>Set Score</name>
+        <notifyAssignee>false</notifyAssignee>
+        <operation>Formula</operation>
+        <protected>false</protected>
+    </fieldUpdates>
+    <fieldUpdates>
+        <fullName>Assign_Owner</fullName>
+        <field>OwnerId</field>
+        <lookupValue>Sales_Queue</lookupValue>
+        <lookupValueType>Queue</lookupValueType>
+        <name>Assign Owner</name>
+        <notifyAssignee>true</notifyAssignee>
+        <operation>LookupValue</operation>
+        <protected>false</protected>
+    </fieldUpdates>
+    <fieldUpdates>
+        <fullName>Update_Parent</fullName>
+        <field>Description</field>
+        <name>Update Parent</name>
+        <notifyAssignee>false</notifyAssignee>
+        <operation>Null</operation>
+        <protected>false</protected>
+        <reevaluateOnChange>true</reevaluateOnChange>
+        <targetObject>ParentId</targetObject>
+    </fieldUpdates>
+    <rules>
+        <fullName>Hot Customers</fullName>
+        <actions>
+            <name>Set_Rating</name>
+            <type>FieldUpdate</type>
+        </actions>
+        <active>true</active>
+        <booleanFilter>1 OR 2</booleanFilter>
+        <criteriaItems>
+            <field>Account.Type</field>
+            <operation>equals</operation>
+            <value>Customer, Partner</value>
+        </criteriaItems>
+        <criteriaItems>
+            <field>Account.Industry</field>
+            <operation>notEqual</operation>
+            <value>Retail,Energy</value>
+        </criteriaItems>
+        <triggerType>onCreateOrTriggeringUpdate</triggerType>
+    </rules>
+    <rules>
+        <fullName>Missing Phone</fullName>
+        <actions>
+            <name>Set_Rating</name>
+            <type>FieldUpdate</type>
+        </actions>
+        <actions>
+            <name>Renewal_Reminder</name>
+            <type>Alert</type>
+        </actions>
+        <active>true</active>
+        <criteriaItems>
+            <field>Account.Phone</field>
+            <operation>equals</operation>
+        </criteriaItems>
+        <criteriaItems>
+            <field>Account.Fax</field>
+            <operation>notEqual</operation>
+        </criteriaItems>
+        <criteriaItems>
+            <field>Account.NumberOfEmployees</field>
+            <operation>greaterThan</operation>
+            <value>100</value>
+        </criteriaItems>
+        <triggerType>onAllChanges</triggerType>
+    </rules>
+    <rules>
+        <fullName>Big Customers</fullName>
+        <actions>
+            <name>Set_Score</name>
+            <type>FieldUpdate</type>
+        </actions>
+        <active>true</active>
+        <formula>ISPICKVAL(Type, "Customer") &amp;&amp; $User.Id &lt;&gt; OwnerId</formula>
+        <triggerType>onCreateOnly</triggerType>
+    </rules>
+    <rules>
+        <fullName>Renewals</fullName>
+        <active>true</active>
+        <formula>true</formula>
+        <triggerType>onCreateOrTriggeringUpdate</triggerType>
+        <workflowTimeTriggers>
+            <actions>
+                <name>Renewal_Reminder</name>
+                <type>Alert</type>
+            </actions>
+            <offsetFromField>Account.Renewal_Date__c</offsetFromField>
+            <timeLength>-30</timeLength>
+            <workflowTimeTriggerUnit>Days</workflowTimeTriggerUnit>
+        </workflowTimeTriggers>
+        <workflowTimeTriggers>
+            <actions>
+                <name>Set_Rating</name>
+                <type>FieldUpdate</type>
+            </actions>
+            <timeLength>1</timeLength>
+            <workflowTimeTriggerUnit>Hours</workflowTimeTriggerUnit>
+        </workflowTimeTriggers>
+        <workflowTimeTriggers>
+            <timeLength>2</timeLength>
+            <workflowTimeTriggerUnit>Hours</workflowTimeTriggerUnit>
+        </workflowTimeTriggers>
+    </rules>
+    <rules>
+        <fullName>Untranslated</fullName>
+        <actions>
+            <name>Assign_Owner</name>
+            <type>FieldUpdate</type>
+        </actions>
+        <actions>
+            <name>Update_Parent</name>
+            <type>FieldUpdate</type>
+        </actions>
+        <actions>
+            <name>Missing_Update</name>
+            <type>FieldUpdate</type>
+        </actions>
+        <actions>
+            <name>Follow_Up</name>
+            <type>Task</type>
+        </actions>
+        <active>true</active>
+        <criteriaItems>
+            <field>Account.Renewal_Date__c</field>
+            <operation>equals</operation>
+            <value>TODAY</value>
+        </criteriaItems>
+        <triggerType>onAllChanges</triggerType>
+    </rules>
+</Workflow>
+`
+
+var testFields = map[string]string{
+	"AnnualRevenue":     "Currency",
+	"NumberOfEmployees": "Number",
+	"Rating":            "Picklist",
+	"Renewal_Date__c":   "Date",
+	"Score__c":          "Number",
+	"Type":              "Picklist",
+}
+
+func testField(object, name string) (field.Field, bool) {
+	t, ok := testFields[name]
+	if !ok {
+		return field.Field{}, false
+	}
+	return field.Field{FullName: name, Type: &TextLiteral{Text: t}}, true
+}
+
+func filters(s *flow.Start) []string {
+	var result []string
+	for _, f := range s.Filters {
+		result = append(result, strings.Join([]string{f.Field.Text, f.Operator.Text, f.Value.String()}, " "))
+	}
+	return result
+}
+
+func scheduledPaths(s *flow.Start) []string {
+	var result []string
+	for _, p := range s.ScheduledPaths {
+		source := p.TimeSource.Text
+		if p.RecordField != nil {
+			source += " " + *p.RecordField
+		}
+		result = append(result, strings.Join([]string{p.Name.Text, p.Label.Text, p.OffsetNumber.Text, p.OffsetUnit.Text, source, string(p.Connector.TargetReference)}, "|"))
+	}
+	return result
+}
+
+func TestWorkflowRule(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "Account.workflow-meta.xml")
+	if err := os.WriteFile(file, []byte(testWorkflow), 0644); err != nil {
+		t.Fatal(err)
+	}
+	w, err := workflow.Open(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		rule           string
+		triggerType    string
+		filters        []string
+		filterLogic    string
+		filterFormula  string
+		scheduledPaths []string
+		issues         []string
+	}{
+		{
+			rule:        "Hot Customers",
+			triggerType: "RecordBeforeSave",
+			filters: []string{
+				"Type EqualTo Customer",
+				"Type EqualTo Partner",
+				"Industry NotEqualTo Retail",
+				"Industry NotEqualTo Energy",
+			},
+			filterLogic: "(1 OR 2) OR (3 AND 4)",
+		},
+		{
+			rule:        "Missing Phone",
+			triggerType: "RecordAfterSave",
+			filters: []string{
+				"Phone IsNull true",
+				"Fax IsNull false",
+				"NumberOfEmployees GreaterThan 100",
+			},
+			filterLogic: "and",
+		},
+		{
+			rule:          "Big Customers",
+			triggerType:   "RecordBeforeSave",
+			filterFormula: `ISPICKVAL({!$Record.Type}, "Customer") && {!$User.Id} <> {!$Record.OwnerId}`,
+		},
+		{
+			rule:        "Renewals",
+			triggerType: "RecordAfterSave",
+			scheduledPaths: []string{
+				"X30_Days_Before_Renewal_Date_c|30 Days Before Renewal_Date__c|-30|Days|RecordField Renewal_Date__c|Send_Renewal_Reminder",
+				"X1_Hours_After_Rule_Trigger_Date|1 Hours After Rule Trigger Date|1|Hours|RecordTriggerEvent|Update_Record",
+			},
+			issues: []string{
+				"time trigger 2 Hours has no actions",
+			},
+		},
+		{
+			rule:        "Untranslated",
+			triggerType: "RecordAfterSave",
+			issues: []string{
+				"criteria item 1 compares date Renewal_Date__c; entry criteria were not translated",
+				"field update Update_Parent updates Description on the related ParentId record; cross-object updates are not translated",
+				"field update Missing_Update was not found",
+				"Task action Follow_Up is not translated",
+				"field update Assign_Owner notifies the assignee; flows don't send owner change notifications",
+				"field update Assign_Owner uses the LookupValue operation, which is not translated",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		var rule workflow.Rule
+		for _, r := range w.Rules {
+			if r.FullName == test.rule {
+				rule = r
+			}
+		}
+		m := WorkflowRule(w, "Account", rule, Options{Field: testField})
+		s := m.Flow.Start
+		if *s.TriggerType != test.triggerType {
+			t.Errorf("%s: expected trigger type %s, got %s", test.rule, test.triggerType, *s.TriggerType)
+		}
+		if actual := filters(s); !reflect.DeepEqual(actual, test.filters) {
+			t.Errorf("%s: expected filters %q, got %q", test.rule, test.filters, actual)
+		}
+		filterLogic := ""
+		if s.FilterLogic != nil {
+			filterLogic = *s.FilterLogic
+		}
+		if filterLogic != test.filterLogic {
+			t.Errorf("%s: expected filter logic %q, got %q", test.rule, test.filterLogic, filterLogic)
+		}
+		filterFormula := ""
+		if s.FilterFormula != nil {
+			filterFormula = s.FilterFormula.String()
+		}
+		if filterFormula != test.filterFormula {
+			t.Errorf("%s: expected filter formula %q, got %q", test.rule, test.filterFormula, filterFormula)
+		}
+		if actual := scheduledPaths(s); !reflect.DeepEqual(actual, test.scheduledPaths) {
+			t.Errorf("%s: expected scheduled paths %q, got %q", test.rule, test.scheduledPaths, actual)
+		}
+		if !reflect.DeepEqual(m.Issues, test.issues) {
+			t.Errorf("%s: expected issues %q, got %q", test.rule, test.issues, m.Issues)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package repo
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+const sfdxProjectFile = "sfdx-project.json"
+
+type sfdxProject struct {
+	PackageDirectories []struct {
+		Path string `json:"path"`
+	} `json:"packageDirectories"`
+}
+
+// Directories that never contain deployable metadata
+var ignoredDirectories = map[string]bool{
+	".git":         true,
+	".sf":          true,
+	".sfdx":        true,
+	"node_modules": true,
+}
+
+// SourceDirectories returns the directories containing metadata in the
+// project at root.  For an sfdx project, these are the package directories
+// listed in sfdx-project.json.  For a metadata API project, this is the src
+// directory if it exists, or root itself.
+func SourceDirectories(root string) ([]string, error) {
+	projectFile := filepath.Join(root, sfdxProjectFile)
+	if contents, err := os.ReadFile(projectFile); err == nil {
+		var project sfdxProject
+		if err := json.Unmarshal(contents, &project); err != nil {
+			return nil, errors.Wrap(err, "parsing "+projectFile)
+		}
+		var dirs []string
+		for _, p := range project.PackageDirectories {
+			dirs = append(dirs, filepath.Join(root, filepath.FromSlash(p.Path)))
+		}
+		if len(dirs) == 0 {
+			return nil, errors.New("no packageDirectories found in " + projectFile)
+		}
+		return dirs, nil
+	}
+	src := filepath.Join(root, "src")
+	if info, err := os.Stat(src); err == nil && info.IsDir() {
+		return []string{src}, nil
+	}
+	return []string{root}, nil
+}
+
+// MetadataFiles returns the paths of all metadata files in the project at
+// root.
+func MetadataFiles(root string) ([]string, error) {
+	dirs, err := SourceDirectories(root)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, dir := range dirs {
+		err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				if ignoredDirectories[d.Name()] {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !d.Type().IsRegular() || !hasXmlDeclaration(path) {
+				return nil
+			}
+			if IsMetadataFile(path) {
+				files = append(files, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "reading "+dir)
+		}
+	}
+	return files, nil
+}
+
+// LoadProject opens all of the metadata in the sfdx or metadata API project
+// at root.
+func (o *Repo) LoadProject(root string) error {
+	files, err := MetadataFiles(root)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if _, err := o.Open(file); err != nil {
+			log.Warnf("failed to open %s: %s", file, err.Error())
+		}
+	}
+	return nil
+}
+
+// Files returns the paths of the open metadata of the given types, or of all
+// open metadata if no types are given.
+func (o *Repo) Files(types ...string) []string {
+	if len(types) == 0 {
+		types = o.Types()
+	}
+	var files []string
+	for _, t := range types {
+		for _, m := range o.Items(t) {
+			files = append(files, string(m.GetMetadataInfo().Path()))
+		}
+	}
+	sort.Strings(files)
+	return files
+}
+
+// Check the start of the file so large non-xml files such as static resources
+// don't need to be read
+func hasXmlDeclaration(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	start := make([]byte, 64)
+	n, err := io.ReadFull(f, start)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return false
+	}
+	start = bytes.TrimPrefix(start[:n], []byte("\xef\xbb\xbf"))
+	return bytes.HasPrefix(bytes.TrimSpace(start), []byte("<?xml"))
+}
@@ -0,0 +1,453 @@
+// Package refactor updates metadata throughout a repository.
+package refactor
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	. "github.com/ForceCLI/force-md/general"
+	"github.com/ForceCLI/force-md/internal"
+	"github.com/ForceCLI/force-md/internal/formula"
+	"github.com/ForceCLI/force-md/metadata"
+	flexipage "github.com/ForceCLI/force-md/metadata/flexipages"
+	"github.com/ForceCLI/force-md/metadata/flow"
+	layout "github.com/ForceCLI/force-md/metadata/layouts"
+	"github.com/ForceCLI/force-md/metadata/objects"
+	"github.com/ForceCLI/force-md/metadata/objects/compactlayout"
+	"github.com/ForceCLI/force-md/metadata/objects/field"
+	"github.com/ForceCLI/force-md/metadata/objects/fieldset"
+	"github.com/ForceCLI/force-md/metadata/objects/listview"
+	"github.com/ForceCLI/force-md/metadata/objects/recordtype"
+	"github.com/ForceCLI/force-md/metadata/objects/validationrule"
+	"github.com/ForceCLI/force-md/metadata/permissionset"
+	"github.com/ForceCLI/force-md/metadata/profile"
+	"github.com/ForceCLI/force-md/metadata/reportType"
+	"github.com/ForceCLI/force-md/metadata/workflow"
+	"github.com/ForceCLI/force-md/repo"
+	"github.com/ForceCLI/force-md/repo/deps"
+)
+
+// Change is a metadata file updated by a refactoring
+type Change struct {
+	Metadata metadata.RegisterableMetadata
+	// The path the metadata should be written to.  If it differs from the
+	// metadata's path, the file has been renamed.
+	Path       string
+	References int
+}
+
+type fieldRenamer struct {
+	object   string
+	oldName  string
+	newName  string
+	resolver *deps.Resolver
+	found    bool
+}
+
+// RenameField renames a custom field, e.g. Account.Old__c, to newName, and
+// updates the references to it in the metadata open in r.  newName can
+// include the object name, e.g. Account.New__c.  The metadata is updated in
+// place but not written.
+func RenameField(r *repo.Repo, fieldName, newName string) ([]Change, error) {
+	object, oldName, ok := strings.Cut(fieldName, ".")
+	if !ok || object == "" || oldName == "" {
+		return nil, errors.New("field must be of the form Object.Field")
+	}
+	if newObject, name, ok := strings.Cut(newName, "."); ok {
+		if !strings.EqualFold(newObject, object) {
+			return nil, errors.New("field cannot be moved to another object")
+		}
+		newName = name
+	}
+	if newName == "" {
+		return nil, errors.New("new field name required")
+	}
+	if strings.HasSuffix(strings.ToLower(oldName), "__c") != strings.HasSuffix(strings.ToLower(newName), "__c") {
+		return nil, errors.New("custom fields must end in __c")
+	}
+	fr := &fieldRenamer{
+		object:   object,
+		oldName:  oldName,
+		newName:  newName,
+		resolver: deps.NewResolver(r),
+	}
+	var changes []Change
+	for _, t := range r.Types() {
+		for _, m := range r.Items(t) {
+			count := fr.rename(m)
+			if count == 0 {
+				continue
+			}
+			path := string(m.GetMetadataInfo().Path())
+			// Field files are named for the field in sfdx source format
+			if f, ok := m.(*field.CustomField); ok && f.FullName == newName {
+				path = filepath.Join(filepath.Dir(path), newName+".field-meta.xml")
+			}
+			changes = append(changes, Change{Metadata: m, Path: path, References: count})
+		}
+	}
+	if !fr.found {
+		return nil, errors.New("field " + fieldName + " not found")
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Metadata.GetMetadataInfo().Path() < changes[j].Metadata.GetMetadataInfo().Path()
+	})
+	return changes, nil
+}
+
+func objectName(name string) string {
+	objectName, _, _ := strings.Cut(name, ".")
+	return objectName
+}
+
+func (fr *fieldRenamer) isField(object, name string) bool {
+	return strings.EqualFold(object, fr.object) && strings.EqualFold(name, fr.oldName)
+}
+
+// Rename a field name, e.g. Old__c, on object
+func (fr *fieldRenamer) renameField(object string, name *string) int {
+	if !fr.isField(object, *name) {
+		return 0
+	}
+	*name = fr.newName
+	return 1
+}
+
+// Rename a qualified field name, e.g. Account.Old__c
+func (fr *fieldRenamer) renameQualified(name *string) int {
+	object, fieldName, ok := strings.Cut(*name, ".")
+	if !ok || !fr.isField(object, fieldName) {
+		return 0
+	}
+	*name = object + "." + fr.newName
+	return 1
+}
+
+// Rename a field name that may or may not be qualified by the object name
+func (fr *fieldRenamer) renameColumn(object string, name *string) int {
+	if strings.Contains(*name, ".") {
+		return fr.renameQualified(name)
+	}
+	return fr.renameField(object, name)
+}
+
+// Rename the references to the field in a formula evaluated in the context of
+// object, following relationships to other objects
+func (fr *fieldRenamer) renameFormula(object string, f *TextLiteral) int {
+	if f == nil {
+		return 0
+	}
+	updated, count, err := formula.ReplaceFieldReferences(f.String(), func(ref string) (string, bool) {
+		if strings.HasPrefix(ref, "$") {
+			return "", false
+		}
+		fields := fr.resolver.Fields(object, ref)
+		if len(fields) != len(strings.Split(ref, ".")) {
+			return "", false
+		}
+		last := fields[len(fields)-1]
+		if !fr.isField(objectName(last), strings.TrimPrefix(last, objectName(last)+".")) {
+			return "", false
+		}
+		if i := strings.LastIndex(ref, "."); i >= 0 {
+			return ref[:i+1] + fr.newName, true
+		}
+		return fr.newName, true
+	})
+	if err != nil {
+		log.Warnf("could not update formula on %s: %s", object, err.Error())
+		return 0
+	}
+	if count > 0 {
+		f.Text = internal.FormulaEscaper.Replace(updated)
+	}
+	return count
+}
+
+func (fr *fieldRenamer) rename(m metadata.RegisterableMetadata) int {
+	name := string(m.GetMetadataInfo().Name())
+	switch m := m.(type) {
+	case *objects.CustomObject:
+		return fr.renameObject(name, m)
+	case *field.CustomField:
+		count := fr.renameFieldDefinition(objectName(name), &m.Field)
+		return count + fr.renameFormula(objectName(name), m.Formula)
+	case *listview.ListViewMetadata:
+		return fr.renameListView(objectName(name), &m.ListView)
+	case *compactlayout.CompactLayoutMetadata:
+		return fr.renameCompactLayout(objectName(name), &m.CompactLayout)
+	case *fieldset.FieldSetMetadata:
+		return fr.renameFieldSet(objectName(name), &m.FieldSet)
+	case *recordtype.RecordTypeMetadata:
+		return fr.renameRecordType(objectName(name), &m.RecordType)
+	case *validationrule.ValidationRule:
+		return fr.renameValidationRule(objectName(name), &m.Rule)
+	case *profile.Profile:
+		return fr.renameFieldPermissions(m.FieldPermissions)
+	case *permissionset.PermissionSet:
+		return fr.renameFieldPermissions(m.FieldPermissions)
+	case *layout.Layout:
+		return fr.renameLayout(m)
+	case *flexipage.FlexiPage:
+		return fr.renameFlexiPage(m)
+	case *flow.Flow:
+		return fr.renameFlow(m)
+	case *workflow.Workflow:
+		return fr.renameWorkflow(name, m)
+	case *reportType.ReportType:
+		return fr.renameReportType(m)
+	}
+	return 0
+}
+
+func (fr *fieldRenamer) renameFieldDefinition(object string, f *field.Field) int {
+	count := fr.renameField(object, &f.FullName)
+	if count > 0 {
+		fr.found = true
+	}
+	return count
+}
+
+func (fr *fieldRenamer) renameObject(object string, o *objects.CustomObject) int {
+	count := 0
+	for i := range o.Fields {
+		count += fr.renameFieldDefinition(object, &o.Fields[i])
+		count += fr.renameFormula(object, o.Fields[i].Formula)
+	}
+	for i := range o.CompactLayouts {
+		count += fr.renameCompactLayout(object, &o.CompactLayouts[i])
+	}
+	for i := range o.FieldSets {
+		count += fr.renameFieldSet(object, &o.FieldSets[i])
+	}
+	for i := range o.ListViews {
+		count += fr.renameListView(object, &o.ListViews[i])
+	}
+	for i := range o.RecordTypes {
+		count += fr.renameRecordType(object, &o.RecordTypes[i])
+	}
+	for i := range o.ValidationRules {
+		count += fr.renameValidationRule(object, &o.ValidationRules[i])
+	}
+	if s := o.SearchLayouts; s != nil {
+		for _, fields := range [][]struct {
+			Text string `xml:",chardata"`
+		}{
+			s.CustomTabListAdditionalFields,
+			s.LookupDialogsAdditionalFields,
+			s.LookupFilterFields,
+			s.LookupPhoneDialogsAdditionalFields,
+			s.SearchFilterFields,
+			s.SearchResultsAdditionalFields,
+		} {
+			for i := range fields {
+				count += fr.renameColumn(object, &fields[i].Text)
+			}
+		}
+	}
+	return count
+}
+
+func (fr *fieldRenamer) renameListView(object string, l *listview.ListView) int {
+	count := 0
+	for i := range l.Columns {
+		count += fr.renameColumn(object, &l.Columns[i].Text)
+	}
+	for i := range l.Filters {
+		count += fr.renameColumn(object, &l.Filters[i].Field.Text)
+	}
+	return count
+}
+
+func (fr *fieldRenamer) renameCompactLayout(object string, l *compactlayout.CompactLayout) int {
+	count := 0
+	for i := range l.Fields {
+		count += fr.renameField(object, &l.Fields[i].Text)
+	}
+	return count
+}
+
+func (fr *fieldRenamer) renameFieldSet(object string, s *fieldset.FieldSet) int {
+	count := 0
+	for i := range s.AvailableFields {
+		count += fr.renameField(object, &s.AvailableFields[i].Field.Text)
+	}
+	for i := range s.DisplayedFields {
+		count += fr.renameField(object, &s.DisplayedFields[i].Field.Text)
+	}
+	return count
+}
+
+func (fr *fieldRenamer) renameRecordType(object string, r *recordtype.RecordType) int {
+	count := 0
+	for i := range r.PicklistValues {
+		count += fr.renameField(object, &r.PicklistValues[i].Picklist)
+	}
+	return count
+}
+
+func (fr *fieldRenamer) renameValidationRule(object string, r *validationrule.Rule) int {
+	count := fr.renameFormula(object, r.ErrorConditionFormula)
+	if r.ErrorDisplayField != nil {
+		count += fr.renameField(object, &r.ErrorDisplayField.Text)
+	}
+	return count
+}
+
+func (fr *fieldRenamer) renameFieldPermissions(permissions permissionset.FieldPermissionsList) int {
+	count := 0
+	for i := range permissions {
+		count += fr.renameQualified(&permissions[i].Field)
+	}
+	if count > 0 {
+		permissions.Tidy()
+	}
+	return count
+}
+
+// Layout names are prefixed with the object name, e.g. Account-Account Layout
+func (fr *fieldRenamer) renameLayout(l *layout.Layout) int {
+	object, _, _ := strings.Cut(string(l.GetMetadataInfo().Name()), "-")
+	count := 0
+	for s := range l.LayoutSections {
+		for c := range l.LayoutSections[s].LayoutColumns {
+			items := l.LayoutSections[s].LayoutColumns[c].LayoutItems
+			for i := range items {
+				if items[i].Field != nil {
+					count += fr.renameField(object, &items[i].Field.Text)
+				}
+			}
+		}
+	}
+	if l.RelatedContent != nil {
+		for i := range l.RelatedContent.RelatedContentItems {
+			if f := l.RelatedContent.RelatedContentItems[i].LayoutItem.Field; f != nil {
+				count += fr.renameField(object, &f.Text)
+			}
+		}
+	}
+	if l.SummaryLayout != nil {
+		for i := range l.SummaryLayout.SummaryLayoutItems {
+			if f := l.SummaryLayout.SummaryLayoutItems[i].Field; f != nil {
+				count += fr.renameField(object, &f.Text)
+			}
+		}
+	}
+	if l.MiniLayout != nil {
+		for i := range l.MiniLayout.Fields {
+			count += fr.renameField(object, &l.MiniLayout.Fields[i].Text)
+		}
+	}
+	return count
+}
+
+// Field items on record pages are prefixed with Record, e.g. Record.Name
+func (fr *fieldRenamer) renameFlexiPage(p *flexipage.FlexiPage) int {
+	object := p.SobjectType.String()
+	count := 0
+	for r := range p.FlexiPageRegions {
+		items := p.FlexiPageRegions[r].ItemInstances
+		for i := range items {
+			if items[i].FieldInstance == nil {
+				continue
+			}
+			item := &items[i].FieldInstance.FieldItem.Text
+			prefix, fieldName, ok := strings.Cut(*item, ".")
+			if !ok || fr.renameField(object, &fieldName) == 0 {
+				continue
+			}
+			*item = prefix + "." + fieldName
+			count++
+		}
+	}
+	return count
+}
+
+func (fr *fieldRenamer) renameFlow(f *flow.Flow) int {
+	count := 0
+	var triggerObject string
+	if f.Start != nil && f.Start.Object != nil {
+		triggerObject = *f.Start.Object
+		for i := range f.Start.Filters {
+			count += fr.renameField(triggerObject, &f.Start.Filters[i].Field.Text)
+		}
+	}
+	for i := range f.RecordLookups {
+		l := &f.RecordLookups[i]
+		for j := range l.Filters {
+			count += fr.renameField(l.Object, &l.Filters[j].Field)
+		}
+		for j := range l.QueriedFields {
+			count += fr.renameField(l.Object, &l.QueriedFields[j].Text)
+		}
+		for j := range l.OutputAssignments {
+			count += fr.renameField(l.Object, &l.OutputAssignments[j].Field.Text)
+		}
+		if l.SortField != nil {
+			count += fr.renameField(l.Object, &l.SortField.Text)
+		}
+	}
+	for i := range f.RecordCreates {
+		c := &f.RecordCreates[i]
+		if c.Object == nil {
+			continue
+		}
+		for j := range c.InputAssignments {
+			count += fr.renameField(c.Object.Text, &c.InputAssignments[j].Field.Text)
+		}
+	}
+	for i := range f.RecordUpdates {
+		u := &f.RecordUpdates[i]
+		var object string
+		switch {
+		case u.Object != nil:
+			object = u.Object.Text
+		case u.InputReference != nil && u.InputReference.Text == "$Record":
+			object = triggerObject
+		}
+		for j := range u.Filters {
+			count += fr.renameField(object, &u.Filters[j].Field.Text)
+		}
+		for j := range u.InputAssignments {
+			count += fr.renameField(object, &u.InputAssignments[j].Field.Text)
+		}
+	}
+	return count
+}
+
+// Workflows are named for their object
+func (fr *fieldRenamer) renameWorkflow(object string, w *workflow.Workflow) int {
+	count := 0
+	for i := range w.FieldUpdates {
+		u := &w.FieldUpdates[i]
+		target := object
+		if u.TargetObject != nil && u.TargetObject.Text != "" {
+			target = u.TargetObject.Text
+		}
+		count += fr.renameField(target, &u.Field.Text)
+		count += fr.renameFormula(object, u.Formula)
+	}
+	for i := range w.Rules {
+		r := &w.Rules[i]
+		for j := range r.CriteriaItems {
+			count += fr.renameQualified(&r.CriteriaItems[j].Field.Text)
+		}
+		count += fr.renameFormula(object, r.Formula)
+	}
+	return count
+}
+
+func (fr *fieldRenamer) renameReportType(t *reportType.ReportType) int {
+	count := 0
+	for s := range t.Sections {
+		columns := t.Sections[s].Columns
+		for i := range columns {
+			count += fr.renameField(fr.resolver.TableObject(columns[i].Table), &columns[i].Field)
+		}
+	}
+	return count
+}
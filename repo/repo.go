@@ -44,7 +44,10 @@ func (o *Repo) Open(file string) (metadata.MetadataPointer, error) {
 		o.openMetadata[metadataType] = &items
 	}
 	items := o.openMetadata[metadataType]
-	if _, exists := (*items)[name]; exists {
+	if existing, exists := (*items)[name]; exists {
+		if existing.GetMetadataInfo().Path() == m.GetMetadataInfo().Path() {
+			return existing, nil
+		}
 		log.Warnf("file %s of type %s already registered", name, metadataType)
 	}
 	(*items)[name] = m